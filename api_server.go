@@ -0,0 +1,216 @@
+package main
+
+import (
+    "context"
+    "crypto/subtle"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+)
+
+const defaultAPIServerPort = 8765
+
+// maxAPIRequestBytes bounds request bodies; the API's requests are a few small fields
+const maxAPIRequestBytes = 1 << 20
+
+// apiRun tracks a pipeline run started through the HTTP API
+type apiRun struct {
+    Status     string `json:"status"`
+    StartedAt  string `json:"startedAt"`
+    FinishedAt string `json:"finishedAt,omitempty"`
+    Error      string `json:"error,omitempty"`
+}
+
+// startAPIServer starts the local scripting API if it is enabled in settings, replacing
+// any server already running
+func (a *App) startAPIServer() error {
+    a.stopAPIServer()
+
+    settings, err := a.GetAppSettings()
+    if err != nil {
+        return fmt.Errorf("failed to get app settings: %w", err)
+    }
+    if !settings.APIServerEnabled {
+        return nil
+    }
+    if settings.APIServerToken == "" {
+        return fmt.Errorf("the local API requires a token to be set in settings")
+    }
+
+    port := settings.APIServerPort
+    if port == 0 {
+        port = defaultAPIServerPort
+    }
+
+    // Only ever reachable from this machine
+    listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+    if err != nil {
+        return fmt.Errorf("failed to listen on port %d: %w", port, err)
+    }
+
+    mux := http.NewServeMux()
+    mux.HandleFunc("POST /api/projects", a.handleAPICreateProject)
+    mux.HandleFunc("POST /api/projects/{id}/run", a.handleAPIRunPipeline)
+    mux.HandleFunc("GET /api/projects/{id}/status", a.handleAPIProjectStatus)
+
+    server := &http.Server{
+        Handler:           requireAPIToken(settings.APIServerToken, mux),
+        ReadHeaderTimeout: 10 * time.Second,
+    }
+
+    a.apiMu.Lock()
+    a.apiServer = server
+    a.apiMu.Unlock()
+
+    go func() {
+        if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+            fmt.Printf("Warning: local API server stopped: %v\n", err)
+        }
+    }()
+    fmt.Printf("Local API listening on http://%s\n", listener.Addr())
+
+    return nil
+}
+
+// stopAPIServer shuts the local API down, if it is running
+func (a *App) stopAPIServer() {
+    a.apiMu.Lock()
+    server := a.apiServer
+    a.apiServer = nil
+    a.apiMu.Unlock()
+
+    if server == nil {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+    defer cancel()
+    server.Shutdown(ctx)
+}
+
+// requireAPIToken rejects requests without "Authorization: Bearer <token>"
+func requireAPIToken(token string, next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+        if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+            writeAPIError(w, http.StatusUnauthorized, "missing or invalid token")
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+func (a *App) handleAPICreateProject(w http.ResponseWriter, r *http.Request) {
+    var request struct {
+        SourceType     string `json:"sourceType"`
+        Source         string `json:"source"`
+        TargetLanguage string `json:"targetLanguage"`
+        Name           string `json:"name"`
+    }
+    r.Body = http.MaxBytesReader(w, r.Body, maxAPIRequestBytes)
+    if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+        var tooLarge *http.MaxBytesError
+        if errors.As(err, &tooLarge) {
+            writeAPIError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", maxAPIRequestBytes))
+            return
+        }
+        writeAPIError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+        return
+    }
+
+    project, err := a.CreateProject(request.SourceType, request.Source, request.TargetLanguage, request.Name)
+    if err != nil {
+        writeAPIError(w, http.StatusBadRequest, err.Error())
+        return
+    }
+
+    writeAPIJSON(w, http.StatusCreated, project)
+}
+
+// handleAPIRunPipeline starts the full pipeline in the background; poll the status
+// endpoint for the outcome
+func (a *App) handleAPIRunPipeline(w http.ResponseWriter, r *http.Request) {
+    projectID := r.PathValue("id")
+    if _, err := a.LoadProject(projectID); err != nil {
+        writeAPIError(w, http.StatusNotFound, err.Error())
+        return
+    }
+
+    a.apiMu.Lock()
+    if run, ok := a.apiRuns[projectID]; ok && run.Status == "running" {
+        a.apiMu.Unlock()
+        writeAPIError(w, http.StatusConflict, "pipeline is already running for this project")
+        return
+    }
+    run := &apiRun{Status: "running", StartedAt: time.Now().Format(time.RFC3339)}
+    if a.apiRuns == nil {
+        a.apiRuns = make(map[string]*apiRun)
+    }
+    a.apiRuns[projectID] = run
+    status := *run
+    a.apiMu.Unlock()
+
+    go func() {
+        results, err := a.RunFullPipeline(projectID)
+
+        a.apiMu.Lock()
+        defer a.apiMu.Unlock()
+        run.FinishedAt = time.Now().Format(time.RFC3339)
+        if err != nil {
+            run.Status = "failed"
+            run.Error = err.Error()
+        } else if paused, _ := results["paused"].(bool); paused {
+            run.Status = "paused"
+        } else {
+            run.Status = "completed"
+        }
+    }()
+
+    writeAPIJSON(w, http.StatusAccepted, status)
+}
+
+func (a *App) handleAPIProjectStatus(w http.ResponseWriter, r *http.Request) {
+    projectID := r.PathValue("id")
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        writeAPIError(w, http.StatusNotFound, err.Error())
+        return
+    }
+
+    response := struct {
+        ProjectID      string         `json:"projectId"`
+        Name           string         `json:"name"`
+        CompletedSteps CompletedSteps `json:"completedSteps"`
+        Notes          string         `json:"notes,omitempty"`
+        Run            *apiRun        `json:"run,omitempty"`
+    }{
+        ProjectID:      project.ID,
+        Name:           project.Name,
+        CompletedSteps: project.CompletedSteps,
+        Notes:          project.Notes,
+    }
+
+    a.apiMu.Lock()
+    if run, ok := a.apiRuns[projectID]; ok {
+        snapshot := *run
+        response.Run = &snapshot
+    }
+    a.apiMu.Unlock()
+
+    writeAPIJSON(w, http.StatusOK, response)
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v interface{}) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+    writeAPIJSON(w, status, map[string]string{"error": message})
+}
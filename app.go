@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -11,27 +13,101 @@ import (
 
 	"crypto/rand"
     "encoding/hex"
-    "io"
     // "sort"
     // "strconv"
     "strings"
+    "sync"
     "time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // App struct
 type App struct {
 	ctx context.Context
+
+	watcherMu sync.Mutex
+	watcher   *projectWatcher
+
+	// runCtx is cancelled on shutdown to stop every tracked subprocess
+	runCtx     context.Context
+	cancelRuns context.CancelFunc
+	procMu     sync.Mutex
+	procs      map[*exec.Cmd]*os.Process
+
+	// lockPath is set while this instance holds the single-instance lock
+	lockPath string
+	// secondInstance is set when another instance held the lock at launch; Wails hands the
+	// launch over to that instance and exits, see onSecondInstanceLaunch
+	secondInstance bool
+
+	// languages caches the backends' language support after the first query
+	languagesMu sync.Mutex
+	languages   *LanguageSupport
+
+	// apiServer is the optional local HTTP API; apiRuns tracks pipelines it started
+	apiMu     sync.Mutex
+	apiServer *http.Server
+	apiRuns   map[string]*apiRun
+
+	// eventSink replaces frontend events when running headless
+	eventSink func(name string, data ...interface{})
+
+	// jobs limits how many pipeline steps run at once
+	jobs jobLimiter
+
+	// projectRuns holds each project's pipeline cancellation scope
+	projectRunsMu sync.Mutex
+	projectRuns   map[string]*projectRun
+
+	// etaRuns holds each running pipeline's ETA state; stepTimingsMu serializes writing the
+	// step timing history
+	etaRunsMu     sync.Mutex
+	etaRuns       map[string]*etaRun
+	stepTimingsMu sync.Mutex
+
+	// exports holds the cancel function of each running export, by destination path
+	exportsMu sync.Mutex
+	exports   map[string]context.CancelFunc
+
+	// scriptsHash caches the hash of the embedded Python scripts
+	scriptsHashMu sync.Mutex
+	scriptsHash   string
+
+	// transcriptUndo holds each project's undo and redo snapshots of its segments file
+	transcriptUndoMu sync.Mutex
+	transcriptUndo   map[string]*transcriptHistory
+
+	// projectSaves holds debounced project saves; projectSaveWriteMu serializes writing them
+	projectSavesMu     sync.Mutex
+	projectSaves       map[string]*queuedProjectSave
+	projectSaveWriteMu sync.Mutex
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{}
+	runCtx, cancelRuns := context.WithCancel(context.Background())
+	return &App{
+		runCtx:     runCtx,
+		cancelRuns: cancelRuns,
+		procs:      make(map[*exec.Cmd]*os.Process),
+	}
 }
 
 // OnStartup is called when the app starts up
 func (a *App) OnStartup(ctx context.Context) {
 	a.ctx = ctx
 	
+	// Wails is handing this launch to the running instance; leave the shared state alone
+	if a.secondInstance {
+		fmt.Println("Error:", errInstanceRunning)
+		wailsruntime.Quit(ctx)
+		return
+	}
+	
+	// Must happen before anything is written to the temp directory
+	a.applyTempDirSetting()
+	
 	// Extract Python scripts to temp directory
 	if tempDir, err := extractPythonScripts(); err == nil {
 		os.Setenv("KOKORO_PYTHON_DIR", tempDir)
@@ -39,6 +115,43 @@ func (a *App) OnStartup(ctx context.Context) {
 	} else {
 		fmt.Printf("Failed to extract Python scripts: %v\n", err)
 	}
+	
+	// Keep the project list in sync with changes made outside the app
+	if err := a.startProjectWatcher(); err != nil {
+		fmt.Printf("Failed to start project watcher: %v\n", err)
+	}
+	
+	if err := a.startAPIServer(); err != nil {
+		fmt.Printf("Failed to start local API: %v\n", err)
+	}
+}
+
+// OnShutdown is called when the app is closing
+func (a *App) OnShutdown(ctx context.Context) {
+	if a.secondInstance {
+		return
+	}
+	
+	// Write edits still waiting out their debounce
+	a.flushAllProjectSaves()
+
+	a.stopProjectWatcher()
+	a.stopAPIServer()
+	
+	// Stop in-flight pipelines and their subprocess trees
+	a.stopAllProcesses()
+	defer a.releaseInstanceLock()
+	
+	// Remove the extracted Python scripts unless the user wants to keep them
+	settings, err := a.GetAppSettings()
+	if err == nil && settings.KeepTempFiles {
+		return
+	}
+	if tempDir := os.Getenv("KOKORO_PYTHON_DIR"); tempDir != "" && strings.HasPrefix(tempDir, os.TempDir()) {
+		if err := os.RemoveAll(tempDir); err != nil {
+			fmt.Printf("Failed to remove Python scripts: %v\n", err)
+		}
+	}
 }
 
 // PipelineConfig represents the dubbing pipeline configuration
@@ -91,14 +204,9 @@ func getPythonCommand() string {
 // RunDubbingPipeline executes the Python dubbing pipeline
 func (a *App) RunDubbingPipeline(config PipelineConfig) (string, error) {
 	// Get the Python scripts directory (either embedded temp or local dev)
-	pythonDir := os.Getenv("KOKORO_PYTHON_DIR")
-	if pythonDir == "" {
-		// Fallback for development - get absolute path
-		workDir, _ := os.Getwd()
-		pythonDir = filepath.Join(workDir, "python")
-	}
+	pythonDir := a.getPythonScriptsDir()
 	
-	scriptPath := filepath.Join(pythonDir, "dubbing_pipeline.py")
+	scriptPath := filepath.Join(pythonDir, dubbingPipelineScript)
 	
 	// Debug: Print paths
 	fmt.Printf("Working directory: %s\n", pythonDir)
@@ -118,7 +226,7 @@ func (a *App) RunDubbingPipeline(config PipelineConfig) (string, error) {
 	
 	fmt.Printf("Python command: %s\n", pythonCmd)
 	
-	// Prepare command - dubbing_pipeline.py expects URL and target language
+	// dubbing_pipeline.py needs a YouTube URL
 	var sourceUrl string
 	if config.VideoURL != "" {
 		sourceUrl = config.VideoURL
@@ -127,11 +235,6 @@ func (a *App) RunDubbingPipeline(config PipelineConfig) (string, error) {
 		return "", fmt.Errorf("full pipeline currently only supports YouTube URLs")
 	}
 	
-	cmd := exec.Command(pythonCmd, scriptPath, sourceUrl, config.TargetLang)
-	
-	// Set working directory to Python scripts directory
-	cmd.Dir = pythonDir
-	
 	// IMPORTANT: Set output directories to project location, not temp directory
 	// Get the project's output directory (should be passed from frontend)
 	outputDir := config.OutputDir
@@ -141,38 +244,39 @@ func (a *App) RunDubbingPipeline(config PipelineConfig) (string, error) {
 		outputDir = filepath.Join(workDir, "output")
 	}
 	
+	// The scripts directory holds the pipeline's own data folders, and is deleted on exit
+	// when extracted to a temp directory
+	if err := validateOutputDir(outputDir, pythonDir); err != nil {
+		return "", err
+	}
+	
 	// Ensure output directory exists
 	os.MkdirAll(outputDir, 0755)
 	
-	// Set environment variables for configuration
-	env := append(os.Environ(),
-        // Override config directories with environment variables if provided
-		// Override Python script's default directories
-		fmt.Sprintf("KOKORO_VIDEO_OUTPUT_DIR=%s", filepath.Join(outputDir, "videos")),
-		fmt.Sprintf("KOKORO_AUDIO_OUTPUT_DIR=%s", filepath.Join(outputDir, "audio")),
-		fmt.Sprintf("KOKORO_TRANSCRIPT_OUTPUT_DIR=%s", filepath.Join(outputDir, "transcripts")),
-		fmt.Sprintf("OUTPUT_DIR=%s", outputDir),
-		fmt.Sprintf("AUDIO_SETTINGS=%s", marshalToJSON(config.AudioSettings)),
-
+	// Pass the whole configuration as one JSON file rather than a pile of env vars,
+	// which are size-limited on some platforms
+	invocation, cleanup, err := newPipelineInvocation(pythonDir, config, outputDir)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	
+	cmd := a.trackedCommand(pythonCmd, invocation.Args()...)
+	defer a.untrackCommand(cmd)
+	
+	// Set working directory to Python scripts directory
+	cmd.Dir = pythonDir
+	
+	cmd.Env = append(os.Environ(),
         // Add API keys
         fmt.Sprintf("ANTHROPIC_API_KEY=%s", ""),
         fmt.Sprintf("HF_TOKEN=%s", ""),
 	)
 	
-	// Add text and segment rules as environment variables if they exist
-	if len(config.TextRules) > 0 {
-		env = append(env, fmt.Sprintf("TEXT_RULES=%s", marshalToJSON(config.TextRules)))
-	}
-	if len(config.SegmentRules) > 0 {
-		env = append(env, fmt.Sprintf("SEGMENT_RULES=%s", marshalToJSON(config.SegmentRules)))
-	}
-	
-	cmd.Env = env
-	
 	// Execute command with timeout (dubbing can take a while)
 	fmt.Printf("🚀 Starting dubbing pipeline for: %s\n", sourceUrl)
 	fmt.Printf("📁 Output directory: %s\n", outputDir)
-	output, err := cmd.CombinedOutput()
+	_, output, _, err := a.runStreamingCommand(cmd, "", "full", filepath.Join(outputDir, "pipeline.log"))
 	
 	if err != nil {
 		return "", fmt.Errorf("pipeline execution failed: %v\nOutput: %s", err, string(output))
@@ -183,15 +287,58 @@ func (a *App) RunDubbingPipeline(config PipelineConfig) (string, error) {
 	return string(output), nil
 }
 
+// pipelineRunConfig is the file handed to dubbing_pipeline.py via --config
+type pipelineRunConfig struct {
+	PipelineConfig
+	VideoOutputDir      string `json:"videoOutputDir"`
+	AudioOutputDir      string `json:"audioOutputDir"`
+	TranscriptOutputDir string `json:"transcriptOutputDir"`
+}
+
+// writePipelineRunConfig writes the run configuration to a temp file and returns its path.
+// The caller is responsible for removing it.
+func writePipelineRunConfig(config PipelineConfig, outputDir string) (string, error) {
+	config.OutputDir = outputDir
+	runConfig := pipelineRunConfig{
+		PipelineConfig:      config,
+		VideoOutputDir:      filepath.Join(outputDir, "videos"),
+		AudioOutputDir:      filepath.Join(outputDir, "audio"),
+		TranscriptOutputDir: filepath.Join(outputDir, "transcripts"),
+	}
+	
+	// Running with silently emptied settings is worse than not running
+	data, err := json.MarshalIndent(runConfig, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize pipeline config: %w", err)
+	}
+	
+	file, err := os.CreateTemp("", "kokoro-pipeline-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create pipeline config file: %w", err)
+	}
+	defer file.Close()
+	
+	if _, err := file.Write(data); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write pipeline config file: %w", err)
+	}
+	
+	return file.Name(), nil
+}
+
 // SynthesizeVoice calls the Kokoro API for voice synthesis
 func (a *App) SynthesizeVoice(request VoiceRequest) ([]byte, error) {
-	pythonDir := os.Getenv("KOKORO_PYTHON_DIR")
-	if pythonDir == "" {
-		pythonDir = filepath.Join(".", "python")
-	}
+	pythonDir := a.getPythonScriptsDir()
 	
 	scriptPath := filepath.Join(pythonDir, "synthesize_voice.py")
 	
+	usesMarkup := containsMarkup(request.Input)
+	if usesMarkup {
+		if err := a.ValidateSSML(request.Input); err != nil {
+			return nil, err
+		}
+	}
+	
 	// Marshal request to JSON
 	requestJSON, err := json.Marshal(request)
 	if err != nil {
@@ -199,10 +346,14 @@ func (a *App) SynthesizeVoice(request VoiceRequest) ([]byte, error) {
 	}
 	
 	pythonCmd := getPythonCommand()
-	cmd := exec.Command(pythonCmd, scriptPath, string(requestJSON))
+	cmd := a.trackedCommand(pythonCmd, scriptPath, string(requestJSON))
+	defer a.untrackCommand(cmd)
 	cmd.Dir = pythonDir
+	if usesMarkup {
+		cmd.Env = append(os.Environ(), "SYNTHESIS_MARKUP=1")
+	}
 	
-	output, err := cmd.CombinedOutput()
+	output, err := a.commandCombinedOutput(cmd)
 	if err != nil {
 		return nil, fmt.Errorf("voice synthesis failed: %v", err)
 	}
@@ -283,12 +434,12 @@ func (a *App) SaveProject(name string, config map[string]interface{}) error {
 // }
 
 // Helper function to marshal data to JSON string
-func marshalToJSON(data interface{}) string {
+func marshalToJSON(data interface{}) (string, error) {
 	bytes, err := json.Marshal(data)
 	if err != nil {
-		return "{}"
+		return "", err
 	}
-	return string(bytes)
+	return string(bytes), nil
 }
 
 // ## PROJECT RELATED TYPES
@@ -301,17 +452,40 @@ type ProjectConfig struct {
     Version         int                    `json:"version"`
     SourceType      string                 `json:"sourceType"`
     SourceUrl       *string                `json:"sourceUrl,omitempty"`
+    // Platform is the site a URL source is on, see detectPlatform. Projects from before
+    // it existed are all YouTube.
+    Platform        string                 `json:"platform,omitempty"`
     VideoId         *string                `json:"videoId,omitempty"`
     OriginalFilename *string               `json:"originalFilename,omitempty"`
     TargetLanguage  string                 `json:"targetLanguage"`
+    OutputDir       *string                `json:"outputDir,omitempty"`
+    PauseAfterStep  []string               `json:"pauseAfterStep,omitempty"`
+    PausedAfter     *string                `json:"pausedAfter,omitempty"`
+    UsesMarkup      bool                   `json:"usesMarkup,omitempty"`
+    Notes           string                 `json:"notes,omitempty"`
+    Category        string                 `json:"category,omitempty"`
+    // Locale decides how dates and numbers are written in outputs, see localeFormats
+    Locale          string                 `json:"locale,omitempty"`
+    // DownloadFormat is the quality fetched for URL sources, see downloadFormats
+    DownloadFormat  string                 `json:"downloadFormat,omitempty"`
+    // TrimStart and TrimEnd limit dubbing to part of the source, in seconds
+    TrimStart       *float64               `json:"trimStart,omitempty"`
+    TrimEnd         *float64               `json:"trimEnd,omitempty"`
+    // AttachedVideo marks a VideoFile added to an audio project by AttachVideo: the audio
+    // stays the source and the video is only used when combining
+    AttachedVideo   bool                   `json:"attachedVideo,omitempty"`
+    // EnvOverrides are extra environment variables for this project's pipeline steps
+    EnvOverrides    map[string]string      `json:"envOverrides,omitempty"`
     CompletedSteps  CompletedSteps         `json:"completedSteps"`
     FileReferences  FileReferences         `json:"fileReferences"`
     Settings        ProjectSettings        `json:"settings"`
     TextRules       []TextRule             `json:"textRules"`
     SegmentRules    []SegmentRule          `json:"segmentRules"`
+    StepCache       map[string]StepCacheEntry `json:"stepCache,omitempty"`
 }
 
 type CompletedSteps struct {
+    // Download also records a local project's import step, which replaced it
     Download   bool `json:"download"`
     Transcribe bool `json:"transcribe"`
     Translate  bool `json:"translate"`
@@ -325,6 +499,8 @@ type FileReferences struct {
     SegmentsFile *string        `json:"segmentsFile,omitempty"`
     FinalAudio   *string        `json:"finalAudio,omitempty"`
     FinalVideo   *string        `json:"finalVideo,omitempty"`
+    // TrimmedFile is the source clipped to TrimStart/TrimEnd, relative to the project
+    TrimmedFile  *string        `json:"trimmedFile,omitempty"`
 }
 
 type FileReference struct {
@@ -339,14 +515,22 @@ type ProjectSettings struct {
     Transcription TranscriptionSettings `json:"transcription"`
     Translation   TranslationSettings   `json:"translation"`
     Audio         AudioSettings         `json:"audio"`
+    Synthesis     SynthesisSettings     `json:"synthesis"`
     Cleanup       CleanupSettings       `json:"cleanup"`
+    Output        OutputSettings        `json:"output"`
 }
 
 type TranscriptionSettings struct {
+    // Source is the transcription backend, see transcriptionBackends
     Source             string  `json:"source"`
     EnableDiarization  bool    `json:"enableDiarization"`
     Language           string  `json:"language"`
     Model              *string `json:"model,omitempty"`
+    // ComputeType and BeamSize tune the local backends that accept them
+    ComputeType        string  `json:"computeType,omitempty"`
+    BeamSize           int     `json:"beamSize,omitempty"`
+    // SourceAudioStream picks the audio track of multi-track sources (0 = first audio stream)
+    SourceAudioStream  *int    `json:"sourceAudioStream,omitempty"`
 }
 
 type TranslationSettings struct {
@@ -354,6 +538,7 @@ type TranslationSettings struct {
     SimpleModel      string                    `json:"simpleModel"`
     CloudProvider    *string                   `json:"cloudProvider,omitempty"`
     AdvancedSettings *AdvancedTranslationSettings `json:"advancedSettings,omitempty"`
+    Glossary         []GlossaryEntry              `json:"glossary,omitempty"`
 }
 
 type AdvancedTranslationSettings struct {
@@ -362,6 +547,16 @@ type AdvancedTranslationSettings struct {
     Models        []string `json:"models"`
 }
 
+// GlossaryEntry pins the translation of a source term (brand or character names, etc.)
+type GlossaryEntry struct {
+    ID            string `json:"id"`
+    SourceTerm    string `json:"sourceTerm"`
+    TargetTerm    string `json:"targetTerm"`
+    CaseSensitive bool   `json:"caseSensitive"`
+    Notes         string `json:"notes,omitempty"`
+    CreatedAt     string `json:"createdAt"`
+}
+
 type AudioSettings struct {
     PreventOverlaps    bool   `json:"preventOverlaps"`
     MinGap             int    `json:"minGap"`
@@ -370,11 +565,23 @@ type AudioSettings struct {
     EffectsPreset      string `json:"effectsPreset"`
 }
 
+type SynthesisSettings struct {
+    // Voice is used for every speaker when set; otherwise each speaker keeps its default voice
+    Voice string `json:"voice,omitempty"`
+    // Seed makes synthesis reproducible; when nil a seed is picked per run and recorded in the step result
+    Seed  *int   `json:"seed,omitempty"`
+}
+
 type CleanupSettings struct {
     Mode                  string `json:"mode"`
     KeepIntermediateFiles bool   `json:"keepIntermediateFiles"`
 }
 
+type OutputSettings struct {
+    FilenameTemplate string `json:"filenameTemplate"`
+    Format           string `json:"format"`
+}
+
 type TextRule struct {
     ID              string `json:"id"`
     OriginalText    string `json:"originalText"`
@@ -382,6 +589,9 @@ type TextRule struct {
     Language        string `json:"language"`
     Priority        string `json:"priority"`
     CaseSensitive   bool   `json:"caseSensitive"`
+    // IsRegex makes OriginalText a regular expression; ReplacementText can then refer to
+    // its groups as $1 or ${name}
+    IsRegex         bool   `json:"isRegex,omitempty"`
     CreatedAt       string `json:"createdAt"`
 }
 
@@ -395,12 +605,33 @@ type SegmentRule struct {
 }
 
 type AppSettings struct {
-    DefaultProjectsPath string   `json:"defaultProjectsPath"`
-    AutoCleanup         string   `json:"autoCleanup"`
-    ShowOnboarding      bool     `json:"showOnboarding"`
-    RecentProjects      []string `json:"recentProjects"`
-    ExportLocation      string   `json:"exportLocation"`
-    CustomExportPath    *string  `json:"customExportPath,omitempty"`
+    DefaultProjectsPath  string   `json:"defaultProjectsPath"`
+    AutoCleanup          string   `json:"autoCleanup"`
+    ShowOnboarding       bool     `json:"showOnboarding"`
+    RecentProjects       []string `json:"recentProjects"`
+    ExportLocation       string   `json:"exportLocation"`
+    CustomExportPath     *string  `json:"customExportPath,omitempty"`
+    HistoryDepth         int      `json:"historyDepth,omitempty"`
+    KeepTempFiles        bool     `json:"keepTempFiles,omitempty"`
+    CompletionWebhookURL *string  `json:"completionWebhookUrl,omitempty"`
+    APIServerEnabled     bool     `json:"apiServerEnabled,omitempty"`
+    APIServerPort        int      `json:"apiServerPort,omitempty"`
+    APIServerToken       string   `json:"apiServerToken,omitempty"`
+    TempDir              *string  `json:"tempDir,omitempty"`
+    DownloadRateLimit    *string  `json:"downloadRateLimit,omitempty"`
+    Device               string   `json:"device,omitempty"`
+    // DedupeInputs shares one copy of identical input files between projects, see inputsCacheDir
+    DedupeInputs         bool     `json:"dedupeInputs,omitempty"`
+    // MaxConcurrentJobs bounds pipeline steps running at once across projects;
+    // MaxConcurrentGPUJobs is the lower bound for transcription and synthesis
+    MaxConcurrentJobs    int      `json:"maxConcurrentJobs,omitempty"`
+    MaxConcurrentGPUJobs int      `json:"maxConcurrentGpuJobs,omitempty"`
+    // LogLevel is quiet, normal, verbose or debug; empty means normal
+    LogLevel             string   `json:"logLevel,omitempty"`
+    // DefaultProjectSettings are what new projects start with, instead of the built-in defaults
+    DefaultProjectSettings *ProjectSettings `json:"defaultProjectSettings,omitempty"`
+    // CustomVoicesPath holds extra Kokoro voice packs, see scanCustomVoices
+    CustomVoicesPath     *string  `json:"customVoicesPath,omitempty"`
 }
 
 // ## PROJECT RELATED FUNCTIONS
@@ -431,6 +662,23 @@ func (a *App) GetDefaultProjectsPath() (string, error) {
 
 // CreateProject creates a new project with the given configuration
 func (a *App) CreateProject(sourceType string, source string, targetLang string, customName string) (*ProjectConfig, error) {
+    return a.CreateProjectInCategory(sourceType, source, targetLang, customName, "")
+}
+
+// CreateProjectInCategory creates a project inside a category folder of the projects directory.
+// An empty category places it at the top level.
+func (a *App) CreateProjectInCategory(sourceType string, source string, targetLang string, customName string, category string) (*ProjectConfig, error) {
+    // The language ends up in the folder name, so a blank one would leave "[]" behind
+    targetLang = strings.TrimSpace(targetLang)
+    if targetLang == "" {
+        return nil, fmt.Errorf("target language is required")
+    }
+    
+    category, err := sanitizeCategory(category)
+    if err != nil {
+        return nil, err
+    }
+    
     // Generate unique project ID
     projectID, err := generateProjectID()
     if err != nil {
@@ -443,26 +691,30 @@ func (a *App) CreateProject(sourceType string, source string, targetLang string,
         return nil, fmt.Errorf("failed to get app settings: %w", err)
     }
     
-    projectsDir := settings.DefaultProjectsPath
+    projectsDir := filepath.Join(settings.DefaultProjectsPath, category)
     if err := os.MkdirAll(projectsDir, 0755); err != nil {
         return nil, fmt.Errorf("failed to create projects directory: %w", err)
     }
     
     // Determine project name and video ID
-    var displayName, videoID string
+    var displayName, videoID, platform string
     var fileRef *FileReference
     
     switch sourceType {
     case "youtube":
-        videoID = extractVideoID(source)
+        // Any site yt-dlp can download from, not just YouTube
+        source = strings.TrimSpace(source)
+        platform, videoID = detectPlatform(source)
         if videoID == "" {
-            return nil, fmt.Errorf("invalid YouTube URL: %s", source)
+            return nil, fmt.Errorf("invalid video URL: %s", source)
         }
         // Use custom name if provided, otherwise default
         if customName != "" {
             displayName = customName
-        } else {
+        } else if platform == "youtube" {
             displayName = fmt.Sprintf("YouTube Video %s", videoID)
+        } else {
+            displayName = fmt.Sprintf("%s Video %s", platformDisplayName(platform), strings.TrimPrefix(videoID, platform+"_"))
         }
     case "video", "audio":
         // Generate local ID
@@ -477,6 +729,10 @@ func (a *App) CreateProject(sourceType string, source string, targetLang string,
             displayName = baseName
         }
         
+        if err := a.validateSourceMedia(sourceType, source); err != nil {
+            return nil, err
+        }
+        
         // Create file reference (linked initially)
         fileInfo, err := os.Stat(source)
         if err != nil {
@@ -503,14 +759,20 @@ func (a *App) CreateProject(sourceType string, source string, targetLang string,
         videoID, 
         strings.ToUpper(targetLang))
     
-    // Handle name clashes
-    folderName, version := resolveProjectNameClash(projectsDir, folderName)
-    
-    // Create project directory
-    projectDir := filepath.Join(projectsDir, folderName)
-    if err := os.MkdirAll(projectDir, 0755); err != nil {
+    // Create project directory, handling name clashes
+    folderName, version, err := resolveProjectNameClash(projectsDir, folderName)
+    if err != nil {
         return nil, fmt.Errorf("failed to create project directory: %w", err)
     }
+    projectDir := filepath.Join(projectsDir, folderName)
+    
+    // Don't leave a folder without a config behind if anything below fails
+    created := false
+    defer func() {
+        if !created {
+            os.RemoveAll(projectDir)
+        }
+    }()
     
     // Create subdirectories
     subdirs := []string{"input", "transcripts", "audio", "output"}
@@ -530,30 +792,10 @@ func (a *App) CreateProject(sourceType string, source string, targetLang string,
         Version:      version,
         SourceType:   sourceType,
         TargetLanguage: targetLang,
+        Category:       category,
         CompletedSteps: CompletedSteps{},
         FileReferences: FileReferences{},
-        Settings: ProjectSettings{
-            Transcription: TranscriptionSettings{
-                Source:            "whisperx",
-                EnableDiarization: true,
-                Language:          "en",
-            },
-            Translation: TranslationSettings{
-                Mode:        "simple",
-                SimpleModel: "m2m100_418m",
-            },
-            Audio: AudioSettings{
-                PreventOverlaps:   true,
-                MinGap:           100,
-                GlobalCrossfade:  false,
-                CrossfadeDuration: 150,
-                EffectsPreset:    "voice",
-            },
-            Cleanup: CleanupSettings{
-                Mode:                  "auto",
-                KeepIntermediateFiles: false,
-            },
-        },
+        Settings:       a.newProjectSettings(),
         TextRules:    []TextRule{},
         SegmentRules: []SegmentRule{},
     }
@@ -562,6 +804,7 @@ func (a *App) CreateProject(sourceType string, source string, targetLang string,
     if sourceType == "youtube" {
         project.SourceUrl = &source
         project.VideoId = &videoID
+        project.Platform = platform
     } else {
         filename := filepath.Base(source)
         project.OriginalFilename = &filename
@@ -578,43 +821,179 @@ func (a *App) CreateProject(sourceType string, source string, targetLang string,
     if err := a.saveProjectConfig(projectDir, project); err != nil {
         return nil, fmt.Errorf("failed to save project config: %w", err)
     }
+    created = true
     
     // Update recent projects
     if err := a.addToRecentProjects(projectID); err != nil {
         fmt.Printf("Warning: failed to update recent projects: %v\n", err)
     }
     
+    a.emitEvent("project:created", projectID)
+    
     return project, nil
 }
 
+// defaultProjectSettings returns the settings new projects start with
+func defaultProjectSettings() ProjectSettings {
+    return ProjectSettings{
+        Transcription: TranscriptionSettings{
+            Source:            "whisperx",
+            EnableDiarization: true,
+            Language:          "en",
+        },
+        Translation: TranslationSettings{
+            Mode:        "simple",
+            SimpleModel: "m2m100_418m",
+        },
+        Audio: AudioSettings{
+            PreventOverlaps:   true,
+            MinGap:           100,
+            GlobalCrossfade:   false,
+            CrossfadeDuration: 150,
+            EffectsPreset:     "voice",
+        },
+        Cleanup: CleanupSettings{
+            Mode:                  "auto",
+            KeepIntermediateFiles: false,
+        },
+        Output: OutputSettings{
+            FilenameTemplate: defaultOutputFilenameTemplate,
+            Format:           defaultOutputFormat,
+        },
+    }
+}
+
+// maxFilenameRunes keeps generated folder names well clear of path length limits
+const maxFilenameRunes = 50
+
+// windowsReservedNames can't be used as a file or folder name on Windows, with any extension
+var windowsReservedNames = map[string]bool{
+    "CON": true, "PRN": true, "AUX": true, "NUL": true,
+    "COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+    "LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// unsafeFilenameRune reports whether r can't appear in a file or folder name on some platform
+func unsafeFilenameRune(r rune) bool {
+    return strings.ContainsRune(`/\:*?"<>|`, r) || isControlRune(r)
+}
+
+func isControlRune(r rune) bool {
+    return r < 0x20 || r == 0x7f
+}
+
 // Helper function to sanitize filename
 func sanitizeForFilename(name string) string {
+    name = strings.ToValidUTF8(name, "-")
+    
     // Remove/replace characters that are problematic in filenames
-    name = strings.ReplaceAll(name, "/", "-")
-    name = strings.ReplaceAll(name, "\\", "-")
-    name = strings.ReplaceAll(name, ":", "-")
-    name = strings.ReplaceAll(name, "*", "-")
-    name = strings.ReplaceAll(name, "?", "-")
-    name = strings.ReplaceAll(name, "\"", "-")
-    name = strings.ReplaceAll(name, "<", "-")
-    name = strings.ReplaceAll(name, ">", "-")
-    name = strings.ReplaceAll(name, "|", "-")
+    name = strings.Map(func(r rune) rune {
+        switch {
+        case isControlRune(r):
+            return -1
+        case unsafeFilenameRune(r):
+            return '-'
+        }
+        return r
+    }, name)
+    
+    // Limit length to avoid path issues, cutting on a character boundary
+    runes := []rune(name)
+    if len(runes) > maxFilenameRunes {
+        runes = runes[:maxFilenameRunes]
+        // Don't leave a dangling joiner or variation selector from a split emoji sequence
+        for len(runes) > 0 && (runes[len(runes)-1] == '\u200d' || runes[len(runes)-1] == '\ufe0f') {
+            runes = runes[:len(runes)-1]
+        }
+        name = string(runes)
+    }
+    
+    // Windows drops trailing dots and spaces, and leading dots hide the folder elsewhere
+    name = strings.Trim(name, " .")
+    
+    stem := name
+    if i := strings.IndexByte(stem, '.'); i >= 0 {
+        stem = stem[:i]
+    }
+    if windowsReservedNames[strings.ToUpper(strings.TrimSpace(stem))] {
+        name = "_" + name
+    }
     
-    // Limit length to avoid path issues
-    if len(name) > 50 {
-        name = name[:50]
+    if name == "" {
+        name = "Untitled"
     }
     
-    return strings.TrimSpace(name)
+    return name
 }
 
 // LoadProject loads a project by ID
 func (a *App) LoadProject(projectID string) (*ProjectConfig, error) {
+    a.flushProjectSave(projectID)
+    
     projectDir, err := a.findProjectDirectory(projectID)
     if err != nil {
         return nil, fmt.Errorf("project not found: %w", err)
     }
     
+    project, err := readProjectConfig(projectDir)
+    if err != nil {
+        return nil, err
+    }
+    
+    if err := validateProjectConfig(project); err != nil {
+        return nil, err
+    }
+    
+    return project, nil
+}
+
+// GetProjectByFolderName loads a project directly from its folder under the projects root
+func (a *App) GetProjectByFolderName(folderName string) (*ProjectConfig, error) {
+    projectDir, err := a.resolveProjectFolder(folderName)
+    if err != nil {
+        return nil, err
+    }
+    
+    configPath := filepath.Join(projectDir, "project.json")
+    if _, err := os.Stat(configPath); os.IsNotExist(err) {
+        return nil, fmt.Errorf("project.json not found in folder: %s", folderName)
+    }
+    
+    return readProjectConfig(projectDir)
+}
+
+// resolveProjectFolder returns the absolute path of a folder inside the projects root, named
+// either directly or as "<category>/<folder>" for one inside a category folder. Names that
+// would escape the root are rejected.
+func (a *App) resolveProjectFolder(folderName string) (string, error) {
+    settings, err := a.GetAppSettings()
+    if err != nil {
+        return "", fmt.Errorf("failed to get app settings: %w", err)
+    }
+    
+    parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(folderName), `\`, "/"), "/")
+    if len(parts) > 2 {
+        return "", fmt.Errorf("invalid project folder name: %s", folderName)
+    }
+    for _, part := range parts {
+        if part == "" || part == "." || part == ".." || filepath.IsAbs(part) || filepath.VolumeName(part) != "" {
+            return "", fmt.Errorf("invalid project folder name: %s", folderName)
+        }
+    }
+    
+    projectDir := filepath.Join(append([]string{settings.DefaultProjectsPath}, parts...)...)
+    info, err := os.Stat(projectDir)
+    if err != nil {
+        return "", fmt.Errorf("project folder not found: %s", folderName)
+    }
+    if !info.IsDir() {
+        return "", fmt.Errorf("not a project folder: %s", folderName)
+    }
+    
+    return projectDir, nil
+}
+
+func readProjectConfig(projectDir string) (*ProjectConfig, error) {
     configPath := filepath.Join(projectDir, "project.json")
     data, err := os.ReadFile(configPath)
     if err != nil {
@@ -631,14 +1010,110 @@ func (a *App) LoadProject(projectID string) (*ProjectConfig, error) {
 
 // UpdateProject updates an existing project configuration
 func (a *App) UpdateProject(project *ProjectConfig) error {
+    // This save is newer than any queued one, and mustn't be overtaken by one being written
+    a.projectSaveWriteMu.Lock()
+    defer a.projectSaveWriteMu.Unlock()
+    a.dropQueuedProjectSave(project.ID)
+    
+    return a.updateProject(project)
+}
+
+func (a *App) updateProject(project *ProjectConfig) error {
     projectDir, err := a.findProjectDirectory(project.ID)
     if err != nil {
         return fmt.Errorf("project not found: %w", err)
     }
     
+    if err := validateTextRules(project.TextRules); err != nil {
+        return err
+    }
+    if err := validateTranscriptionSettings(project.Settings.Transcription); err != nil {
+        return fmt.Errorf("invalid transcription settings: %w", err)
+    }
+    if err := a.ValidateTranslationSettings(project.Settings.Translation); err != nil {
+        return fmt.Errorf("invalid translation settings: %w", err)
+    }
+    if err := validateTranslationProvider(project.Settings.Translation, project.TargetLanguage); err != nil {
+        return fmt.Errorf("invalid translation settings: %w", err)
+    }
+    if _, err := renderOutputFilename(project.Settings.Output.FilenameTemplate, project); err != nil {
+        return fmt.Errorf("invalid output filename template: %w", err)
+    }
+    if err := validateOutputFormat(project.Settings.Output.Format); err != nil {
+        return err
+    }
+    if err := validatePauseSteps(project.PauseAfterStep); err != nil {
+        return err
+    }
+    if err := validateDownloadFormat(project); err != nil {
+        return err
+    }
+    if err := validateEnvOverrides(project.EnvOverrides); err != nil {
+        return err
+    }
+    if err := validateSynthesisSeed(project.Settings.Synthesis.Seed); err != nil {
+        return err
+    }
+    if err := validateLocale(project.Locale); err != nil {
+        return err
+    }
+    if project.Locale != "" {
+        project.Locale = normalizeLocale(project.Locale)
+    }
+    // Checks below that query a backend or probe the source only run when their inputs could
+    // have changed. Read the file directly, since LoadProject would wait on the save calling this.
+    previous, err := readProjectConfig(projectDir)
+    if err != nil {
+        previous = nil
+    }
+    if voice := project.Settings.Synthesis.Voice; voice != "" {
+        if previous == nil || previous.Settings.Synthesis.Voice != voice || previous.TargetLanguage != project.TargetLanguage {
+            if err := a.ValidateVoiceLanguage(voice, project.TargetLanguage); err != nil {
+                return fmt.Errorf("invalid synthesis voice: %w", err)
+            }
+        }
+    }
+    if previous == nil || !sameInt(previous.Settings.Transcription.SourceAudioStream, project.Settings.Transcription.SourceAudioStream) {
+        if err := a.validateSourceAudioStream(projectDir, project); err != nil {
+            return err
+        }
+    }
+    if previous == nil || trimInputsChanged(projectDir, previous, project) {
+        if err := a.validateTrim(projectDir, project); err != nil {
+            return err
+        }
+    }
+    if project.OutputDir != nil {
+        if err := validateOutputDir(*project.OutputDir, projectInputDirs(projectDir)...); err != nil {
+            return err
+        }
+    }
+    
     project.LastModified = time.Now().Format(time.RFC3339)
     
-    return a.saveProjectConfig(projectDir, project)
+    if err := a.saveProjectConfig(projectDir, project); err != nil {
+        return err
+    }
+    
+    a.emitEvent("project:updated", project.ID)
+    return nil
+}
+
+const maxProjectNotesLength = 20000
+
+// SetProjectNotes replaces a project's freeform notes
+func (a *App) SetProjectNotes(projectID, notes string) error {
+    if len(notes) > maxProjectNotesLength {
+        return fmt.Errorf("notes are too long (%d characters, maximum %d)", len(notes), maxProjectNotesLength)
+    }
+    
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    
+    project.Notes = notes
+    return a.UpdateProject(project)
 }
 
 // GetRecentProjects returns the list of recent projects
@@ -673,6 +1148,12 @@ func (a *App) CopyLinkedFilesToProject(projectID string) error {
         return fmt.Errorf("project directory not found: %w", err)
     }
     
+    // Copying a changed file would silently bake different media into the project
+    if changes := linkedSourceChanges(project); len(changes) > 0 {
+        a.emitEvent("project:source-changed", projectID, changes)
+        return sourceChangedError(changes)
+    }
+    
     updated := false
     
     // Copy video file if linked
@@ -730,21 +1211,25 @@ func extractVideoID(url string) string {
     return ""
 }
 
-func resolveProjectNameClash(projectsDir, baseName string) (string, int) {
+// resolveProjectNameClash creates a new folder for baseName, adding " (2)", " (3)", ... if
+// the name is taken. os.Mkdir fails if the folder exists, so two concurrent calls can never
+// claim the same folder. Returns the name of the folder actually created.
+func resolveProjectNameClash(projectsDir, baseName string) (string, int, error) {
     version := 1
     projectName := baseName
     
     for {
-        projectPath := filepath.Join(projectsDir, projectName)
-        if _, err := os.Stat(projectPath); os.IsNotExist(err) {
-            break
+        err := os.Mkdir(filepath.Join(projectsDir, projectName), 0755)
+        if err == nil {
+            return projectName, version, nil
+        }
+        if !os.IsExist(err) {
+            return "", 0, err
         }
         
         version++
         projectName = fmt.Sprintf("%s (%d)", baseName, version)
     }
-    
-    return projectName, version
 }
 
 func (a *App) saveProjectConfig(projectDir string, project *ProjectConfig) error {
@@ -754,7 +1239,36 @@ func (a *App) saveProjectConfig(projectDir string, project *ProjectConfig) error
         return fmt.Errorf("failed to marshal project config: %w", err)
     }
     
-    return os.WriteFile(configPath, data, 0644)
+    // Keep the previous version around so settings changes can be undone
+    if err := a.archiveProjectConfig(projectDir); err != nil {
+        fmt.Printf("Warning: failed to archive project config: %v\n", err)
+    }
+    
+    // Write then rename so a crash mid-save can't leave a truncated project.json
+    tmpPath := configPath + ".tmp"
+    if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+        return err
+    }
+    if err := os.Rename(tmpPath, configPath); err != nil {
+        os.Remove(tmpPath)
+        return err
+    }
+    return nil
+}
+
+// GetProjectConfigPath returns the absolute path of a project's project.json, for tools
+// that read project configs directly
+func (a *App) GetProjectConfigPath(projectID string) (string, error) {
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return "", err
+    }
+
+    configPath, err := filepath.Abs(filepath.Join(projectDir, "project.json"))
+    if err != nil {
+        return "", fmt.Errorf("failed to resolve project config path: %w", err)
+    }
+    return configPath, nil
 }
 
 func (a *App) findProjectDirectory(projectID string) (string, error) {
@@ -765,18 +1279,13 @@ func (a *App) findProjectDirectory(projectID string) (string, error) {
     
     projectsDir := settings.DefaultProjectsPath
     
-    // Walk through project directories to find matching ID
-    entries, err := os.ReadDir(projectsDir)
+    // Walk through project directories (including those in categories) to find matching ID
+    projectDirs, err := projectFolders(projectsDir)
     if err != nil {
         return "", fmt.Errorf("failed to read projects directory: %w", err)
     }
     
-    for _, entry := range entries {
-        if !entry.IsDir() {
-            continue
-        }
-        
-        projectDir := filepath.Join(projectsDir, entry.Name())
+    for _, projectDir := range projectDirs {
         configPath := filepath.Join(projectDir, "project.json")
         
         data, err := os.ReadFile(configPath)
@@ -806,29 +1315,31 @@ func (a *App) copyFileToProject(projectDir, subdir string, fileRef *FileReferenc
     filename := filepath.Base(srcPath)
     destPath := filepath.Join(projectDir, subdir, filename)
     
-    // Copy file
-    src, err := os.Open(srcPath)
-    if err != nil {
-        return fmt.Errorf("failed to open source file: %w", err)
-    }
-    defer src.Close()
-    
-    dest, err := os.Create(destPath)
+    // Share one copy between projects made from the same file when deduplication is on
+    deduped, err := a.dedupeInputFile(srcPath, destPath)
     if err != nil {
-        return fmt.Errorf("failed to create destination file: %w", err)
+        fmt.Printf("Warning: input deduplication failed, copying instead: %v\n", err)
     }
-    defer dest.Close()
-    
-    if _, err := io.Copy(dest, src); err != nil {
-        return fmt.Errorf("failed to copy file: %w", err)
+    if !deduped {
+        if err := copyFileContents(srcPath, destPath); err != nil {
+            return fmt.Errorf("failed to copy file: %w", err)
+        }
     }
     
     // Update file reference
     relativePath := filepath.Join(subdir, filename)
-    fileRef.OriginalPath = &fileRef.Path
+    originalPath := fileRef.Path
+    fileRef.OriginalPath = &originalPath
     fileRef.Path = relativePath
     fileRef.IsLinked = false
     
+    if info, err := os.Stat(destPath); err == nil {
+        size := info.Size()
+        modTime := info.ModTime().Format(time.RFC3339)
+        fileRef.Size = &size
+        fileRef.LastModified = &modTime
+    }
+    
     return nil
 }
 
@@ -877,7 +1388,12 @@ func (a *App) DeleteProject(projectID string) error {
     }
     
     // Remove project directory
-    return os.RemoveAll(projectDir)
+    if err := os.RemoveAll(projectDir); err != nil {
+        return err
+    }
+    
+    a.emitEvent("project:deleted", projectID)
+    return nil
 }
 
 // ShowProjectInFolder opens the project directory in the system file explorer
@@ -887,19 +1403,7 @@ func (a *App) ShowProjectInFolder(projectID string) error {
         return fmt.Errorf("project not found: %w", err)
     }
     
-    var cmd *exec.Cmd
-    switch runtime.GOOS {
-    case "windows":
-        cmd = exec.Command("explorer", projectDir)
-    case "darwin":
-        cmd = exec.Command("open", projectDir)
-    case "linux":
-        cmd = exec.Command("xdg-open", projectDir)
-    default:
-        return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
-    }
-    
-    return cmd.Start()
+    return revealInFileManager(projectDir)
 }
 
 // App Settings Functions
@@ -911,14 +1415,7 @@ func (a *App) GetAppSettings() (*AppSettings, error) {
     
     // Return defaults if file doesn't exist
     if _, err := os.Stat(settingsPath); os.IsNotExist(err) {
-        defaultPath, _ := a.GetDefaultProjectsPath()
-        return &AppSettings{
-            DefaultProjectsPath: defaultPath,
-            AutoCleanup:         "auto",
-            ShowOnboarding:      true,
-            RecentProjects:      []string{},
-            ExportLocation:      "project-folder",
-        }, nil
+        return a.defaultAppSettings(), nil
     }
     
     data, err := os.ReadFile(settingsPath)
@@ -928,18 +1425,61 @@ func (a *App) GetAppSettings() (*AppSettings, error) {
     
     var settings AppSettings
     if err := json.Unmarshal(data, &settings); err != nil {
-        return nil, fmt.Errorf("failed to parse settings: %w", err)
+        // A corrupt file would otherwise lock the user out of the app; set it aside for
+        // debugging and start over from defaults
+        corruptPath := settingsPath + ".corrupt"
+        if renameErr := os.Rename(settingsPath, corruptPath); renameErr != nil && !os.IsNotExist(renameErr) {
+            return nil, fmt.Errorf("failed to parse settings (%v) and to move them aside: %w", err, renameErr)
+        }
+        a.emitWarning(fmt.Sprintf("Settings file was corrupt (%v); it was saved as %s and defaults are in use", err, corruptPath))
+        return a.defaultAppSettings(), nil
     }
     
     return &settings, nil
 }
 
+// defaultAppSettings returns the settings used before any have been saved
+func (a *App) defaultAppSettings() *AppSettings {
+    defaultPath, _ := a.GetDefaultProjectsPath()
+    return &AppSettings{
+        DefaultProjectsPath: defaultPath,
+        AutoCleanup:         "auto",
+        ShowOnboarding:      true,
+        RecentProjects:      []string{},
+        ExportLocation:      "project-folder",
+    }
+}
+
 func (a *App) SaveAppSettings(settings *AppSettings) error {
     settingsPath, err := a.getSettingsPath()
     if err != nil {
         return err
     }
     
+    if err := validateWebhookURL(settings.CompletionWebhookURL); err != nil {
+        return err
+    }
+    if err := validateTempDirSetting(settings.TempDir); err != nil {
+        return err
+    }
+    if err := validateDownloadRateLimit(settings.DownloadRateLimit); err != nil {
+        return err
+    }
+    if err := validateDeviceSetting(settings.Device); err != nil {
+        return err
+    }
+    if err := validateJobLimits(settings); err != nil {
+        return err
+    }
+    if err := validateLogLevel(settings.LogLevel); err != nil {
+        return err
+    }
+    if err := validateCustomVoicesPath(settings.CustomVoicesPath); err != nil {
+        return err
+    }
+    
+    previous, _ := a.GetAppSettings()
+    
     // Ensure settings directory exists
     if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
         return fmt.Errorf("failed to create settings directory: %w", err)
@@ -950,7 +1490,36 @@ func (a *App) SaveAppSettings(settings *AppSettings) error {
         return fmt.Errorf("failed to marshal settings: %w", err)
     }
     
-    return os.WriteFile(settingsPath, data, 0644)
+    // Write then rename so an interrupted save can't corrupt the settings
+    tmpPath := settingsPath + ".tmp"
+    if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+        return err
+    }
+    if err := os.Rename(tmpPath, settingsPath); err != nil {
+        os.Remove(tmpPath)
+        return err
+    }
+    
+    if previous != nil && (previous.APIServerEnabled != settings.APIServerEnabled ||
+        previous.APIServerPort != settings.APIServerPort || previous.APIServerToken != settings.APIServerToken) {
+        if err := a.startAPIServer(); err != nil {
+            return fmt.Errorf("settings saved, but the local API failed to start: %w", err)
+        }
+    }
+    
+    // Follow the projects folder if it moved
+    if previous != nil && previous.DefaultProjectsPath != settings.DefaultProjectsPath {
+        a.watcherMu.Lock()
+        watching := a.watcher != nil
+        a.watcherMu.Unlock()
+        if watching {
+            if err := a.startProjectWatcher(); err != nil {
+                fmt.Printf("Warning: failed to restart project watcher: %v\n", err)
+            }
+        }
+    }
+    
+    return nil
 }
 
 func (a *App) getSettingsPath() (string, error) {
@@ -987,45 +1556,253 @@ func (a *App) getSettingsPath() (string, error) {
 
 // Pipeline execution related functions
 
-// RunPipelineStep executes a single pipeline step for a project
+// RunPipelineStep executes a single pipeline step for a project.
+// A step whose inputs are unchanged since its last successful run returns the cached
+// result instead of running again; use RerunPipelineStep to run it regardless.
 func (a *App) RunPipelineStep(projectID string, step string) (map[string]interface{}, error) {
+    return a.runPipelineStep(projectID, step, false, nil)
+}
+
+// RerunPipelineStep executes a single pipeline step even if its cached result is still valid
+func (a *App) RerunPipelineStep(projectID string, step string) (map[string]interface{}, error) {
+    return a.runPipelineStep(projectID, step, true, nil)
+}
+
+// runPipelineStep runs one step of project_pipeline.py with extraEnv added to its environment
+func (a *App) runPipelineStep(projectID string, step string, force bool, extraEnv []string) (map[string]interface{}, error) {
     // Find project directory
     projectDir, err := a.findProjectDirectory(projectID)
     if err != nil {
         return nil, fmt.Errorf("project not found: %w", err)
     }
     
+    // Callers may ask for either first step; run the one this project's source has
+    if isSourceStep(step) {
+        project, err := a.LoadProject(projectID)
+        if err != nil {
+            return nil, fmt.Errorf("failed to load project: %w", err)
+        }
+        step = projectPipelineSteps(project)[0]
+    }
+    
+    // Earlier steps' output is stale if a linked source changed underneath them
+    if err := a.checkSourceBeforeRun(projectID); err != nil {
+        return nil, err
+    }
+    
+    // Later steps must work on the clipped source, never silently on the full one
+    if !isSourceStep(step) {
+        if err := a.requireTrimmedSource(projectID); err != nil {
+            return nil, err
+        }
+    }
+    
+    inputHash, err := a.stepInputHash(projectID, step)
+    if err != nil {
+        fmt.Printf("Warning: failed to hash inputs for step %s: %v\n", step, err)
+    }
+    if !force && inputHash != "" {
+        if cached, ok := a.cachedStepResult(projectID, step, inputHash); ok {
+            return cached, nil
+        }
+    }
+    
+    // CancelProject and CancelAll stop the step through this context
+    runCtx, endRun := a.beginProjectRun(projectID)
+    defer endRun()
+    
+    // Wait for a free slot; other projects' steps may already be using the machine
+    release, err := a.acquireJobSlot(runCtx, projectID, step)
+    if err != nil {
+        if runCtx.Err() != nil {
+            return nil, fmt.Errorf("%s step: %w", step, errPipelineCancelled)
+        }
+        return nil, err
+    }
+    defer release()
+    
+    // Only tracks a lone step; a full run is already tracked by runPipelineFrom
+    stopETA := a.trackETA(projectID, []string{step})
+    defer stopETA()
+    a.etaStepStarted(projectID, step)
+    stepStarted := time.Now()
+    
     // Get Python command
     pythonCmd := a.getPythonCommand()
     
     // Get Python scripts directory
-    pythonDir := os.Getenv("KOKORO_PYTHON_DIR")
-    if pythonDir == "" {
-        workDir, _ := os.Getwd()
-        pythonDir = filepath.Join(workDir, "python")
-    }
+    pythonDir := a.getPythonScriptsDir()
     
     scriptPath := filepath.Join(pythonDir, "project_pipeline.py")
     
     // Prepare command
-    cmd := exec.Command(pythonCmd, scriptPath, projectDir, step)
+    cmd := a.trackedCommandContext(runCtx, pythonCmd, scriptPath, projectDir, step)
+    defer a.untrackCommand(cmd)
     cmd.Dir = pythonDir
     
     // Set environment variables
     cmd.Env = append(os.Environ(),
         fmt.Sprintf("PYTHONPATH=%s", pythonDir),
     )
+    cmd.Env = append(cmd.Env, extraEnv...)
+    
+    // Run model inference on the device chosen in settings
+    if step == "transcribe" || step == "synthesize" {
+        cmd.Env = append(cmd.Env, a.deviceEnv()...)
+    }
+    
+    // Pick the download quality and cap download speed for users on metered connections
+    if step == "download" {
+        project, err := a.LoadProject(projectID)
+        if err != nil {
+            return nil, fmt.Errorf("failed to load project: %w", err)
+        }
+        downloadEnv, err := a.downloadEnv(project)
+        if err != nil {
+            return nil, err
+        }
+        cmd.Env = append(cmd.Env, downloadEnv...)
+    }
+    
+    // Route transcription to the selected backend, failing early if it can't run
+    if step == "transcribe" {
+        project, err := a.LoadProject(projectID)
+        if err != nil {
+            return nil, fmt.Errorf("failed to load project: %w", err)
+        }
+        backend, err := a.requireTranscriptionBackend(project.Settings.Transcription)
+        if err != nil {
+            return nil, err
+        }
+        transcriptionJSON, err := marshalToJSON(project.Settings.Transcription)
+        if err != nil {
+            return nil, fmt.Errorf("failed to serialize transcription settings: %w", err)
+        }
+        cmd.Env = append(cmd.Env,
+            fmt.Sprintf("TRANSCRIPTION_BACKEND=%s", backend.id),
+            fmt.Sprintf("TRANSCRIPTION_SETTINGS=%s", transcriptionJSON),
+        )
+    }
+    
+    // Forward translation settings as structured JSON
+    if step == "translate" {
+        project, err := a.LoadProject(projectID)
+        if err != nil {
+            return nil, fmt.Errorf("failed to load project: %w", err)
+        }
+        if err := a.ValidateTranslationSettings(project.Settings.Translation); err != nil {
+            return nil, fmt.Errorf("invalid translation settings: %w", err)
+        }
+        translationJSON, err := marshalToJSON(project.Settings.Translation)
+        if err != nil {
+            return nil, fmt.Errorf("failed to serialize translation settings: %w", err)
+        }
+        cmd.Env = append(cmd.Env, fmt.Sprintf("TRANSLATION_SETTINGS=%s", translationJSON))
+    }
+    
+    // Reject malformed markup or a voice that can't speak the target language before
+    // they reach the synthesis backend
+    var seed int
+    if step == "synthesize" {
+        project, err := a.LoadProject(projectID)
+        if err != nil {
+            return nil, fmt.Errorf("failed to load project: %w", err)
+        }
+        if project.UsesMarkup {
+            if err := a.validateSegmentMarkup(projectID); err != nil {
+                return nil, fmt.Errorf("invalid synthesis markup: %w", err)
+            }
+            cmd.Env = append(cmd.Env, "SYNTHESIS_MARKUP=1")
+        }
+        if voice := project.Settings.Synthesis.Voice; voice != "" {
+            if err := a.ValidateVoiceLanguage(voice, project.TargetLanguage); err != nil {
+                return nil, fmt.Errorf("invalid synthesis voice: %w", err)
+            }
+            cmd.Env = append(cmd.Env, fmt.Sprintf("SYNTHESIS_VOICE=%s", voice))
+        }
+        if settings, err := a.GetAppSettings(); err == nil && settings.CustomVoicesPath != nil && *settings.CustomVoicesPath != "" {
+            cmd.Env = append(cmd.Env, fmt.Sprintf("SYNTHESIS_CUSTOM_VOICES=%s", *settings.CustomVoicesPath))
+        }
+        seed = synthesisSeed(project.Settings.Synthesis)
+        cmd.Env = append(cmd.Env, fmt.Sprintf("SYNTHESIS_SEED=%d", seed))
+    }
+    
+    // Name and format the final output from the project's output settings
+    if step == "combine" {
+        project, err := a.LoadProject(projectID)
+        if err != nil {
+            return nil, fmt.Errorf("failed to load project: %w", err)
+        }
+        basename, err := renderOutputFilename(project.Settings.Output.FilenameTemplate, project)
+        if err != nil {
+            return nil, fmt.Errorf("invalid output filename template: %w", err)
+        }
+        if err := validateOutputFormat(project.Settings.Output.Format); err != nil {
+            return nil, err
+        }
+        if project.OutputDir != nil {
+            if err := validateOutputDir(*project.OutputDir, projectInputDirs(projectDir)...); err != nil {
+                return nil, err
+            }
+        }
+        cmd.Env = append(cmd.Env,
+            fmt.Sprintf("OUTPUT_BASENAME=%s", basename),
+            fmt.Sprintf("OUTPUT_FORMAT=%s", outputFormatOrDefault(project.Settings.Output.Format)),
+        )
+    }
     
-    // Execute command and capture output
-    output, err := cmd.CombinedOutput()
+    // Per-project overrides go last so they win over the built-in variables
+    if project, err := a.LoadProject(projectID); err == nil {
+        cmd.Env = append(cmd.Env, envOverrideList(project.EnvOverrides)...)
+    }
+    
+    // Execute command, streaming logs and progress to the frontend
+    output, combined, warnings, err := a.runStreamingCommand(cmd, projectID, step, stepLogPath(projectDir, step))
+    if err != nil && runCtx.Err() != nil {
+        if resetErr := a.resetCancelledStep(projectID, step); resetErr != nil {
+            fmt.Printf("Warning: failed to reset cancelled step %s: %v\n", step, resetErr)
+        }
+        return nil, fmt.Errorf("%s step: %w", step, errPipelineCancelled)
+    }
     if err != nil {
-        return nil, fmt.Errorf("pipeline step failed: %v\nOutput: %s", err, string(output))
+        return nil, fmt.Errorf("pipeline step failed: %v\nOutput: %s", err, string(combined))
     }
     
     // Parse JSON result
     var result map[string]interface{}
-    if err := json.Unmarshal(output, &result); err != nil {
-        return nil, fmt.Errorf("failed to parse pipeline output: %w\nOutput: %s", err, string(output))
+    if err := parseTrailingJSON(output, &result); err != nil {
+        return nil, fmt.Errorf("failed to parse pipeline output: %w\nOutput: %s", err, string(combined))
+    }
+    if len(warnings) > 0 {
+        result["warnings"] = warnings
+    }
+    if step == "synthesize" {
+        result["seed"] = seed
+    }
+    
+    // A step can exit cleanly yet leave empty or malformed output behind
+    if success, ok := result["success"].(bool); ok && success {
+        if err := a.validateStepOutput(projectID, step); err != nil {
+            if markErr := a.markStepIncomplete(projectID, step); markErr != nil {
+                fmt.Printf("Warning: failed to reset step %s: %v\n", step, markErr)
+            }
+            return nil, fmt.Errorf("%s step produced invalid output: %w", step, err)
+        }
+        if isSourceStep(step) {
+            if err := a.trimSource(projectID); err != nil {
+                if markErr := a.markStepIncomplete(projectID, step); markErr != nil {
+                    fmt.Printf("Warning: failed to reset step %s: %v\n", step, markErr)
+                }
+                return nil, err
+            }
+        }
+        a.recordStepTiming(projectID, step, time.Since(stepStarted).Seconds())
+    }
+    
+    if success, ok := result["success"].(bool); ok && success && inputHash != "" {
+        if err := a.storeStepResult(projectID, step, inputHash, result); err != nil {
+            fmt.Printf("Warning: failed to cache result for step %s: %v\n", step, err)
+        }
     }
     
     return result, nil
@@ -1033,21 +1810,65 @@ func (a *App) RunPipelineStep(projectID string, step string) (map[string]interfa
 
 // RunFullPipeline executes the complete pipeline for a project
 func (a *App) RunFullPipeline(projectID string) (map[string]interface{}, error) {
-    steps := []string{"download", "transcribe", "translate", "synthesize", "combine"}
+    return a.runPipelineFrom(projectID, 0)
+}
+
+// runPipelineFrom runs the pipeline steps starting at index start, stopping early at any
+// step the project pauses after
+func (a *App) runPipelineFrom(projectID string, start int) (map[string]interface{}, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load project: %w", err)
+    }
+    steps := projectPipelineSteps(project)[start:]
+    
+    // A new run supersedes any pause left by the previous one
+    if err := a.setPipelinePaused(projectID, nil); err != nil {
+        return nil, err
+    }
+    
+    runCtx, endRun := a.beginProjectRun(projectID)
+    defer endRun()
+    
+    stopETA := a.trackETA(projectID, steps)
+    defer stopETA()
     
     results := make(map[string]interface{})
     results["steps"] = make(map[string]interface{})
+    warnings := []PipelineWarning{}
+    results["warnings"] = warnings
+    
+    started := time.Now()
+    stepDurations := make(map[string]float64)
+    defer func() {
+        a.notifyPipelineCompletion(a.pipelineCompletion(projectID, results, started, stepDurations))
+    }()
     
     for _, step := range steps {
+        if runCtx.Err() != nil {
+            results["success"] = false
+            results["cancelled"] = true
+            results["error"] = errPipelineCancelled.Error()
+            return results, errPipelineCancelled
+        }
+        
+        stepStarted := time.Now()
         stepResult, err := a.RunPipelineStep(projectID, step)
+        stepDurations[step] = time.Since(stepStarted).Seconds()
+        a.etaStepFinished(projectID, step)
         if err != nil {
             results["success"] = false
             results["error"] = err.Error()
             results["failedStep"] = step
+            if errors.Is(err, errPipelineCancelled) {
+                results["cancelled"] = true
+            }
             return results, err
         }
         
         results["steps"].(map[string]interface{})[step] = stepResult
+        warnings = append(warnings, stepWarnings(stepResult)...)
+        results["warnings"] = warnings
         
         // Stop if step failed
         if success, ok := stepResult["success"].(bool); !ok || !success {
@@ -1058,6 +1879,19 @@ func (a *App) RunFullPipeline(projectID string) (map[string]interface{}, error)
             }
             return results, fmt.Errorf("pipeline step '%s' failed", step)
         }
+        
+        // Approval gate: wait for ResumePipeline before the next step
+        if step != steps[len(steps)-1] && a.pausesAfterStep(projectID, step) {
+            if err := a.setPipelinePaused(projectID, &step); err != nil {
+                return results, err
+            }
+            results["success"] = true
+            results["paused"] = true
+            results["pausedAfter"] = step
+            results["message"] = fmt.Sprintf("⏸️ Pipeline paused after %s", step)
+            a.emitEvent("pipeline:paused", PipelinePaused{ProjectID: projectID, Step: step})
+            return results, nil
+        }
     }
     
     results["success"] = true
@@ -1066,6 +1900,53 @@ func (a *App) RunFullPipeline(projectID string) (map[string]interface{}, error)
     return results, nil
 }
 
+// pipelineCompletion summarizes a finished RunFullPipeline for the completion webhook
+func (a *App) pipelineCompletion(projectID string, results map[string]interface{}, started time.Time, stepDurations map[string]float64) PipelineCompletion {
+    finished := time.Now()
+    completion := PipelineCompletion{
+        Event:           "pipeline.failed",
+        ProjectID:       projectID,
+        Status:          "failed",
+        StartedAt:       started.Format(time.RFC3339),
+        FinishedAt:      finished.Format(time.RFC3339),
+        DurationSeconds: finished.Sub(started).Seconds(),
+        StepDurations:   stepDurations,
+        Warnings:        stepWarnings(results),
+    }
+    
+    if paused, _ := results["paused"].(bool); paused {
+        completion.Event = "pipeline.paused"
+        completion.Status = "paused"
+    } else if cancelled, _ := results["cancelled"].(bool); cancelled {
+        completion.Event = "pipeline.cancelled"
+        completion.Status = "cancelled"
+    } else if success, _ := results["success"].(bool); success {
+        completion.Event = "pipeline.completed"
+        completion.Status = "success"
+    }
+    if step, ok := results["failedStep"].(string); ok {
+        completion.FailedStep = step
+    }
+    if results["error"] != nil {
+        completion.Error = fmt.Sprint(results["error"])
+    }
+    
+    if projectDir, err := a.findProjectDirectory(projectID); err == nil {
+        if project, err := readProjectConfig(projectDir); err == nil {
+            completion.ProjectName = project.Name
+            output := project.FileReferences.FinalVideo
+            if isAudioOutputFormat(project.Settings.Output.Format) {
+                output = project.FileReferences.FinalAudio
+            }
+            if output != nil {
+                completion.OutputPath = resolveProjectPath(projectDir, *output)
+            }
+        }
+    }
+    
+    return completion
+}
+
 // Helper function to get Python command (reuse existing logic)
 func (a *App) getPythonCommand() string {
     if runtime.GOOS == "windows" {
@@ -0,0 +1,138 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "unicode/utf8"
+)
+
+func TestSanitizeForFilename(t *testing.T) {
+    tests := []struct {
+        name string
+        in   string
+        want string
+    }{
+        {"plain title", "My Video", "My Video"},
+        {"path separators", `a/b\c:d`, "a-b-c-d"},
+        {"wildcards and quotes", `what? "yes" <no> *|`, `what- -yes- -no- --`},
+        {"control characters", "tab\there\x00\x7f", "tabhere"},
+        {"CJK title", "東京の夜 – 日本語吹き替え", "東京の夜 – 日本語吹き替え"},
+        {"Korean title", "서울 여행", "서울 여행"},
+        {"emoji title", "Cooking 🍳 with 👨\u200d👩\u200d👧", "Cooking 🍳 with 👨\u200d👩\u200d👧"},
+        {"invalid UTF-8", "bad\xffname", "bad-name"},
+        {"reserved CON", "CON", "_CON"},
+        {"reserved nul lowercase", "nul", "_nul"},
+        {"reserved with extension", "aux.txt", "_aux.txt"},
+        {"reserved COM port", "COM1", "_COM1"},
+        {"reserved prefix is fine", "CONTEXT", "CONTEXT"},
+        {"reserved with trailing dots", "NUL...", "_NUL"},
+        {"trailing dots and spaces", "Episode 1. . ", "Episode 1"},
+        {"leading dot", ".hidden", "hidden"},
+        {"only dots", "...", "Untitled"},
+        {"empty", "", "Untitled"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := sanitizeForFilename(tt.in); got != tt.want {
+                t.Errorf("sanitizeForFilename(%q) = %q, want %q", tt.in, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestSanitizeForFilenameLongTitles(t *testing.T) {
+    tests := []struct {
+        name string
+        in   string
+    }{
+        {"long CJK title", strings.Repeat("日本語", maxFilenameRunes)},
+        {"long emoji title", strings.Repeat("👨\u200d👩\u200d👧", maxFilenameRunes)},
+        {"long ASCII title ending in dots", strings.Repeat("a", maxFilenameRunes-2) + "......"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := sanitizeForFilename(tt.in)
+            if !utf8.ValidString(got) {
+                t.Errorf("result is not valid UTF-8: %q", got)
+            }
+            if n := utf8.RuneCountInString(got); n > maxFilenameRunes {
+                t.Errorf("result has %d runes, want at most %d", n, maxFilenameRunes)
+            }
+            if strings.HasSuffix(got, "\u200d") || strings.HasSuffix(got, ".") {
+                t.Errorf("result ends with a joiner or dot: %q", got)
+            }
+        })
+    }
+}
+
+// newTestApp returns an App whose settings and projects live in temporary directories
+func newTestApp(t *testing.T) (*App, string) {
+    t.Helper()
+    home := t.TempDir()
+    t.Setenv("HOME", home)
+    t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+    t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+    t.Setenv("USERPROFILE", home)
+
+    app := NewApp()
+    projectsDir := filepath.Join(home, "Projects")
+    settings, err := app.GetAppSettings()
+    if err != nil {
+        t.Fatalf("GetAppSettings: %v", err)
+    }
+    settings.DefaultProjectsPath = projectsDir
+    if err := app.SaveAppSettings(settings); err != nil {
+        t.Fatalf("SaveAppSettings: %v", err)
+    }
+    return app, projectsDir
+}
+
+func TestCreateProjectInCategoryRejectsBlankTargetLanguage(t *testing.T) {
+    const url = "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+    for _, lang := range []string{"", " ", "\t\n "} {
+        t.Run(fmt.Sprintf("%q", lang), func(t *testing.T) {
+            app, projectsDir := newTestApp(t)
+
+            project, err := app.CreateProjectInCategory("youtube", url, lang, "", "Talks")
+            if err == nil {
+                t.Fatalf("expected an error, got project %+v", project)
+            }
+            if !strings.Contains(err.Error(), "target language is required") {
+                t.Errorf("unexpected error: %v", err)
+            }
+
+            entries, err := os.ReadDir(projectsDir)
+            if err != nil && !os.IsNotExist(err) {
+                t.Fatalf("reading projects directory: %v", err)
+            }
+            if len(entries) > 0 {
+                t.Errorf("projects directory has %d entries after a rejected create, want none", len(entries))
+            }
+        })
+    }
+
+    t.Run("trimmed language is accepted", func(t *testing.T) {
+        app, projectsDir := newTestApp(t)
+
+        project, err := app.CreateProjectInCategory("youtube", url, " es ", "", "")
+        if err != nil {
+            t.Fatalf("CreateProjectInCategory: %v", err)
+        }
+        if project.TargetLanguage != "es" {
+            t.Errorf("TargetLanguage = %q, want %q", project.TargetLanguage, "es")
+        }
+        entries, err := os.ReadDir(projectsDir)
+        if err != nil {
+            t.Fatalf("reading projects directory: %v", err)
+        }
+        if len(entries) != 1 || !strings.HasSuffix(entries[0].Name(), "[ES]") {
+            t.Errorf("expected one project folder ending in [ES], got %v", entries)
+        }
+    })
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+    "fmt"
+    "math"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// attachDurationTolerance is how far an attached video's length may be from the audio's:
+// the larger of two seconds and 5% of the audio
+const attachDurationTolerance = 2.0
+
+// AttachVideo turns an audio-only project into a video project: the video is linked as the
+// project's VideoFile and the dubbed audio is muxed onto it at the combine step. The audio
+// stays the source that gets transcribed, so only combine has to run again.
+func (a *App) AttachVideo(projectID, videoPath string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+
+    refs := project.FileReferences
+    if refs.AudioFile == nil {
+        return fmt.Errorf("project has no audio source to attach a video to")
+    }
+    if refs.VideoFile != nil && !project.AttachedVideo {
+        return fmt.Errorf("project already has a video source")
+    }
+
+    absPath, err := filepath.Abs(videoPath)
+    if err != nil {
+        return fmt.Errorf("invalid path: %w", err)
+    }
+    if err := a.validateSourceMedia("video", absPath); err != nil {
+        return err
+    }
+
+    // Compare with what will actually be dubbed, which is the clip if the audio is trimmed
+    audioPath := resolveFileReferencePath(projectDir, refs.AudioFile)
+    if refs.TrimmedFile != nil {
+        audioPath = filepath.Join(projectDir, *refs.TrimmedFile)
+    }
+    if err := a.checkAttachedDuration(audioPath, absPath); err != nil {
+        return err
+    }
+
+    info, err := os.Stat(absPath)
+    if err != nil {
+        return fmt.Errorf("video file not found: %w", err)
+    }
+    size := info.Size()
+    modTime := info.ModTime().Format(time.RFC3339)
+    project.FileReferences.VideoFile = &FileReference{
+        Path:         absPath,
+        IsLinked:     true,
+        OriginalPath: &absPath,
+        Size:         &size,
+        LastModified: &modTime,
+    }
+    project.AttachedVideo = true
+
+    if isAudioOutputFormat(project.Settings.Output.Format) {
+        project.Settings.Output.Format = defaultOutputFormat
+    }
+
+    if err := a.UpdateProject(project); err != nil {
+        return err
+    }
+    return a.markStepIncomplete(projectID, "combine")
+}
+
+// checkAttachedDuration rejects a video whose length is clearly not the audio's. Without
+// ffprobe the durations are unknown and the check is skipped.
+func (a *App) checkAttachedDuration(audioPath, videoPath string) error {
+    audioDuration, ok, err := a.probeMediaDuration(audioPath)
+    if err != nil {
+        return fmt.Errorf("failed to read audio duration: %w", err)
+    }
+    if !ok || audioDuration <= 0 {
+        return nil
+    }
+    videoDuration, ok, err := a.probeMediaDuration(videoPath)
+    if err != nil {
+        return fmt.Errorf("failed to read video duration: %w", err)
+    }
+    if !ok || videoDuration <= 0 {
+        return nil
+    }
+
+    tolerance := math.Max(attachDurationTolerance, audioDuration*0.05)
+    if math.Abs(videoDuration-audioDuration) > tolerance {
+        return fmt.Errorf("video is %.1fs long but the audio is %.1fs; they don't look like the same recording", videoDuration, audioDuration)
+    }
+    return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+    "bufio"
+    "encoding/binary"
+    "fmt"
+    "io"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "sort"
+)
+
+// previewSampleRate matches Kokoro's output, so segments aren't resampled twice
+const previewSampleRate = 24000
+
+// previewFileName is where GenerateAudioPreview writes, inside the project's audio folder
+const previewFileName = "dub_preview.wav"
+
+// GenerateAudioPreview lays every synthesized segment on one mono track at its start time,
+// padding the gaps with silence, and returns the WAV file's path. There is no video muxing,
+// background audio or effects, so it is much faster than the combine step. A segment that
+// would overlap the one before it starts when that one ends instead.
+func (a *App) GenerateAudioPreview(projectID string) (string, error) {
+    projectDir, _, segments, err := a.loadProjectSegments(projectID)
+    if err != nil {
+        return "", err
+    }
+
+    ffmpeg, err := exec.LookPath("ffmpeg")
+    if err != nil {
+        return "", fmt.Errorf("ffmpeg is required to build the preview: %w", err)
+    }
+
+    type clip struct {
+        index int
+        start float64
+        path  string
+    }
+    clips := make([]clip, 0, len(segments))
+    for i, segment := range segments {
+        path := segmentAudioPath(projectDir, i, segment)
+        if !fileExists(path) {
+            continue
+        }
+        start := segment.Start
+        if segment.ActualStart != nil {
+            start = *segment.ActualStart
+        }
+        clips = append(clips, clip{index: i, start: start, path: path})
+    }
+    if len(clips) == 0 {
+        return "", fmt.Errorf("no synthesized segments found, run synthesis first")
+    }
+    sort.SliceStable(clips, func(i, j int) bool { return clips[i].start < clips[j].start })
+
+    outPath := filepath.Join(projectDir, "audio", previewFileName)
+    tmpPath := outPath + ".tmp"
+    out, err := os.Create(tmpPath)
+    if err != nil {
+        return "", fmt.Errorf("failed to create preview: %w", err)
+    }
+    defer os.Remove(tmpPath)
+    defer out.Close()
+
+    // The header is rewritten with the real sizes once all samples are written
+    w := bufio.NewWriter(out)
+    if err := writeWAVHeader(w, 0); err != nil {
+        return "", fmt.Errorf("failed to write preview: %w", err)
+    }
+
+    var written int64 // samples
+    silence := make([]byte, 2*previewSampleRate)
+    for _, c := range clips {
+        // Decoding one clip at a time keeps memory flat however long the project is
+        cmd := a.trackedCommand(ffmpeg, "-v", "error", "-i", c.path, "-f", "s16le", "-ac", "1", "-ar", fmt.Sprint(previewSampleRate), "-")
+        pcm, err := a.commandOutput(cmd)
+        a.untrackCommand(cmd)
+        if err != nil {
+            return "", fmt.Errorf("failed to decode segment %d: %w", c.index, err)
+        }
+
+        for pad := int64(c.start*previewSampleRate) - written; pad > 0; {
+            n := min(pad, int64(len(silence)/2))
+            if _, err := w.Write(silence[:n*2]); err != nil {
+                return "", fmt.Errorf("failed to write preview: %w", err)
+            }
+            pad -= n
+            written += n
+        }
+        if _, err := w.Write(pcm); err != nil {
+            return "", fmt.Errorf("failed to write preview: %w", err)
+        }
+        written += int64(len(pcm) / 2)
+    }
+
+    if err := w.Flush(); err != nil {
+        return "", fmt.Errorf("failed to write preview: %w", err)
+    }
+    if _, err := out.Seek(0, 0); err != nil {
+        return "", fmt.Errorf("failed to write preview: %w", err)
+    }
+    if err := writeWAVHeader(out, written*2); err != nil {
+        return "", fmt.Errorf("failed to write preview: %w", err)
+    }
+    if err := out.Close(); err != nil {
+        return "", fmt.Errorf("failed to write preview: %w", err)
+    }
+    if err := os.Rename(tmpPath, outPath); err != nil {
+        return "", fmt.Errorf("failed to save preview: %w", err)
+    }
+    return outPath, nil
+}
+
+// writeWAVHeader writes a 16-bit mono PCM WAV header for dataSize bytes of samples
+func writeWAVHeader(w io.Writer, dataSize int64) error {
+    if dataSize > 0xFFFFFFFF-36 {
+        return fmt.Errorf("preview is too long for a WAV file")
+    }
+    header := []interface{}{
+        []byte("RIFF"), uint32(36 + dataSize), []byte("WAVE"),
+        []byte("fmt "), uint32(16), uint16(1), uint16(1),
+        uint32(previewSampleRate), uint32(previewSampleRate * 2), uint16(2), uint16(16),
+        []byte("data"), uint32(dataSize),
+    }
+    for _, field := range header {
+        if err := binary.Write(w, binary.LittleEndian, field); err != nil {
+            return err
+        }
+    }
+    return nil
+}
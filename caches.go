@@ -0,0 +1,119 @@
+package main
+
+import (
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// CacheReport summarizes what PurgeCaches removed
+type CacheReport struct {
+    Categories      []CacheCategory `json:"categories"`
+    TotalBytesFreed int64           `json:"totalBytesFreed"`
+}
+
+// CacheCategory is one kind of cached data and how much of it was freed
+type CacheCategory struct {
+    Name       string `json:"name"`
+    Path       string `json:"path"`
+    BytesFreed int64  `json:"bytesFreed"`
+    Error      string `json:"error,omitempty"`
+}
+
+// cacheSubdirs are the regenerable caches kept under the app's cache directory
+var cacheSubdirs = []struct {
+    name string
+    dir  string
+}{
+    {"Voice previews", "voice-previews"},
+    {"Waveforms", "waveforms"},
+    {"Thumbnails", "thumbnails"},
+}
+
+// getCacheDir returns the app's cache directory (which may not exist yet)
+func getCacheDir() (string, error) {
+    cacheDir, err := os.UserCacheDir()
+    if err != nil {
+        return "", err
+    }
+    return filepath.Join(cacheDir, "kokoro-studio"), nil
+}
+
+// PurgeCaches deletes regenerable data: the extracted Python scripts (extracted again when
+// next needed), the voice preview, waveform and thumbnail caches, and shared inputs no
+// project uses any more. Project data is never touched.
+func (a *App) PurgeCaches() (*CacheReport, error) {
+    if a.runningProcessCount() > 0 {
+        return nil, fmt.Errorf("cannot purge caches while a pipeline step is running")
+    }
+
+    report := &CacheReport{Categories: make([]CacheCategory, 0, len(cacheSubdirs)+1)}
+
+    // Only the extracted copy is removed; in development the scripts are the source tree
+    if pythonDir := os.Getenv("KOKORO_PYTHON_DIR"); pythonDir != "" && strings.HasPrefix(pythonDir, os.TempDir()) {
+        pythonDirMu.Lock()
+        category := purgeDir("Python scripts", pythonDir)
+        pythonDirPurged = true
+        pythonDirMu.Unlock()
+        report.Categories = append(report.Categories, category)
+    }
+
+    if cacheDir, err := getCacheDir(); err == nil {
+        for _, sub := range cacheSubdirs {
+            report.Categories = append(report.Categories, purgeDir(sub.name, filepath.Join(cacheDir, sub.dir)))
+        }
+    }
+
+    // Shared inputs still linked from a project are project data and stay
+    if settings, err := a.GetAppSettings(); err == nil {
+        report.Categories = append(report.Categories, pruneInputsCache(settings.DefaultProjectsPath))
+    }
+
+    for _, category := range report.Categories {
+        report.TotalBytesFreed += category.BytesFreed
+    }
+    return report, nil
+}
+
+// purgeDir removes a directory, reporting how many bytes it held
+func purgeDir(name, dir string) CacheCategory {
+    category := CacheCategory{Name: name, Path: dir}
+
+    size, err := dirSize(dir)
+    if err != nil {
+        if !os.IsNotExist(err) {
+            category.Error = err.Error()
+        }
+        return category
+    }
+
+    if err := os.RemoveAll(dir); err != nil {
+        // Whatever is left still counts against the freed total
+        remaining, _ := dirSize(dir)
+        category.BytesFreed = size - remaining
+        category.Error = err.Error()
+        return category
+    }
+
+    category.BytesFreed = size
+    return category
+}
+
+// dirSize totals the size of the regular files under dir
+func dirSize(dir string) (int64, error) {
+    var size int64
+    err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+        if err != nil {
+            return err
+        }
+        if d.Type().IsRegular() {
+            if info, err := d.Info(); err == nil {
+                size += info.Size()
+            }
+        }
+        return nil
+    })
+    return size, err
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// projectFolders returns every project folder under the projects root: folders holding a
+// project.json, either directly under the root or one level down inside a category folder
+func projectFolders(projectsDir string) ([]string, error) {
+    entries, err := os.ReadDir(projectsDir)
+    if err != nil {
+        return nil, err
+    }
+
+    var folders []string
+    for _, entry := range entries {
+        if !entry.IsDir() {
+            continue
+        }
+        dir := filepath.Join(projectsDir, entry.Name())
+        if _, err := os.Stat(filepath.Join(dir, "project.json")); err == nil {
+            folders = append(folders, dir)
+            continue
+        }
+
+        // Not a project itself, so possibly a category
+        children, err := os.ReadDir(dir)
+        if err != nil {
+            continue
+        }
+        for _, child := range children {
+            childDir := filepath.Join(dir, child.Name())
+            if child.IsDir() && fileExists(filepath.Join(childDir, "project.json")) {
+                folders = append(folders, childDir)
+            }
+        }
+    }
+
+    return folders, nil
+}
+
+// sanitizeCategory turns a category name into a single safe folder name
+func sanitizeCategory(category string) (string, error) {
+    category = strings.TrimSpace(category)
+    if category == "" {
+        return "", nil
+    }
+    if strings.ContainsAny(category, `/\`) {
+        return "", fmt.Errorf("category cannot contain path separators: %s", category)
+    }
+    return sanitizeForFilename(category), nil
+}
+
+// MoveProjectToCategory moves a project's folder into a category folder of the projects
+// directory. An empty category moves it back to the top level.
+func (a *App) MoveProjectToCategory(projectID, category string) error {
+    category, err := sanitizeCategory(category)
+    if err != nil {
+        return err
+    }
+
+    settings, err := a.GetAppSettings()
+    if err != nil {
+        return fmt.Errorf("failed to get app settings: %w", err)
+    }
+
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+
+    parentDir := filepath.Join(settings.DefaultProjectsPath, category)
+    oldParent := filepath.Dir(projectDir)
+    if filepath.Clean(parentDir) == filepath.Clean(oldParent) {
+        return nil
+    }
+
+    if err := os.MkdirAll(parentDir, 0755); err != nil {
+        return fmt.Errorf("failed to create category folder: %w", err)
+    }
+
+    // A category can't share a name with a project folder at the top level
+    if fileExists(filepath.Join(parentDir, "project.json")) {
+        return fmt.Errorf("category %q clashes with an existing project folder", category)
+    }
+
+    baseName := filepath.Base(projectDir)
+    destName := baseName
+    for version := 2; ; version++ {
+        if _, err := os.Lstat(filepath.Join(parentDir, destName)); os.IsNotExist(err) {
+            break
+        }
+        destName = fmt.Sprintf("%s (%d)", baseName, version)
+    }
+    destDir := filepath.Join(parentDir, destName)
+
+    if err := os.Rename(projectDir, destDir); err != nil {
+        return fmt.Errorf("failed to move project folder: %w", err)
+    }
+
+    project.Category = category
+    if err := a.saveProjectConfig(destDir, project); err != nil {
+        return fmt.Errorf("failed to save project config: %w", err)
+    }
+
+    // Tidy up a category left empty by the move
+    if filepath.Clean(oldParent) != filepath.Clean(settings.DefaultProjectsPath) {
+        os.Remove(oldParent)
+    }
+
+    return nil
+}
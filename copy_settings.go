@@ -0,0 +1,80 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+)
+
+// settingsSections are the settings groups CopySettingsFrom can copy between projects
+var settingsSections = []string{"transcription", "translation", "audio", "synthesis", "rules", "cleanup", "output"}
+
+// CopySettingsFrom copies the selected settings sections from one project to another,
+// leaving the target's files, ID and source untouched
+func (a *App) CopySettingsFrom(targetProjectID, sourceProjectID string, sections []string) error {
+    if len(sections) == 0 {
+        return fmt.Errorf("no settings sections selected")
+    }
+    if targetProjectID == sourceProjectID {
+        return fmt.Errorf("cannot copy settings from a project to itself")
+    }
+    for _, section := range sections {
+        valid := false
+        for _, s := range settingsSections {
+            if s == section {
+                valid = true
+                break
+            }
+        }
+        if !valid {
+            return fmt.Errorf("unknown settings section %q (expected one of: %s)", section, strings.Join(settingsSections, ", "))
+        }
+    }
+
+    source, err := a.LoadProject(sourceProjectID)
+    if err != nil {
+        return fmt.Errorf("failed to load source project: %w", err)
+    }
+    target, err := a.LoadProject(targetProjectID)
+    if err != nil {
+        return fmt.Errorf("failed to load target project: %w", err)
+    }
+
+    // Work on a deep copy of the source so no slices end up shared between the two configs
+    var copied ProjectConfig
+    data, err := json.Marshal(source)
+    if err != nil {
+        return fmt.Errorf("failed to copy settings: %w", err)
+    }
+    if err := json.Unmarshal(data, &copied); err != nil {
+        return fmt.Errorf("failed to copy settings: %w", err)
+    }
+
+    for _, section := range sections {
+        switch section {
+        case "transcription":
+            // The audio stream index refers to the target's own source file
+            stream := target.Settings.Transcription.SourceAudioStream
+            target.Settings.Transcription = copied.Settings.Transcription
+            target.Settings.Transcription.SourceAudioStream = stream
+        case "translation":
+            target.Settings.Translation = copied.Settings.Translation
+        case "audio":
+            target.Settings.Audio = copied.Settings.Audio
+        case "synthesis":
+            target.Settings.Synthesis = copied.Settings.Synthesis
+            target.UsesMarkup = copied.UsesMarkup
+        case "rules":
+            target.TextRules = copied.TextRules
+            target.SegmentRules = copied.SegmentRules
+        case "cleanup":
+            target.Settings.Cleanup = copied.Settings.Cleanup
+        case "output":
+            target.Settings.Output = copied.Settings.Output
+            target.Locale = copied.Locale
+        }
+    }
+
+    // UpdateProject validates the combined settings before anything is written
+    return a.UpdateProject(target)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strings"
+)
+
+// CustomVoicePack is a voice file found in the custom voices directory. Invalid packs are
+// listed with the reason rather than dropped, so a misnamed file isn't a mystery.
+type CustomVoicePack struct {
+    ID       string `json:"id"`
+    Path     string `json:"path"`
+    Language string `json:"language,omitempty"`
+    Gender   string `json:"gender,omitempty"`
+    Valid    bool   `json:"valid"`
+    Problem  string `json:"problem,omitempty"`
+}
+
+// kokoroVoicePrefixes mirrors KOKORO_VOICE_PREFIXES in supported_languages.py: Kokoro
+// voice names start with their language's letter and the speaker's gender
+var kokoroVoicePrefixes = map[string]string{
+    "a": "en", "b": "en", "e": "es", "f": "fr", "h": "hi",
+    "i": "it", "j": "ja", "p": "pt", "z": "zh",
+}
+
+var voiceGenders = map[string]string{"f": "female", "m": "male"}
+
+// customVoiceName is the Kokoro naming scheme a pack's file name must follow, e.g. ef_maria.pt
+var customVoiceName = regexp.MustCompile(`^([a-z])([fm])_[A-Za-z0-9_]+$`)
+
+// GetCustomVoicePacks lists the voice packs in the CustomVoicesPath directory, valid or not
+func (a *App) GetCustomVoicePacks() ([]CustomVoicePack, error) {
+    settings, err := a.GetAppSettings()
+    if err != nil {
+        return nil, fmt.Errorf("failed to get app settings: %w", err)
+    }
+    if settings.CustomVoicesPath == nil || *settings.CustomVoicesPath == "" {
+        return []CustomVoicePack{}, nil
+    }
+    return scanCustomVoices(*settings.CustomVoicesPath)
+}
+
+// scanCustomVoices checks every entry of dir against the layout the synthesis step loads:
+// one Kokoro voice tensor per <voice id>.pt file, directly in the directory
+func scanCustomVoices(dir string) ([]CustomVoicePack, error) {
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read custom voices directory: %w", err)
+    }
+
+    packs := []CustomVoicePack{}
+    for _, entry := range entries {
+        name := entry.Name()
+        if strings.HasPrefix(name, ".") {
+            continue
+        }
+        pack := CustomVoicePack{ID: strings.TrimSuffix(name, filepath.Ext(name)), Path: filepath.Join(dir, name)}
+
+        if match := customVoiceName.FindStringSubmatch(pack.ID); match != nil {
+            pack.Language = kokoroVoicePrefixes[match[1]]
+            pack.Gender = voiceGenders[match[2]]
+        }
+        switch {
+        case entry.IsDir():
+            pack.Problem = "voice packs must be .pt files directly in the custom voices directory, not folders"
+        case filepath.Ext(name) != ".pt":
+            pack.Problem = "not a .pt voice file"
+        case pack.Language == "" && customVoiceName.MatchString(pack.ID):
+            pack.Problem = fmt.Sprintf("unknown language letter %q; expected one of %s", pack.ID[:1], strings.Join(sortedKeys(kokoroVoicePrefixes), ", "))
+        case pack.Language == "":
+            pack.Problem = "name must follow Kokoro's scheme, e.g. ef_maria.pt: language letter, f or m, underscore, name"
+        default:
+            pack.Problem = checkVoiceTensorFile(pack.Path)
+        }
+        pack.Valid = pack.Problem == ""
+        packs = append(packs, pack)
+    }
+
+    sort.Slice(packs, func(i, j int) bool { return packs[i].ID < packs[j].ID })
+    return packs, nil
+}
+
+// checkVoiceTensorFile checks a file looks like a saved PyTorch tensor, which is a zip
+// archive; it can't tell whether the tensor has the shape Kokoro expects
+func checkVoiceTensorFile(path string) string {
+    f, err := os.Open(path)
+    if err != nil {
+        return fmt.Sprintf("cannot be read: %v", err)
+    }
+    defer f.Close()
+
+    header := make([]byte, 4)
+    if n, _ := f.Read(header); n < len(header) || !bytes.Equal(header, []byte("PK\x03\x04")) {
+        return "not a PyTorch tensor file"
+    }
+    return ""
+}
+
+func sortedKeys(m map[string]string) []string {
+    keys := make([]string, 0, len(m))
+    for key := range m {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+// customVoiceInfos returns the valid custom voices, for ListAvailableVoices. Problems
+// reading the directory are left to GetCustomVoicePacks to report.
+func (a *App) customVoiceInfos() []VoiceInfo {
+    packs, err := a.GetCustomVoicePacks()
+    if err != nil {
+        return nil
+    }
+    var voices []VoiceInfo
+    for _, pack := range packs {
+        if pack.Valid {
+            voices = append(voices, VoiceInfo{ID: pack.ID, Language: pack.Language, Gender: pack.Gender, Custom: true})
+        }
+    }
+    return voices
+}
+
+// customVoiceFingerprint fingerprints the voice pack a voice ID loads from the custom
+// voices directory, or returns "" for a built-in voice
+func (a *App) customVoiceFingerprint(voice string) string {
+    settings, err := a.GetAppSettings()
+    if err != nil || settings.CustomVoicesPath == nil || *settings.CustomVoicesPath == "" {
+        return ""
+    }
+    path := filepath.Join(*settings.CustomVoicesPath, voice+".pt")
+    if !fileExists(path) {
+        return ""
+    }
+    return pathFingerprint(path)
+}
+
+// validateCustomVoicesPath rejects a CustomVoicesPath that isn't a readable directory
+func validateCustomVoicesPath(dir *string) error {
+    if dir == nil || *dir == "" {
+        return nil
+    }
+    info, err := os.Stat(*dir)
+    if err != nil {
+        return fmt.Errorf("invalid custom voices directory: %w", err)
+    }
+    if !info.IsDir() {
+        return fmt.Errorf("invalid custom voices directory: %s is not a directory", *dir)
+    }
+    return nil
+}
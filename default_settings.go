@@ -0,0 +1,55 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+)
+
+// newProjectSettings returns the settings a new project starts with: the user's saved
+// defaults if any, otherwise the built-in ones
+func (a *App) newProjectSettings() ProjectSettings {
+    settings, err := a.GetAppSettings()
+    if err != nil || settings.DefaultProjectSettings == nil {
+        return defaultProjectSettings()
+    }
+
+    // Copy so projects never share the defaults' slices (e.g. the glossary)
+    var copied ProjectSettings
+    data, err := json.Marshal(settings.DefaultProjectSettings)
+    if err != nil || json.Unmarshal(data, &copied) != nil {
+        return defaultProjectSettings()
+    }
+    return copied
+}
+
+// validateReusableSettings checks settings meant for more than one project, such as the
+// defaults or a preset
+func (a *App) validateReusableSettings(settings ProjectSettings) error {
+    if err := validateTranscriptionSettings(settings.Transcription); err != nil {
+        return fmt.Errorf("invalid transcription settings: %w", err)
+    }
+    if err := a.ValidateTranslationSettings(settings.Translation); err != nil {
+        return fmt.Errorf("invalid translation settings: %w", err)
+    }
+    if _, err := renderOutputFilename(settings.Output.FilenameTemplate, &ProjectConfig{Settings: settings}); err != nil {
+        return fmt.Errorf("invalid output filename template: %w", err)
+    }
+    return validateOutputFormat(settings.Output.Format)
+}
+
+// SetDefaultProjectSettings saves the settings every new project starts with
+func (a *App) SetDefaultProjectSettings(settings ProjectSettings) error {
+    if err := a.validateReusableSettings(settings); err != nil {
+        return err
+    }
+
+    // The audio stream index only means something for one particular source file
+    settings.Transcription.SourceAudioStream = nil
+
+    appSettings, err := a.GetAppSettings()
+    if err != nil {
+        return fmt.Errorf("failed to get app settings: %w", err)
+    }
+    appSettings.DefaultProjectSettings = &settings
+    return a.SaveAppSettings(appSettings)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "os"
+    "path/filepath"
+    "runtime"
+    "strings"
+)
+
+// inferenceDevices are the values the Device setting accepts
+var inferenceDevices = []string{"auto", "cpu", "cuda", "mps"}
+
+// validateDeviceSetting rejects devices that don't exist on this platform: MPS is Apple
+// Silicon only and macOS has no CUDA
+func validateDeviceSetting(device string) error {
+    device = strings.ToLower(strings.TrimSpace(device))
+    switch device {
+    case "", "auto", "cpu":
+        return nil
+    case "cuda":
+        if runtime.GOOS == "darwin" {
+            return fmt.Errorf("CUDA is not available on macOS")
+        }
+        return nil
+    case "mps":
+        if runtime.GOOS != "darwin" {
+            return fmt.Errorf("MPS is only available on macOS")
+        }
+        return nil
+    }
+    return fmt.Errorf("unsupported device: %s (supported: %s)", device, strings.Join(inferenceDevices, ", "))
+}
+
+// DetectAvailableDevices probes which inference devices the Python environment can use,
+// always including "auto" and "cpu"
+func (a *App) DetectAvailableDevices() ([]string, error) {
+    pythonDir := a.getPythonScriptsDir()
+    cmd := a.trackedCommand(a.getPythonCommand(), filepath.Join(pythonDir, "detect_devices.py"))
+    defer a.untrackCommand(cmd)
+    cmd.Dir = pythonDir
+    cmd.Env = append(os.Environ(), fmt.Sprintf("PYTHONPATH=%s", pythonDir))
+
+    var stderr bytes.Buffer
+    cmd.Stderr = &stderr
+    output, runErr := a.commandOutput(cmd)
+
+    var result struct {
+        Devices []string `json:"devices"`
+        Error   string   `json:"error"`
+    }
+    if err := parseTrailingJSON(output, &result); err != nil {
+        if runErr != nil {
+            return nil, fmt.Errorf("failed to detect devices: %v\nOutput: %s", runErr, stderr.String())
+        }
+        return nil, fmt.Errorf("failed to parse device list: %w", err)
+    }
+    if result.Error != "" {
+        return nil, fmt.Errorf("failed to detect devices: %s", result.Error)
+    }
+
+    devices := []string{"auto"}
+    for _, device := range result.Devices {
+        if device != "auto" && validateDeviceSetting(device) == nil {
+            devices = append(devices, device)
+        }
+    }
+    return devices, nil
+}
+
+// deviceEnv forwards the Device setting to the steps that run models locally. Synthesis
+// runs in the Kokoro server, which picks its own device, but receives it too for backends
+// that run in-process.
+func (a *App) deviceEnv() []string {
+    settings, err := a.GetAppSettings()
+    if err != nil {
+        return nil
+    }
+    device := strings.ToLower(strings.TrimSpace(settings.Device))
+    if device == "" || validateDeviceSetting(device) != nil {
+        return nil
+    }
+    return []string{fmt.Sprintf("INFERENCE_DEVICE=%s", device)}
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package main
+
+import "syscall"
+
+// diskFreeBytes returns the space available to the app on the volume holding dir
+func diskFreeBytes(dir string) (uint64, error) {
+    var stat syscall.Statfs_t
+    if err := syscall.Statfs(dir, &stat); err != nil {
+        return 0, err
+    }
+    return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package main
+
+import (
+    "syscall"
+    "unsafe"
+)
+
+var procGetDiskFreeSpaceEx = syscall.NewLazyDLL("kernel32.dll").NewProc("GetDiskFreeSpaceExW")
+
+// diskFreeBytes returns the space available to the app on the volume holding dir
+func diskFreeBytes(dir string) (uint64, error) {
+    path, err := syscall.UTF16PtrFromString(dir)
+    if err != nil {
+        return 0, err
+    }
+    var available uint64
+    ok, _, callErr := procGetDiskFreeSpaceEx.Call(uintptr(unsafe.Pointer(path)), uintptr(unsafe.Pointer(&available)), 0, 0)
+    if ok == 0 {
+        return 0, callErr
+    }
+    return available, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+    "fmt"
+    "regexp"
+    "sort"
+    "strings"
+)
+
+// downloadRateLimitPattern matches yt-dlp --limit-rate values: bytes per second with an
+// optional K/M/G suffix, e.g. "500K" or "1.5M"
+var downloadRateLimitPattern = regexp.MustCompile(`^\d+(\.\d+)?[KMGkmg]?$`)
+
+// downloadFormats maps the download qualities offered in the UI to yt-dlp -f selectors.
+// "best" is the best single file with both audio and video, which is also the default.
+var downloadFormats = map[string]string{
+    "best":      "best[ext=mp4]/best[ext=webm]/best",
+    "2160p":     "bestvideo[height<=2160]+bestaudio/best[height<=2160]",
+    "1080p":     "bestvideo[height<=1080]+bestaudio/best[height<=1080]",
+    "720p":      "bestvideo[height<=720]+bestaudio/best[height<=720]",
+    "480p":      "bestvideo[height<=480]+bestaudio/best[height<=480]",
+    "360p":      "bestvideo[height<=360]+bestaudio/best[height<=360]",
+    "bestaudio": "bestaudio[ext=m4a]/bestaudio",
+}
+
+const defaultDownloadFormat = "best"
+
+// validateDownloadFormat checks a project's download format is a known one and, for
+// audio-only downloads, that the output doesn't need a video track
+func validateDownloadFormat(project *ProjectConfig) error {
+    format := downloadFormatOrDefault(project.DownloadFormat)
+    if _, ok := downloadFormats[format]; !ok {
+        known := make([]string, 0, len(downloadFormats))
+        for name := range downloadFormats {
+            known = append(known, name)
+        }
+        sort.Strings(known)
+        return fmt.Errorf("unsupported download format: %s (supported: %s)", project.DownloadFormat, strings.Join(known, ", "))
+    }
+    if format == "bestaudio" && !project.AttachedVideo && !isAudioOutputFormat(project.Settings.Output.Format) {
+        return fmt.Errorf("an audio-only download needs an audio output format (%s)", strings.Join(audioOutputFormats, ", "))
+    }
+    return nil
+}
+
+func downloadFormatOrDefault(format string) string {
+    format = strings.ToLower(strings.TrimSpace(format))
+    if format == "" {
+        return defaultDownloadFormat
+    }
+    return format
+}
+
+// validateDownloadRateLimit rejects a DownloadRateLimit setting yt-dlp wouldn't understand
+func validateDownloadRateLimit(limit *string) error {
+    if limit == nil || strings.TrimSpace(*limit) == "" {
+        return nil
+    }
+    if !downloadRateLimitPattern.MatchString(strings.TrimSpace(*limit)) {
+        return fmt.Errorf("invalid download rate limit %q (expected e.g. \"500K\" or \"1M\")", *limit)
+    }
+    return nil
+}
+
+// downloadEnv returns the environment the download step needs from the project and app
+// settings. An invalid rate limit (e.g. from a hand-edited settings.json) is ignored with a
+// warning rather than failing the download.
+func (a *App) downloadEnv(project *ProjectConfig) ([]string, error) {
+    if err := validateDownloadFormat(project); err != nil {
+        return nil, err
+    }
+
+    format := downloadFormatOrDefault(project.DownloadFormat)
+    env := []string{fmt.Sprintf("DOWNLOAD_FORMAT=%s", downloadFormats[format])}
+    if format == "bestaudio" {
+        env = append(env, "DOWNLOAD_AUDIO_ONLY=1")
+    }
+
+    settings, err := a.GetAppSettings()
+    if err != nil || settings.DownloadRateLimit == nil {
+        return env, nil
+    }
+
+    limit := strings.TrimSpace(*settings.DownloadRateLimit)
+    if limit == "" {
+        return env, nil
+    }
+    if err := validateDownloadRateLimit(&limit); err != nil {
+        a.emitWarning(fmt.Sprintf("Ignoring download rate limit: %v", err))
+        return env, nil
+    }
+
+    return append(env, fmt.Sprintf("DOWNLOAD_RATE_LIMIT=%s", limit)), nil
+}
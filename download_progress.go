@@ -0,0 +1,150 @@
+package main
+
+import (
+    "regexp"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+// DownloadProgress is the payload of a "download:progress" event. For live streams and
+// downloads of unknown size Indeterminate is set and only DownloadedBytes is meaningful.
+type DownloadProgress struct {
+    ProjectID       string  `json:"projectId"`
+    Percent         float64 `json:"percent"`
+    Indeterminate   bool    `json:"indeterminate"`
+    DownloadedBytes int64   `json:"downloadedBytes"`
+    TotalBytes      int64   `json:"totalBytes,omitempty"`
+    // TotalEstimated is set when yt-dlp only knows an approximate size ("of ~ 120MiB")
+    TotalEstimated  bool    `json:"totalEstimated,omitempty"`
+    SpeedBytes      int64   `json:"speedBytes,omitempty"`
+    ETASeconds      *int    `json:"etaSeconds,omitempty"`
+}
+
+var (
+    // [download]  45.3% of ~ 120.45MiB at  2.31MiB/s ETA 00:30 (frag 3/40)
+    // [download] 100% of  120.45MiB in 00:00:52 at 2.31MiB/s
+    ytdlpPercentLine = regexp.MustCompile(`^\[download\]\s+(\d+(?:\.\d+)?)%\s+of\s+(~\s*)?(\S+)(?:\s+at\s+(\S+))?(?:\s+ETA\s+(\S+))?`)
+    // [download]   12.00MiB at  1.00MiB/s (00:00:12)
+    ytdlpUnknownSizeLine = regexp.MustCompile(`^\[download\]\s+(\d+(?:\.\d+)?[KMGT]?i?B)\s+at\s+(\S+)`)
+    ytdlpSize            = regexp.MustCompile(`^(\d+(?:\.\d+)?)([KMGT]?)(i?)B(?:/s)?$`)
+)
+
+// parseDownloadProgress decodes one of yt-dlp's progress lines
+func parseDownloadProgress(line string) (DownloadProgress, bool) {
+    line = strings.TrimSpace(line)
+    var progress DownloadProgress
+
+    if m := ytdlpPercentLine.FindStringSubmatch(line); m != nil {
+        percent, err := strconv.ParseFloat(m[1], 64)
+        if err != nil {
+            return progress, false
+        }
+        progress.Percent = min(max(percent, 0), 100)
+        total, ok := parseYtdlpSize(m[3])
+        if !ok {
+            // "of Unknown total size"
+            progress.Percent = 0
+            progress.Indeterminate = true
+            return progress, true
+        }
+        progress.TotalBytes = total
+        progress.TotalEstimated = m[2] != ""
+        progress.DownloadedBytes = int64(float64(total) * progress.Percent / 100)
+        if speed, ok := parseYtdlpSize(m[4]); ok {
+            progress.SpeedBytes = speed
+        }
+        if eta, ok := parseYtdlpDuration(m[5]); ok {
+            progress.ETASeconds = &eta
+        } else if progress.Percent == 100 {
+            done := 0
+            progress.ETASeconds = &done
+        }
+        return progress, true
+    }
+
+    if m := ytdlpUnknownSizeLine.FindStringSubmatch(line); m != nil {
+        downloaded, ok := parseYtdlpSize(m[1])
+        if !ok {
+            return progress, false
+        }
+        progress.Indeterminate = true
+        progress.DownloadedBytes = downloaded
+        if speed, ok := parseYtdlpSize(m[2]); ok {
+            progress.SpeedBytes = speed
+        }
+        return progress, true
+    }
+
+    return progress, false
+}
+
+// parseYtdlpSize parses sizes like "120.45MiB", "1.2GB" or "2.31MiB/s" into bytes
+func parseYtdlpSize(s string) (int64, bool) {
+    m := ytdlpSize.FindStringSubmatch(s)
+    if m == nil {
+        return 0, false
+    }
+    value, err := strconv.ParseFloat(m[1], 64)
+    if err != nil {
+        return 0, false
+    }
+    base := 1000.0
+    if m[3] == "i" {
+        base = 1024
+    }
+    for range strings.Index("KMGT", m[2]) + 1 {
+        value *= base
+    }
+    return int64(value), true
+}
+
+// parseYtdlpDuration parses "SS", "MM:SS" or "HH:MM:SS" into seconds
+func parseYtdlpDuration(s string) (int, bool) {
+    if s == "" {
+        return 0, false
+    }
+    seconds := 0
+    for _, part := range strings.Split(s, ":") {
+        n, err := strconv.Atoi(part)
+        if err != nil || n < 0 {
+            return 0, false
+        }
+        seconds = seconds*60 + n
+    }
+    return seconds, true
+}
+
+// downloadProgressInterval limits how often "download:progress" is sent to the frontend
+const downloadProgressInterval = 250 * time.Millisecond
+
+// downloadProgressEmitter re-emits yt-dlp progress as throttled "download:progress" events.
+// Completion is always sent so the UI never stalls just short of 100%.
+type downloadProgressEmitter struct {
+    app       *App
+    projectID string
+    mu        sync.Mutex
+    last      time.Time
+}
+
+// observe emits line's progress if it is a yt-dlp progress line and reports whether it was
+func (e *downloadProgressEmitter) observe(line string) bool {
+    progress, ok := parseDownloadProgress(line)
+    if !ok {
+        return false
+    }
+    progress.ProjectID = e.projectID
+
+    e.mu.Lock()
+    now := time.Now()
+    if progress.Percent < 100 && now.Sub(e.last) < downloadProgressInterval {
+        e.mu.Unlock()
+        return true
+    }
+    e.last = now
+    e.mu.Unlock()
+
+    e.app.emitEvent("download:progress", progress)
+    return true
+}
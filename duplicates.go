@@ -0,0 +1,273 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+)
+
+// projectEntry pairs a project config with the folder it was read from
+type projectEntry struct {
+    Dir    string
+    Config *ProjectConfig
+}
+
+// listProjects reads every project folder under the projects root (including categories),
+// skipping folders without a readable project.json
+func (a *App) listProjects() ([]projectEntry, error) {
+    a.flushAllProjectSaves()
+
+    settings, err := a.GetAppSettings()
+    if err != nil {
+        return nil, fmt.Errorf("failed to get app settings: %w", err)
+    }
+
+    projectDirs, err := projectFolders(settings.DefaultProjectsPath)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil, nil
+        }
+        return nil, fmt.Errorf("failed to read projects directory: %w", err)
+    }
+
+    var projects []projectEntry
+    for _, projectDir := range projectDirs {
+        project, err := readProjectConfig(projectDir)
+        if err != nil || project.ID == "" {
+            continue
+        }
+        projects = append(projects, projectEntry{Dir: projectDir, Config: project})
+    }
+
+    return projects, nil
+}
+
+// duplicateKey identifies projects made from the same source for the same language. A local
+// file's video ID is made up at import, so local projects are matched on the file instead.
+func duplicateKey(projectDir string, project *ProjectConfig) string {
+    source := ""
+    if isLocalSource(project) {
+        source = localSourceFingerprint(projectDir, project)
+    } else if project.VideoId != nil {
+        source = *project.VideoId
+    }
+    if source == "" {
+        return ""
+    }
+    return source + "|" + strings.ToLower(project.TargetLanguage)
+}
+
+// localSourceFingerprint fingerprints the file a local project was made from: the file it
+// links to or, once copied into the project, the file it was copied from
+func localSourceFingerprint(projectDir string, project *ProjectConfig) string {
+    ref := project.FileReferences.VideoFile
+    if ref == nil || project.AttachedVideo {
+        ref = project.FileReferences.AudioFile
+    }
+    if ref == nil {
+        return ""
+    }
+    if ref.OriginalPath != nil && *ref.OriginalPath != "" {
+        return pathFingerprint(*ref.OriginalPath)
+    }
+    return fileReferenceFingerprint(projectDir, ref)
+}
+
+// FindDuplicateProjects groups projects sharing the same source and target language: the
+// same video ID for downloads, the same input file for local files. It only reports;
+// nothing is changed until MergeProjects is called.
+func (a *App) FindDuplicateProjects() ([][]ProjectConfig, error) {
+    projects, err := a.listProjects()
+    if err != nil {
+        return nil, err
+    }
+
+    groups := make(map[string][]ProjectConfig)
+    for _, p := range projects {
+        if key := duplicateKey(p.Dir, p.Config); key != "" {
+            groups[key] = append(groups[key], *p.Config)
+        }
+    }
+
+    duplicates := make([][]ProjectConfig, 0)
+    for _, group := range groups {
+        if len(group) < 2 {
+            continue
+        }
+        // Oldest first, so the original tends to be the natural one to keep
+        sort.Slice(group, func(i, j int) bool {
+            return group[i].Created < group[j].Created
+        })
+        duplicates = append(duplicates, group)
+    }
+    sort.Slice(duplicates, func(i, j int) bool {
+        return duplicates[i][0].Created < duplicates[j][0].Created
+    })
+
+    return duplicates, nil
+}
+
+// mergedDataDirs are the project subfolders whose files are moved into the kept project
+var mergedDataDirs = []string{"input", "transcripts", "audio", "output"}
+
+// MergeProjects moves the data of the duplicate projects in mergeIDs into keepID and then
+// deletes them. Files and references the kept project already has always win.
+func (a *App) MergeProjects(keepID string, mergeIDs []string) error {
+    keep, err := a.LoadProject(keepID)
+    if err != nil {
+        return fmt.Errorf("failed to load project to keep: %w", err)
+    }
+    keepDir, err := a.findProjectDirectory(keepID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+
+    // Check everything up front so a bad ID doesn't leave a half-merged state
+    keepKey := duplicateKey(keepDir, keep)
+    merges := make([]projectEntry, 0, len(mergeIDs))
+    for _, id := range mergeIDs {
+        if id == keepID {
+            return fmt.Errorf("cannot merge a project into itself: %s", id)
+        }
+        project, err := a.LoadProject(id)
+        if err != nil {
+            return fmt.Errorf("failed to load project %s: %w", id, err)
+        }
+        dir, err := a.findProjectDirectory(id)
+        if err != nil {
+            return fmt.Errorf("project not found: %w", err)
+        }
+        if key := duplicateKey(dir, project); key == "" || key != keepKey {
+            return fmt.Errorf("project %q is not a duplicate of %q", project.Name, keep.Name)
+        }
+        merges = append(merges, projectEntry{Dir: dir, Config: project})
+    }
+
+    for _, merge := range merges {
+        if err := moveProjectData(merge.Dir, keepDir); err != nil {
+            return fmt.Errorf("failed to move files from %q: %w", merge.Config.Name, err)
+        }
+        mergeProjectConfig(keep, merge.Config)
+    }
+
+    // Adopted outputs count as completed steps
+    for _, step := range projectPipelineSteps(keep) {
+        if !isStepCompleted(keep.CompletedSteps, step) && stepOutputsExist(keepDir, keep, step) {
+            for _, merge := range merges {
+                if isStepCompleted(merge.Config.CompletedSteps, step) {
+                    setStepCompleted(&keep.CompletedSteps, step, true)
+                    break
+                }
+            }
+        }
+    }
+
+    if err := a.UpdateProject(keep); err != nil {
+        return fmt.Errorf("failed to save merged project: %w", err)
+    }
+
+    for _, merge := range merges {
+        if err := a.DeleteProject(merge.Config.ID); err != nil {
+            return fmt.Errorf("failed to delete merged project %q: %w", merge.Config.Name, err)
+        }
+    }
+
+    return nil
+}
+
+// moveProjectData moves data files into the same subfolders of destDir, leaving any file
+// the destination already has untouched
+func moveProjectData(srcDir, destDir string) error {
+    for _, sub := range mergedDataDirs {
+        entries, err := os.ReadDir(filepath.Join(srcDir, sub))
+        if err != nil {
+            continue
+        }
+        if err := os.MkdirAll(filepath.Join(destDir, sub), 0755); err != nil {
+            return err
+        }
+        for _, entry := range entries {
+            if entry.IsDir() {
+                continue
+            }
+            dest := filepath.Join(destDir, sub, entry.Name())
+            if _, err := os.Stat(dest); err == nil {
+                continue
+            }
+            if err := os.Rename(filepath.Join(srcDir, sub, entry.Name()), dest); err != nil {
+                return err
+            }
+        }
+    }
+    return nil
+}
+
+// mergeProjectConfig fills references and rules missing from keep with those of merge
+func mergeProjectConfig(keep, merge *ProjectConfig) {
+    refs := &keep.FileReferences
+    if refs.VideoFile == nil {
+        refs.VideoFile = merge.FileReferences.VideoFile
+    }
+    if refs.AudioFile == nil {
+        refs.AudioFile = merge.FileReferences.AudioFile
+    }
+    if refs.SegmentsFile == nil {
+        refs.SegmentsFile = merge.FileReferences.SegmentsFile
+    }
+    if refs.FinalAudio == nil {
+        refs.FinalAudio = merge.FileReferences.FinalAudio
+    }
+    if refs.FinalVideo == nil {
+        refs.FinalVideo = merge.FileReferences.FinalVideo
+    }
+    if keep.SourceUrl == nil {
+        keep.SourceUrl = merge.SourceUrl
+    }
+
+    textRuleIDs := make(map[string]bool)
+    for _, rule := range keep.TextRules {
+        textRuleIDs[rule.ID] = true
+    }
+    for _, rule := range merge.TextRules {
+        if !textRuleIDs[rule.ID] {
+            keep.TextRules = append(keep.TextRules, rule)
+        }
+    }
+
+    segmentRuleIDs := make(map[string]bool)
+    for _, rule := range keep.SegmentRules {
+        segmentRuleIDs[rule.ID] = true
+    }
+    for _, rule := range merge.SegmentRules {
+        if !segmentRuleIDs[rule.ID] {
+            keep.SegmentRules = append(keep.SegmentRules, rule)
+        }
+    }
+
+    glossaryIDs := make(map[string]bool)
+    for _, entry := range keep.Settings.Translation.Glossary {
+        glossaryIDs[entry.ID] = true
+    }
+    for _, entry := range merge.Settings.Translation.Glossary {
+        if !glossaryIDs[entry.ID] {
+            keep.Settings.Translation.Glossary = append(keep.Settings.Translation.Glossary, entry)
+        }
+    }
+}
+
+func setStepCompleted(steps *CompletedSteps, step string, completed bool) {
+    switch step {
+    case "download", "import":
+        steps.Download = completed
+    case "transcribe":
+        steps.Transcribe = completed
+    case "translate":
+        steps.Translate = completed
+    case "synthesize":
+        steps.Synthesize = completed
+    case "combine":
+        steps.Combine = completed
+    }
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+    "fmt"
+    "regexp"
+    "sort"
+    "strings"
+)
+
+// envNamePattern matches portable environment variable names
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// protectedEnvVars can't be overridden per project: changing them would stop the pipeline
+// from finding its interpreter, scripts or output folders. KOKORO_* is protected as a prefix.
+var protectedEnvVars = []string{"PATH", "PYTHONPATH"}
+
+const protectedEnvPrefix = "KOKORO_"
+
+// isProtectedEnvVar reports whether name is reserved for the pipeline. Windows treats
+// variable names case-insensitively, so the check does too.
+func isProtectedEnvVar(name string) bool {
+    upper := strings.ToUpper(name)
+    if strings.HasPrefix(upper, protectedEnvPrefix) {
+        return true
+    }
+    for _, protected := range protectedEnvVars {
+        if upper == protected {
+            return true
+        }
+    }
+    return false
+}
+
+// validateEnvOverrides rejects override names that are malformed or protected
+func validateEnvOverrides(overrides map[string]string) error {
+    for name := range overrides {
+        if !envNamePattern.MatchString(name) {
+            return fmt.Errorf("invalid environment variable name: %q", name)
+        }
+        if isProtectedEnvVar(name) {
+            return fmt.Errorf("environment variable %s cannot be overridden (protected: %s, %s*)",
+                name, strings.Join(protectedEnvVars, ", "), protectedEnvPrefix)
+        }
+    }
+    return nil
+}
+
+// envOverrideList renders a project's overrides as NAME=value entries in a stable order.
+// Protected names are skipped in case project.json was edited by hand.
+func envOverrideList(overrides map[string]string) []string {
+    names := make([]string, 0, len(overrides))
+    for name := range overrides {
+        if envNamePattern.MatchString(name) && !isProtectedEnvVar(name) {
+            names = append(names, name)
+        }
+    }
+    sort.Strings(names)
+
+    env := make([]string, 0, len(names))
+    for _, name := range names {
+        env = append(env, fmt.Sprintf("%s=%s", name, overrides[name]))
+    }
+    return env
+}
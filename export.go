@@ -0,0 +1,168 @@
+package main
+
+import (
+    "archive/zip"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// SegmentAudioManifestEntry describes one clip in an exported segment audio archive
+type SegmentAudioManifestEntry struct {
+    File           string  `json:"file"`
+    Index          int     `json:"index"`
+    Speaker        string  `json:"speaker"`
+    Start          float64 `json:"start"`
+    End            float64 `json:"end"`
+    OriginalText   string  `json:"originalText"`
+    TranslatedText string  `json:"translatedText"`
+}
+
+// ExportSegmentAudio zips every synthesized segment clip, plus a manifest.json describing
+// each one, into an archive at destPath. Files are streamed into the archive one at a time,
+// with "export:progress" events along the way; CancelExport stops it and removes the archive.
+func (a *App) ExportSegmentAudio(projectID, destPath string) error {
+    projectDir, _, segments, err := a.loadProjectSegments(projectID)
+    if err != nil {
+        return err
+    }
+
+    // Pre-scan the clip sizes so progress can be reported as a percentage
+    var totalBytes int64
+    for i, segment := range segments {
+        if info, err := os.Stat(segmentAudioPath(projectDir, i, segment)); err == nil {
+            totalBytes += info.Size()
+        }
+    }
+
+    progress, done, err := a.beginExport(destPath, totalBytes)
+    if err != nil {
+        return err
+    }
+    defer done()
+
+    if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+        return fmt.Errorf("failed to create destination directory: %w", err)
+    }
+
+    out, err := os.Create(destPath)
+    if err != nil {
+        return fmt.Errorf("failed to create archive: %w", err)
+    }
+    defer out.Close()
+
+    archive := zip.NewWriter(out)
+    manifest := make([]SegmentAudioManifestEntry, 0, len(segments))
+
+    for i, segment := range segments {
+        audioPath := segmentAudioPath(projectDir, i, segment)
+        if !fileExists(audioPath) {
+            continue
+        }
+
+        speaker := segment.Speaker
+        if speaker == "" {
+            speaker = "SPEAKER_UNKNOWN"
+        }
+        name := fmt.Sprintf("%03d_%s_%s%s", i, sanitizeForFilename(speaker), formatClipTimestamp(segment.Start), filepath.Ext(audioPath))
+
+        if err := addFileToZip(archive, audioPath, name, progress); err != nil {
+            archive.Close()
+            out.Close()
+            os.Remove(destPath)
+            if errors.Is(err, errExportCancelled) {
+                return err
+            }
+            return fmt.Errorf("failed to add segment %d: %w", i, err)
+        }
+
+        manifest = append(manifest, SegmentAudioManifestEntry{
+            File:           name,
+            Index:          i,
+            Speaker:        speaker,
+            Start:          segment.Start,
+            End:            segment.End,
+            OriginalText:   segment.OriginalText,
+            TranslatedText: segment.TranslatedText,
+        })
+    }
+
+    if len(manifest) == 0 {
+        archive.Close()
+        os.Remove(destPath)
+        return fmt.Errorf("no synthesized segment audio found, run synthesis first")
+    }
+
+    manifestData, err := json.MarshalIndent(manifest, "", "  ")
+    if err != nil {
+        archive.Close()
+        os.Remove(destPath)
+        return fmt.Errorf("failed to marshal manifest: %w", err)
+    }
+
+    writer, err := archive.Create("manifest.json")
+    if err == nil {
+        _, err = writer.Write(manifestData)
+    }
+    if err != nil {
+        archive.Close()
+        os.Remove(destPath)
+        return fmt.Errorf("failed to write manifest: %w", err)
+    }
+
+    if err := archive.Close(); err != nil {
+        os.Remove(destPath)
+        return fmt.Errorf("failed to finalize archive: %w", err)
+    }
+
+    // A cancel that lands after the last clip was copied still discards the archive
+    if err := progress.cancelled(); err != nil {
+        out.Close()
+        os.Remove(destPath)
+        return err
+    }
+
+    return nil
+}
+
+// addFileToZip streams a file on disk into the archive under the given name, counting the
+// bytes into progress if given
+func addFileToZip(archive *zip.Writer, srcPath, name string, progress *exportTracker) error {
+    src, err := os.Open(srcPath)
+    if err != nil {
+        return err
+    }
+    defer src.Close()
+
+    info, err := src.Stat()
+    if err != nil {
+        return err
+    }
+
+    header, err := zip.FileInfoHeader(info)
+    if err != nil {
+        return err
+    }
+    header.Name = filepath.ToSlash(name)
+    header.Method = zip.Deflate
+
+    writer, err := archive.CreateHeader(header)
+    if err != nil {
+        return err
+    }
+
+    _, err = io.Copy(progress.wrap(writer), src)
+    return err
+}
+
+// formatClipTimestamp renders seconds as e.g. "01m02.500s" for use in filenames
+func formatClipTimestamp(seconds float64) string {
+    d := time.Duration(seconds * float64(time.Second))
+    minutes := int(d / time.Minute)
+    rest := (d % time.Minute).Seconds()
+    return fmt.Sprintf("%02dm%06.3fs", minutes, rest)
+}
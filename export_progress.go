@@ -0,0 +1,135 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+)
+
+// errExportCancelled is returned by an export stopped through CancelExport
+var errExportCancelled = errors.New("export cancelled")
+
+// ExportProgress is the payload of "export:progress" events
+type ExportProgress struct {
+    DestPath     string  `json:"destPath"`
+    Percent      float64 `json:"percent"`
+    BytesWritten int64   `json:"bytesWritten"`
+    TotalBytes   int64   `json:"totalBytes"`
+}
+
+// exportTracker counts the bytes copied into an archive and emits progress as it goes.
+// Its context is cancelled by CancelExport (or on shutdown).
+type exportTracker struct {
+    app         *App
+    ctx         context.Context
+    destPath    string
+    total       int64
+    written     int64
+    lastPercent int
+}
+
+// beginExport registers an export to destPath whose inputs total totalBytes. The returned
+// function must be called once the export finishes.
+func (a *App) beginExport(destPath string, totalBytes int64) (*exportTracker, func(), error) {
+    a.exportsMu.Lock()
+    defer a.exportsMu.Unlock()
+
+    if _, running := a.exports[destPath]; running {
+        return nil, nil, fmt.Errorf("an export to %s is already running", destPath)
+    }
+
+    ctx, cancel := context.WithCancel(a.runCtx)
+    if a.exports == nil {
+        a.exports = make(map[string]context.CancelFunc)
+    }
+    a.exports[destPath] = cancel
+
+    tracker := &exportTracker{
+        app:         a,
+        ctx:         ctx,
+        destPath:    destPath,
+        total:       totalBytes,
+        lastPercent: -1,
+    }
+    tracker.emit()
+
+    done := func() {
+        a.exportsMu.Lock()
+        delete(a.exports, destPath)
+        a.exportsMu.Unlock()
+        cancel()
+    }
+    return tracker, done, nil
+}
+
+// CancelExport stops the running export to destPath; the partial archive is deleted
+func (a *App) CancelExport(destPath string) error {
+    a.exportsMu.Lock()
+    cancel, ok := a.exports[destPath]
+    a.exportsMu.Unlock()
+
+    if !ok {
+        return fmt.Errorf("no export to %s is running", destPath)
+    }
+    cancel()
+    return nil
+}
+
+// wrap returns a writer that counts bytes into the tracker and stops once cancelled.
+// A nil tracker passes writes straight through.
+func (t *exportTracker) wrap(w io.Writer) io.Writer {
+    if t == nil {
+        return w
+    }
+    return &exportWriter{tracker: t, w: w}
+}
+
+// cancelled reports errExportCancelled once the export has been cancelled
+func (t *exportTracker) cancelled() error {
+    if t != nil && t.ctx.Err() != nil {
+        return errExportCancelled
+    }
+    return nil
+}
+
+func (t *exportTracker) add(n int64) {
+    t.written += n
+    t.emit()
+}
+
+// emit sends a progress event whenever the whole-percent value changes
+func (t *exportTracker) emit() {
+    percent := 100.0
+    if t.total > 0 {
+        percent = float64(t.written) * 100 / float64(t.total)
+        if percent > 100 {
+            percent = 100
+        }
+    }
+    if int(percent) == t.lastPercent {
+        return
+    }
+    t.lastPercent = int(percent)
+
+    t.app.emitEvent("export:progress", ExportProgress{
+        DestPath:     t.destPath,
+        Percent:      percent,
+        BytesWritten: t.written,
+        TotalBytes:   t.total,
+    })
+}
+
+type exportWriter struct {
+    tracker *exportTracker
+    w       io.Writer
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+    if err := e.tracker.cancelled(); err != nil {
+        return 0, err
+    }
+    n, err := e.w.Write(p)
+    e.tracker.add(int64(n))
+    return n, err
+}
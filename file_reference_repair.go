@@ -0,0 +1,221 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+    "time"
+)
+
+// sourceDataDirs is where a project keeps its copies of source media
+var sourceDataDirs = []string{"input"}
+
+// RepairReport lists what RepairFileReferences changed and what it couldn't fix
+type RepairReport struct {
+    Fixed  []RepairedReference `json:"fixed"`
+    Broken []RepairedReference `json:"broken"`
+    // Unchanged counts references that already pointed at an existing file
+    Unchanged int `json:"unchanged"`
+}
+
+// RepairedReference is one file reference RepairFileReferences looked at
+type RepairedReference struct {
+    Role    string `json:"role"`
+    OldPath string `json:"oldPath"`
+    NewPath string `json:"newPath,omitempty"`
+    Reason  string `json:"reason,omitempty"`
+}
+
+// RepairFileReferences fixes paths a project folder move left stale: absolute paths into
+// the project's old location are rebuilt relative to where it is now, linked sources that
+// vanished fall back to their copy in input/, and repaired files are re-statted. Anything
+// that still can't be found is reported as broken.
+func (a *App) RepairFileReferences(projectID string) (RepairReport, error) {
+    report := RepairReport{Fixed: []RepairedReference{}, Broken: []RepairedReference{}}
+
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return report, fmt.Errorf("project not found: %w", err)
+    }
+    project, err := readProjectConfig(projectDir)
+    if err != nil {
+        return report, fmt.Errorf("failed to load project: %w", err)
+    }
+
+    outputDir := ""
+    if project.OutputDir != nil {
+        outputDir = *project.OutputDir
+    }
+
+    refs := &project.FileReferences
+    for _, source := range []struct {
+        role string
+        ref  *FileReference
+    }{{"video", refs.VideoFile}, {"audio", refs.AudioFile}} {
+        if source.ref != nil {
+            report.add(repairSourceReference(projectDir, source.role, source.ref))
+        }
+    }
+    for _, output := range []struct {
+        role  string
+        path  *string
+        extra string
+    }{
+        {"segments", refs.SegmentsFile, ""},
+        {"trimmed", refs.TrimmedFile, ""},
+        {"finalAudio", refs.FinalAudio, outputDir},
+        {"finalVideo", refs.FinalVideo, outputDir},
+    } {
+        if output.path != nil {
+            report.add(repairPathReference(projectDir, output.role, output.path, output.extra))
+        }
+    }
+
+    if len(report.Fixed) > 0 {
+        if err := a.saveProjectConfig(projectDir, project); err != nil {
+            return report, fmt.Errorf("failed to save repaired references: %w", err)
+        }
+        appendProjectLog(projectDir, "repair: fixed %d file reference(s), %d still broken", len(report.Fixed), len(report.Broken))
+        a.emitEvent("project:updated", projectID)
+    }
+    return report, nil
+}
+
+// add files a result under fixed, broken or unchanged; nil means unchanged
+func (r *RepairReport) add(result *RepairedReference) {
+    switch {
+    case result == nil:
+        r.Unchanged++
+    case result.NewPath == "":
+        r.Broken = append(r.Broken, *result)
+    default:
+        r.Fixed = append(r.Fixed, *result)
+    }
+}
+
+// repairSourceReference repairs a video or audio source reference in place
+func repairSourceReference(projectDir, role string, ref *FileReference) *RepairedReference {
+    oldPath := ref.Path
+    current := resolveFileReferencePath(projectDir, ref)
+
+    if fileExists(current) {
+        if ref.IsLinked || !filepath.IsAbs(ref.Path) {
+            return nil
+        }
+        // A copied file stored by absolute path breaks on the next move
+        rel, ok := relativeInside(projectDir, current)
+        if !ok {
+            return nil
+        }
+        ref.Path = rel
+        return &RepairedReference{Role: role, OldPath: oldPath, NewPath: rel, Reason: "made relative to the project folder"}
+    }
+
+    if ref.IsLinked {
+        if ref.OriginalPath != nil && *ref.OriginalPath != ref.Path && fileExists(*ref.OriginalPath) {
+            ref.Path = *ref.OriginalPath
+            restatFileReference(ref, ref.Path)
+            return &RepairedReference{Role: role, OldPath: oldPath, NewPath: ref.Path, Reason: "linked file found at its original path"}
+        }
+        if rel, ok := locateInProject(projectDir, ref.Path, sourceDataDirs); ok {
+            ref.Path = rel
+            ref.IsLinked = false
+            restatFileReference(ref, filepath.Join(projectDir, rel))
+            return &RepairedReference{Role: role, OldPath: oldPath, NewPath: rel, Reason: "linked file missing; using the project's copy"}
+        }
+        return &RepairedReference{Role: role, OldPath: oldPath, Reason: "linked file not found; relink it"}
+    }
+
+    if rel, ok := locateInProject(projectDir, ref.Path, sourceDataDirs); ok {
+        ref.Path = rel
+        restatFileReference(ref, filepath.Join(projectDir, rel))
+        return &RepairedReference{Role: role, OldPath: oldPath, NewPath: rel, Reason: "rebuilt against the current project folder"}
+    }
+    return &RepairedReference{Role: role, OldPath: oldPath, Reason: "file not found in the project folder"}
+}
+
+// repairPathReference repairs a plain path reference (relative to the project, or absolute
+// for outputs written to a custom output directory) in place
+func repairPathReference(projectDir, role string, path *string, outputDir string) *RepairedReference {
+    oldPath := *path
+    current := resolveProjectPath(projectDir, oldPath)
+
+    if fileExists(current) {
+        if !filepath.IsAbs(oldPath) {
+            return nil
+        }
+        rel, ok := relativeInside(projectDir, current)
+        if !ok {
+            return nil
+        }
+        *path = rel
+        return &RepairedReference{Role: role, OldPath: oldPath, NewPath: rel, Reason: "made relative to the project folder"}
+    }
+
+    if rel, ok := locateInProject(projectDir, oldPath, mergedDataDirs); ok {
+        *path = rel
+        return &RepairedReference{Role: role, OldPath: oldPath, NewPath: rel, Reason: "rebuilt against the current project folder"}
+    }
+    if outputDir != "" {
+        candidate := filepath.Join(outputDir, filepath.Base(filepath.FromSlash(oldPath)))
+        if candidate != current && fileExists(candidate) {
+            *path = candidate
+            return &RepairedReference{Role: role, OldPath: oldPath, NewPath: candidate, Reason: "found in the project's output directory"}
+        }
+    }
+    return &RepairedReference{Role: role, OldPath: oldPath, Reason: "file not found"}
+}
+
+// locateInProject looks for a stale path inside the project folder: first by the part of
+// the path from a project data folder on ("…/old/place/output/x.mp4" → "output/x.mp4"),
+// then by file name in each of dirs. Paths may come from another OS, so both
+// separators are accepted.
+func locateInProject(projectDir, path string, dirs []string) (string, bool) {
+    parts := strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' })
+    if len(parts) == 0 {
+        return "", false
+    }
+
+    for i, part := range parts {
+        for _, dir := range dirs {
+            if part != dir {
+                continue
+            }
+            rel := filepath.Join(parts[i:]...)
+            if fileExists(filepath.Join(projectDir, rel)) {
+                return rel, true
+            }
+        }
+    }
+
+    base := parts[len(parts)-1]
+    for _, dir := range dirs {
+        rel := filepath.Join(dir, base)
+        if fileExists(filepath.Join(projectDir, rel)) {
+            return rel, true
+        }
+    }
+    return "", false
+}
+
+// relativeInside returns path relative to dir if it lies inside it
+func relativeInside(dir, path string) (string, bool) {
+    rel, err := filepath.Rel(dir, path)
+    if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+        return "", false
+    }
+    return rel, true
+}
+
+// restatFileReference records the current size and modification time of a repaired file
+func restatFileReference(ref *FileReference, path string) {
+    info, err := os.Stat(path)
+    if err != nil {
+        return
+    }
+    size := info.Size()
+    modTime := info.ModTime().Format(time.RFC3339)
+    ref.Size = &size
+    ref.LastModified = &modTime
+}
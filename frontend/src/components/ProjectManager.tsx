@@ -133,7 +133,7 @@ const ProjectManager: React.FC = () => {
                             </label>
                             <div className="flex gap-2">
                                 {[
-                                    { type: 'youtube' as const, icon: Link, label: 'YouTube URL' },
+                                    { type: 'youtube' as const, icon: Link, label: 'Video URL' },
                                     { type: 'video' as const, icon: Video, label: 'Video File' },
                                     { type: 'audio' as const, icon: FileAudio, label: 'Audio File' }
                                 ].map(({ type, icon: Icon, label }) => (
@@ -155,7 +155,7 @@ const ProjectManager: React.FC = () => {
                         {/* Source Input */}
                         <div>
                             <label className="block text-sm font-medium text-purple-300 mb-2">
-                                {sourceType === 'youtube' ? 'YouTube URL' : 'File Path'}
+                                {sourceType === 'youtube' ? 'Video URL' : 'File Path'}
                             </label>
                             <input
                                 type="text"
@@ -175,7 +175,7 @@ const WelcomeScreen: React.FC = () => {
                                 </label>
                                 <div className="flex gap-2">
                                     {[
-                                        { type: 'youtube' as const, icon: Link, label: 'YouTube URL' },
+                                        { type: 'youtube' as const, icon: Link, label: 'Video URL' },
                                         { type: 'video' as const, icon: Video, label: 'Video File' },
                                         { type: 'audio' as const, icon: FileAudio, label: 'Audio File' }
                                     ].map(({ type, icon: Icon, label }) => (
@@ -199,7 +199,7 @@ const WelcomeScreen: React.FC = () => {
                                 {sourceType === 'youtube' ? (
                                     <div>
                                         <label className="block text-sm font-medium text-purple-300 mb-2">
-                                            YouTube URL
+                                            Video URL
                                         </label>
                                         <input
                                             type="text"
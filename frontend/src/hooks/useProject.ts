@@ -6,6 +6,7 @@ import {
     UpdateProject,
     GetRecentProjects,
     RunPipelineStep,
+    RerunPipelineStep,
     RunFullPipeline,
     CopyLinkedFilesToProject,
     DeleteProject,
@@ -176,7 +177,7 @@ export const useProject = () => {
         }
     }, []);
 
-    const runPipelineStep = useCallback(async (step: string): Promise<any> => {
+    const runPipelineStep = useCallback(async (step: string, force: boolean = false): Promise<any> => {
         if (!state.currentProject) {
             throw new Error('No current project');
         }
@@ -185,7 +186,9 @@ export const useProject = () => {
             projectStore.setLoading(true);
             projectStore.setError(null);
 
-            const result = await RunPipelineStep(state.currentProject.id, step);
+            const result = force
+                ? await RerunPipelineStep(state.currentProject.id, step)
+                : await RunPipelineStep(state.currentProject.id, step);
 
             // Reload project to get updated completion status
             await loadProject(state.currentProject.id);
@@ -22,7 +22,7 @@ export interface ExtendedPipelineConfig {
     textRules: any[];
     segmentRules: any[];
     transcriptionSettings: {
-        source: 'whisperx' | 'youtube' | 'upload';
+        source: 'whisperx' | 'faster-whisper' | 'openai';
         enableDiarization: boolean;
         language: string;
     };
@@ -104,7 +104,7 @@ const DubbingPipeline: React.FC<{
     // Advanced settings
     const [showAdvancedSettings, setShowAdvancedSettings] = useState(false);
     const [transcriptionSettings, setTranscriptionSettings] = useState({
-        source: 'whisperx' as 'whisperx' | 'youtube' | 'upload',
+        source: 'whisperx' as 'whisperx' | 'faster-whisper' | 'openai',
         enableDiarization: true,
         language: 'en'
     });
@@ -502,8 +502,8 @@ const DubbingPipeline: React.FC<{
                                             className="w-full px-3 py-2 bg-gray-600 text-white rounded border border-gray-500 focus:border-purple-500 focus:outline-none"
                                         >
                                             <option value="whisperx">WhisperX (Local AI)</option>
-                                            <option value="youtube">YouTube Official Captions</option>
-                                            <option value="upload">Upload Transcript File</option>
+                                            <option value="faster-whisper">faster-whisper (Local AI, no alignment)</option>
+                                            <option value="openai">OpenAI Speech to Text (Cloud)</option>
                                         </select>
                                     </div>
                                     <div className="flex items-center gap-3">
@@ -568,11 +568,16 @@ const DubbingPipeline: React.FC<{
                                             <div>
                                                 <label className="block text-sm text-purple-300 mb-2">Model</label>
                                                 <select
-                                                    value={translationSettings.simpleModel}
-                                                    onChange={(e) => setTranslationSettings(prev => ({
-                                                        ...prev,
-                                                        simpleModel: e.target.value
-                                                    }))}
+                                                    value={translationSettings.cloudProvider || translationSettings.simpleModel}
+                                                    onChange={(e) => {
+                                                        const value = e.target.value;
+                                                        const isCloud = ['anthropic', 'deepl', 'google'].includes(value);
+                                                        setTranslationSettings(prev => ({
+                                                            ...prev,
+                                                            simpleModel: isCloud ? prev.simpleModel : value,
+                                                            cloudProvider: isCloud ? value : ''
+                                                        }));
+                                                    }}
                                                     className="w-full px-3 py-2 bg-gray-600 text-white rounded border border-gray-500 focus:border-purple-500 focus:outline-none"
                                                 >
                                                     <optgroup label="Local Models">
@@ -581,9 +586,10 @@ const DubbingPipeline: React.FC<{
                                                         <option value="marian">MarianMT (Efficient)</option>
                                                         <option value="local_llm">Local LLM (Qwen)</option>
                                                     </optgroup>
-                                                    <optgroup label="Cloud Models">
-                                                        <option value="google_translate">Google Translate API</option>
-                                                        <option value="deepl">DeepL API</option>
+                                                    <optgroup label="Cloud Providers">
+                                                        <option value="anthropic">Anthropic Claude</option>
+                                                        <option value="google">Google Cloud Translation</option>
+                                                        <option value="deepl">DeepL</option>
                                                     </optgroup>
                                                 </select>
                                             </div>
@@ -823,7 +829,7 @@ const DubbingPipeline: React.FC<{
                     )}
 
                     {/* Cloud Service Warnings */}
-                    {(translationSettings.simpleModel.includes('google') || translationSettings.simpleModel.includes('deepl')) && (
+                    {translationSettings.cloudProvider && (
                         <div className="bg-orange-900/20 border border-orange-500/30 rounded-lg p-4">
                             <h3 className="text-orange-300 font-medium mb-2 flex items-center gap-2">
                                 <Cloud size={18} />
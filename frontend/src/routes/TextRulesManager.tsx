@@ -8,6 +8,7 @@ interface TextRule {
     language: string;
     priority: 'high' | 'medium' | 'low';
     caseSensitive: boolean;
+    isRegex?: boolean;
     createdAt: Date;
 }
 
@@ -18,6 +19,7 @@ const TextRulesManager: React.FC = () => {
     const [language, setLanguage] = useState('es');
     const [priority, setPriority] = useState<'high' | 'medium' | 'low'>('medium');
     const [caseSensitive, setCaseSensitive] = useState(false);
+    const [isRegex, setIsRegex] = useState(false);
     const [statusMessage, setStatusMessage] = useState('');
     const [editingRule, setEditingRule] = useState<string | null>(null);
 
@@ -41,6 +43,7 @@ const TextRulesManager: React.FC = () => {
             language,
             priority,
             caseSensitive,
+            isRegex,
             createdAt: new Date()
         };
 
@@ -61,6 +64,7 @@ const TextRulesManager: React.FC = () => {
         setLanguage('es');
         setPriority('medium');
         setCaseSensitive(false);
+        setIsRegex(false);
     };
 
     const editRule = (rule: TextRule) => {
@@ -69,6 +73,7 @@ const TextRulesManager: React.FC = () => {
         setLanguage(rule.language);
         setPriority(rule.priority);
         setCaseSensitive(rule.caseSensitive);
+        setIsRegex(Boolean(rule.isRegex));
         setEditingRule(rule.id);
         showStatus('Editing rule - click Add Rule to save changes', 'info');
     };
@@ -166,6 +171,7 @@ const TextRulesManager: React.FC = () => {
                         priority: (item.priority === 'high' || item.priority === 'medium' || item.priority === 'low')
                             ? item.priority : 'medium',
                         caseSensitive: Boolean(item.caseSensitive),
+                        isRegex: Boolean(item.isRegex),
                         createdAt: item.created ? new Date(item.created) : (item.createdAt ? new Date(item.createdAt) : new Date())
                     }))
                     .filter(rule => rule.originalText && rule.replacementText);
@@ -302,6 +308,16 @@ const TextRulesManager: React.FC = () => {
                     <label htmlFor="case-sensitive" className="text-purple-300">
                         Case sensitive matching
                     </label>
+                    <input
+                        type="checkbox"
+                        id="is-regex"
+                        checked={isRegex}
+                        onChange={(e) => setIsRegex(e.target.checked)}
+                        className="w-4 h-4 ml-4 text-purple-600 bg-gray-700 border-gray-600 rounded focus:ring-purple-500"
+                    />
+                    <label htmlFor="is-regex" className="text-purple-300">
+                        Regular expression ($1 in the replacement refers to a group)
+                    </label>
                 </div>
 
                 <div className="flex flex-wrap gap-3 items-center">
@@ -402,6 +418,11 @@ const TextRulesManager: React.FC = () => {
                                                     Case Sensitive
                                                 </span>
                                             )}
+                                            {rule.isRegex && (
+                                                <span className="px-2 py-1 rounded text-xs bg-gray-600 text-gray-300">
+                                                    Regex
+                                                </span>
+                                            )}
                                         </div>
                                         <div className="text-lg font-semibold text-white mb-1">
                                             "{rule.originalText}"
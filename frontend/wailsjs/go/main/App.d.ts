@@ -2,34 +2,202 @@
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
 
+export function AddGlossaryEntry(arg1:string,arg2:main.GlossaryEntry):Promise<main.GlossaryEntry>;
+
+export function ApplyPreset(arg1:string,arg2:string):Promise<void>;
+
+export function ApplyTextRules(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function AttachVideo(arg1:string,arg2:string):Promise<void>;
+
+export function BackupLibrary(arg1:string):Promise<void>;
+
+export function CancelAll():Promise<void>;
+
+export function CancelExport(arg1:string):Promise<void>;
+
+export function CancelProject(arg1:string):Promise<void>;
+
+export function CheckProjectPermissions(arg1:string):Promise<Array<main.PermissionIssue>>;
+
+export function CheckSourceChanged(arg1:string):Promise<Array<main.SourceChange>>;
+
+export function CheckSyncDrift(arg1:string):Promise<main.DriftReport>;
+
+export function CheckToolCompatibility():Promise<Array<main.CompatResult>>;
+
+export function ConfirmSourceLanguage(arg1:string,arg2:string):Promise<void>;
+
+export function CopyLinkedFile(arg1:string,arg2:string):Promise<void>;
+
 export function CopyLinkedFilesToProject(arg1:string):Promise<void>;
 
+export function CopySettingsFrom(arg1:string,arg2:string,arg3:Array<string>):Promise<void>;
+
 export function CreateProject(arg1:string,arg2:string,arg3:string,arg4:string):Promise<main.ProjectConfig>;
 
+export function CreateProjectInCategory(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<main.ProjectConfig>;
+
+export function DeleteGlossaryEntry(arg1:string,arg2:string):Promise<void>;
+
 export function DeleteProject(arg1:string):Promise<void>;
 
+export function DetectAvailableDevices():Promise<Array<string>>;
+
+export function DetectSourceLanguage(arg1:string):Promise<main.LanguageDetection>;
+
+export function DiscardTranscriptDraft(arg1:string):Promise<void>;
+
+export function ExportSegmentAudio(arg1:string,arg2:string):Promise<void>;
+
+export function ExportSubtitles(arg1:string,arg2:string,arg3:main.SubtitleOptions):Promise<void>;
+
+export function ExtractFrame(arg1:string,arg2:number):Promise<Array<number>>;
+
+export function FindDuplicateProjects():Promise<Array<any>>;
+
+export function FixProjectPermissions(arg1:string):Promise<void>;
+
+export function GenerateAudioPreview(arg1:string):Promise<string>;
+
+export function GenerateRunReport(arg1:string,arg2:string):Promise<string>;
+
 export function GetAppSettings():Promise<main.AppSettings>;
 
+export function GetAvailableLocales():Promise<Array<main.LocaleInfo>>;
+
+export function GetCustomVoicePacks():Promise<Array<main.CustomVoicePack>>;
+
 export function GetDefaultProjectsPath():Promise<string>;
 
+export function GetGlossary(arg1:string):Promise<Array<main.GlossaryEntry>>;
+
+export function GetMediaStreams(arg1:string):Promise<Array<main.StreamInfo>>;
+
+export function GetPipelineETA(arg1:string):Promise<main.ETAReport>;
+
+export function GetProjectByFolderName(arg1:string):Promise<main.ProjectConfig>;
+
+export function GetProjectConfigPath(arg1:string):Promise<string>;
+
 export function GetProjectFiles():Promise<Record<string, any>>;
 
+export function GetProjectHistory(arg1:string):Promise<Array<main.ConfigVersion>>;
+
+export function GetProjectManifest(arg1:string):Promise<main.ProjectManifest>;
+
+export function GetProjectSegmentsCount(arg1:string):Promise<number>;
+
+export function GetProjectsByState(arg1:string):Promise<Array<main.ProjectConfig>>;
+
+export function GetRecentOutputs(arg1:number):Promise<Array<main.OutputRef>>;
+
 export function GetRecentProjects():Promise<Array<main.ProjectConfig>>;
 
+export function GetRequiredModels(arg1:string):Promise<Array<main.ModelInfo>>;
+
+export function GetSegmentAudio(arg1:string,arg2:string):Promise<main.SegmentAudio>;
+
+export function GetSegmentGaps(arg1:string):Promise<Array<main.Gap>>;
+
+export function GetSettingsPresets():Promise<Record<string, main.ProjectSettings>>;
+
+export function GetSupportedLanguages():Promise<main.LanguageSupport>;
+
+export function GetTranscriptDraft(arg1:string):Promise<main.TranscriptDraft>;
+
+export function GetTranscriptionBackends():Promise<Array<main.BackendInfo>>;
+
+export function GetTranslationProviders():Promise<Array<main.TranslationProvider>>;
+
+export function GetVersionInfo():Promise<main.VersionInfo>;
+
+export function ListAvailableVoices():Promise<Array<main.VoiceInfo>>;
+
+export function ListIntermediateFiles(arg1:string):Promise<Array<main.IntermediateFile>>;
+
 export function LoadProject(arg1:string):Promise<main.ProjectConfig>;
 
+export function MergeProjects(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function MergeShortSegments(arg1:string,arg2:number,arg3:number):Promise<number>;
+
+export function MoveProjectToCategory(arg1:string,arg2:string):Promise<void>;
+
+export function OpenIntermediateFile(arg1:string,arg2:string):Promise<void>;
+
+export function PreflightProject(arg1:string):Promise<main.PreflightReport>;
+
+export function PrepareModels(arg1:string):Promise<void>;
+
+export function PreviewTranslation(arg1:string,arg2:string):Promise<string>;
+
+export function PurgeCaches():Promise<main.CacheReport>;
+
+export function QueueProjectSave(arg1:main.ProjectConfig):Promise<void>;
+
+export function RecoverProject(arg1:string):Promise<main.ProjectConfig>;
+
+export function RedoTranscriptEdit(arg1:string):Promise<void>;
+
+export function RelinkToExternal(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function RepairFileReferences(arg1:string):Promise<main.RepairReport>;
+
+export function RepairProject(arg1:string):Promise<main.ProjectConfig>;
+
+export function RerunPipelineStep(arg1:string,arg2:string):Promise<Record<string, any>>;
+
+export function ResetAfterSourceChange(arg1:string):Promise<void>;
+
+export function RestoreLibrary(arg1:string):Promise<void>;
+
+export function RestoreProjectVersion(arg1:string,arg2:string):Promise<void>;
+
+export function ResumePipeline(arg1:string):Promise<Record<string, any>>;
+
+export function RetranslateSegments(arg1:string,arg2:Array<string>):Promise<void>;
+
 export function RunDubbingPipeline(arg1:main.PipelineConfig):Promise<string>;
 
 export function RunFullPipeline(arg1:string):Promise<Record<string, any>>;
 
 export function RunPipelineStep(arg1:string,arg2:string):Promise<Record<string, any>>;
 
+export function RunSelfTest():Promise<main.SelfTestReport>;
+
 export function SaveAppSettings(arg1:main.AppSettings):Promise<void>;
 
 export function SaveProject(arg1:string,arg2:Record<string, any>):Promise<void>;
 
+export function SaveSettingsPreset(arg1:string,arg2:main.ProjectSettings):Promise<void>;
+
+export function SaveTranscriptDraft(arg1:string,arg2:Array<main.TranscriptSegment>):Promise<void>;
+
+export function ScanForOrphanedProjects():Promise<Array<main.OrphanInfo>>;
+
+export function SetDefaultProjectSettings(arg1:main.ProjectSettings):Promise<void>;
+
+export function SetProjectNotes(arg1:string,arg2:string):Promise<void>;
+
+export function SetProjectOutputDir(arg1:string,arg2:string):Promise<void>;
+
 export function ShowProjectInFolder(arg1:string):Promise<void>;
 
+export function SplitLongSegments(arg1:string,arg2:number):Promise<number>;
+
 export function SynthesizeVoice(arg1:main.VoiceRequest):Promise<Array<number>>;
 
+export function TestTextRule(arg1:main.TextRule,arg2:string):Promise<string>;
+
+export function UndoTranscriptEdit(arg1:string):Promise<void>;
+
+export function UpdateGlossaryEntry(arg1:string,arg2:main.GlossaryEntry):Promise<void>;
+
 export function UpdateProject(arg1:main.ProjectConfig):Promise<void>;
+
+export function ValidateSSML(arg1:string):Promise<void>;
+
+export function ValidateTranslationSettings(arg1:main.TranslationSettings):Promise<void>;
+
+export function ValidateVoiceLanguage(arg1:string,arg2:string):Promise<void>;
@@ -2,38 +2,318 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddGlossaryEntry(arg1, arg2) {
+  return window['go']['main']['App']['AddGlossaryEntry'](arg1, arg2);
+}
+
+export function ApplyPreset(arg1, arg2) {
+  return window['go']['main']['App']['ApplyPreset'](arg1, arg2);
+}
+
+export function ApplyTextRules(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ApplyTextRules'](arg1, arg2, arg3);
+}
+
+export function AttachVideo(arg1, arg2) {
+  return window['go']['main']['App']['AttachVideo'](arg1, arg2);
+}
+
+export function BackupLibrary(arg1) {
+  return window['go']['main']['App']['BackupLibrary'](arg1);
+}
+
+export function CancelAll() {
+  return window['go']['main']['App']['CancelAll']();
+}
+
+export function CancelExport(arg1) {
+  return window['go']['main']['App']['CancelExport'](arg1);
+}
+
+export function CancelProject(arg1) {
+  return window['go']['main']['App']['CancelProject'](arg1);
+}
+
+export function CheckProjectPermissions(arg1) {
+  return window['go']['main']['App']['CheckProjectPermissions'](arg1);
+}
+
+export function CheckSourceChanged(arg1) {
+  return window['go']['main']['App']['CheckSourceChanged'](arg1);
+}
+
+export function CheckSyncDrift(arg1) {
+  return window['go']['main']['App']['CheckSyncDrift'](arg1);
+}
+
+export function CheckToolCompatibility() {
+  return window['go']['main']['App']['CheckToolCompatibility']();
+}
+
+export function ConfirmSourceLanguage(arg1, arg2) {
+  return window['go']['main']['App']['ConfirmSourceLanguage'](arg1, arg2);
+}
+
+export function CopyLinkedFile(arg1, arg2) {
+  return window['go']['main']['App']['CopyLinkedFile'](arg1, arg2);
+}
+
 export function CopyLinkedFilesToProject(arg1) {
   return window['go']['main']['App']['CopyLinkedFilesToProject'](arg1);
 }
 
+export function CopySettingsFrom(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CopySettingsFrom'](arg1, arg2, arg3);
+}
+
 export function CreateProject(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['CreateProject'](arg1, arg2, arg3, arg4);
 }
 
+export function CreateProjectInCategory(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['CreateProjectInCategory'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function DeleteGlossaryEntry(arg1, arg2) {
+  return window['go']['main']['App']['DeleteGlossaryEntry'](arg1, arg2);
+}
+
 export function DeleteProject(arg1) {
   return window['go']['main']['App']['DeleteProject'](arg1);
 }
 
+export function DetectAvailableDevices() {
+  return window['go']['main']['App']['DetectAvailableDevices']();
+}
+
+export function DetectSourceLanguage(arg1) {
+  return window['go']['main']['App']['DetectSourceLanguage'](arg1);
+}
+
+export function DiscardTranscriptDraft(arg1) {
+  return window['go']['main']['App']['DiscardTranscriptDraft'](arg1);
+}
+
+export function ExportSegmentAudio(arg1, arg2) {
+  return window['go']['main']['App']['ExportSegmentAudio'](arg1, arg2);
+}
+
+export function ExportSubtitles(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportSubtitles'](arg1, arg2, arg3);
+}
+
+export function ExtractFrame(arg1, arg2) {
+  return window['go']['main']['App']['ExtractFrame'](arg1, arg2);
+}
+
+export function FindDuplicateProjects() {
+  return window['go']['main']['App']['FindDuplicateProjects']();
+}
+
+export function FixProjectPermissions(arg1) {
+  return window['go']['main']['App']['FixProjectPermissions'](arg1);
+}
+
+export function GenerateAudioPreview(arg1) {
+  return window['go']['main']['App']['GenerateAudioPreview'](arg1);
+}
+
+export function GenerateRunReport(arg1, arg2) {
+  return window['go']['main']['App']['GenerateRunReport'](arg1, arg2);
+}
+
 export function GetAppSettings() {
   return window['go']['main']['App']['GetAppSettings']();
 }
 
+export function GetAvailableLocales() {
+  return window['go']['main']['App']['GetAvailableLocales']();
+}
+
+export function GetCustomVoicePacks() {
+  return window['go']['main']['App']['GetCustomVoicePacks']();
+}
+
 export function GetDefaultProjectsPath() {
   return window['go']['main']['App']['GetDefaultProjectsPath']();
 }
 
+export function GetGlossary(arg1) {
+  return window['go']['main']['App']['GetGlossary'](arg1);
+}
+
+export function GetMediaStreams(arg1) {
+  return window['go']['main']['App']['GetMediaStreams'](arg1);
+}
+
+export function GetPipelineETA(arg1) {
+  return window['go']['main']['App']['GetPipelineETA'](arg1);
+}
+
+export function GetProjectByFolderName(arg1) {
+  return window['go']['main']['App']['GetProjectByFolderName'](arg1);
+}
+
+export function GetProjectConfigPath(arg1) {
+  return window['go']['main']['App']['GetProjectConfigPath'](arg1);
+}
+
 export function GetProjectFiles() {
   return window['go']['main']['App']['GetProjectFiles']();
 }
 
+export function GetProjectHistory(arg1) {
+  return window['go']['main']['App']['GetProjectHistory'](arg1);
+}
+
+export function GetProjectManifest(arg1) {
+  return window['go']['main']['App']['GetProjectManifest'](arg1);
+}
+
+export function GetProjectSegmentsCount(arg1) {
+  return window['go']['main']['App']['GetProjectSegmentsCount'](arg1);
+}
+
+export function GetProjectsByState(arg1) {
+  return window['go']['main']['App']['GetProjectsByState'](arg1);
+}
+
+export function GetRecentOutputs(arg1) {
+  return window['go']['main']['App']['GetRecentOutputs'](arg1);
+}
+
 export function GetRecentProjects() {
   return window['go']['main']['App']['GetRecentProjects']();
 }
 
+export function GetRequiredModels(arg1) {
+  return window['go']['main']['App']['GetRequiredModels'](arg1);
+}
+
+export function GetSegmentAudio(arg1, arg2) {
+  return window['go']['main']['App']['GetSegmentAudio'](arg1, arg2);
+}
+
+export function GetSegmentGaps(arg1) {
+  return window['go']['main']['App']['GetSegmentGaps'](arg1);
+}
+
+export function GetSettingsPresets() {
+  return window['go']['main']['App']['GetSettingsPresets']();
+}
+
+export function GetSupportedLanguages() {
+  return window['go']['main']['App']['GetSupportedLanguages']();
+}
+
+export function GetTranscriptDraft(arg1) {
+  return window['go']['main']['App']['GetTranscriptDraft'](arg1);
+}
+
+export function GetTranscriptionBackends() {
+  return window['go']['main']['App']['GetTranscriptionBackends']();
+}
+
+export function GetTranslationProviders() {
+  return window['go']['main']['App']['GetTranslationProviders']();
+}
+
+export function GetVersionInfo() {
+  return window['go']['main']['App']['GetVersionInfo']();
+}
+
+export function ListAvailableVoices() {
+  return window['go']['main']['App']['ListAvailableVoices']();
+}
+
+export function ListIntermediateFiles(arg1) {
+  return window['go']['main']['App']['ListIntermediateFiles'](arg1);
+}
+
 export function LoadProject(arg1) {
   return window['go']['main']['App']['LoadProject'](arg1);
 }
 
+export function MergeProjects(arg1, arg2) {
+  return window['go']['main']['App']['MergeProjects'](arg1, arg2);
+}
+
+export function MergeShortSegments(arg1, arg2, arg3) {
+  return window['go']['main']['App']['MergeShortSegments'](arg1, arg2, arg3);
+}
+
+export function MoveProjectToCategory(arg1, arg2) {
+  return window['go']['main']['App']['MoveProjectToCategory'](arg1, arg2);
+}
+
+export function OpenIntermediateFile(arg1, arg2) {
+  return window['go']['main']['App']['OpenIntermediateFile'](arg1, arg2);
+}
+
+export function PreflightProject(arg1) {
+  return window['go']['main']['App']['PreflightProject'](arg1);
+}
+
+export function PrepareModels(arg1) {
+  return window['go']['main']['App']['PrepareModels'](arg1);
+}
+
+export function PreviewTranslation(arg1, arg2) {
+  return window['go']['main']['App']['PreviewTranslation'](arg1, arg2);
+}
+
+export function PurgeCaches() {
+  return window['go']['main']['App']['PurgeCaches']();
+}
+
+export function QueueProjectSave(arg1) {
+  return window['go']['main']['App']['QueueProjectSave'](arg1);
+}
+
+export function RecoverProject(arg1) {
+  return window['go']['main']['App']['RecoverProject'](arg1);
+}
+
+export function RedoTranscriptEdit(arg1) {
+  return window['go']['main']['App']['RedoTranscriptEdit'](arg1);
+}
+
+export function RelinkToExternal(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RelinkToExternal'](arg1, arg2, arg3);
+}
+
+export function RepairFileReferences(arg1) {
+  return window['go']['main']['App']['RepairFileReferences'](arg1);
+}
+
+export function RepairProject(arg1) {
+  return window['go']['main']['App']['RepairProject'](arg1);
+}
+
+export function RerunPipelineStep(arg1, arg2) {
+  return window['go']['main']['App']['RerunPipelineStep'](arg1, arg2);
+}
+
+export function ResetAfterSourceChange(arg1) {
+  return window['go']['main']['App']['ResetAfterSourceChange'](arg1);
+}
+
+export function RestoreLibrary(arg1) {
+  return window['go']['main']['App']['RestoreLibrary'](arg1);
+}
+
+export function RestoreProjectVersion(arg1, arg2) {
+  return window['go']['main']['App']['RestoreProjectVersion'](arg1, arg2);
+}
+
+export function ResumePipeline(arg1) {
+  return window['go']['main']['App']['ResumePipeline'](arg1);
+}
+
+export function RetranslateSegments(arg1, arg2) {
+  return window['go']['main']['App']['RetranslateSegments'](arg1, arg2);
+}
+
 export function RunDubbingPipeline(arg1) {
   return window['go']['main']['App']['RunDubbingPipeline'](arg1);
 }
@@ -46,6 +326,10 @@ export function RunPipelineStep(arg1, arg2) {
   return window['go']['main']['App']['RunPipelineStep'](arg1, arg2);
 }
 
+export function RunSelfTest() {
+  return window['go']['main']['App']['RunSelfTest']();
+}
+
 export function SaveAppSettings(arg1) {
   return window['go']['main']['App']['SaveAppSettings'](arg1);
 }
@@ -54,14 +338,66 @@ export function SaveProject(arg1, arg2) {
   return window['go']['main']['App']['SaveProject'](arg1, arg2);
 }
 
+export function SaveSettingsPreset(arg1, arg2) {
+  return window['go']['main']['App']['SaveSettingsPreset'](arg1, arg2);
+}
+
+export function SaveTranscriptDraft(arg1, arg2) {
+  return window['go']['main']['App']['SaveTranscriptDraft'](arg1, arg2);
+}
+
+export function ScanForOrphanedProjects() {
+  return window['go']['main']['App']['ScanForOrphanedProjects']();
+}
+
+export function SetDefaultProjectSettings(arg1) {
+  return window['go']['main']['App']['SetDefaultProjectSettings'](arg1);
+}
+
+export function SetProjectNotes(arg1, arg2) {
+  return window['go']['main']['App']['SetProjectNotes'](arg1, arg2);
+}
+
+export function SetProjectOutputDir(arg1, arg2) {
+  return window['go']['main']['App']['SetProjectOutputDir'](arg1, arg2);
+}
+
 export function ShowProjectInFolder(arg1) {
   return window['go']['main']['App']['ShowProjectInFolder'](arg1);
 }
 
+export function SplitLongSegments(arg1, arg2) {
+  return window['go']['main']['App']['SplitLongSegments'](arg1, arg2);
+}
+
 export function SynthesizeVoice(arg1) {
   return window['go']['main']['App']['SynthesizeVoice'](arg1);
 }
 
+export function TestTextRule(arg1, arg2) {
+  return window['go']['main']['App']['TestTextRule'](arg1, arg2);
+}
+
+export function UndoTranscriptEdit(arg1) {
+  return window['go']['main']['App']['UndoTranscriptEdit'](arg1);
+}
+
+export function UpdateGlossaryEntry(arg1, arg2) {
+  return window['go']['main']['App']['UpdateGlossaryEntry'](arg1, arg2);
+}
+
 export function UpdateProject(arg1) {
   return window['go']['main']['App']['UpdateProject'](arg1);
 }
+
+export function ValidateSSML(arg1) {
+  return window['go']['main']['App']['ValidateSSML'](arg1);
+}
+
+export function ValidateTranslationSettings(arg1) {
+  return window['go']['main']['App']['ValidateTranslationSettings'](arg1);
+}
+
+export function ValidateVoiceLanguage(arg1, arg2) {
+  return window['go']['main']['App']['ValidateVoiceLanguage'](arg1, arg2);
+}
@@ -16,46 +16,18 @@ export namespace main {
 	        this.models = source["models"];
 	    }
 	}
-	export class AppSettings {
-	    defaultProjectsPath: string;
-	    autoCleanup: string;
-	    showOnboarding: boolean;
-	    recentProjects: string[];
-	    exportLocation: string;
-	    customExportPath?: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new AppSettings(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.defaultProjectsPath = source["defaultProjectsPath"];
-	        this.autoCleanup = source["autoCleanup"];
-	        this.showOnboarding = source["showOnboarding"];
-	        this.recentProjects = source["recentProjects"];
-	        this.exportLocation = source["exportLocation"];
-	        this.customExportPath = source["customExportPath"];
-	    }
-	}
-	export class AudioSettings {
-	    preventOverlaps: boolean;
-	    minGap: number;
-	    globalCrossfade: boolean;
-	    crossfadeDuration: number;
-	    effectsPreset: string;
+	export class OutputSettings {
+	    filenameTemplate: string;
+	    format: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new AudioSettings(source);
+	        return new OutputSettings(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.preventOverlaps = source["preventOverlaps"];
-	        this.minGap = source["minGap"];
-	        this.globalCrossfade = source["globalCrossfade"];
-	        this.crossfadeDuration = source["crossfadeDuration"];
-	        this.effectsPreset = source["effectsPreset"];
+	        this.filenameTemplate = source["filenameTemplate"];
+	        this.format = source["format"];
 	    }
 	}
 	export class CleanupSettings {
@@ -72,149 +44,59 @@ export namespace main {
 	        this.keepIntermediateFiles = source["keepIntermediateFiles"];
 	    }
 	}
-	export class CompletedSteps {
-	    download: boolean;
-	    transcribe: boolean;
-	    translate: boolean;
-	    synthesize: boolean;
-	    combine: boolean;
-	
-	    static createFrom(source: any = {}) {
-	        return new CompletedSteps(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.download = source["download"];
-	        this.transcribe = source["transcribe"];
-	        this.translate = source["translate"];
-	        this.synthesize = source["synthesize"];
-	        this.combine = source["combine"];
-	    }
-	}
-	export class FileReference {
-	    path: string;
-	    isLinked: boolean;
-	    originalPath?: string;
-	    size?: number;
-	    lastModified?: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new FileReference(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.isLinked = source["isLinked"];
-	        this.originalPath = source["originalPath"];
-	        this.size = source["size"];
-	        this.lastModified = source["lastModified"];
-	    }
-	}
-	export class FileReferences {
-	    videoFile?: FileReference;
-	    audioFile?: FileReference;
-	    segmentsFile?: string;
-	    finalAudio?: string;
-	    finalVideo?: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new FileReferences(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.videoFile = this.convertValues(source["videoFile"], FileReference);
-	        this.audioFile = this.convertValues(source["audioFile"], FileReference);
-	        this.segmentsFile = source["segmentsFile"];
-	        this.finalAudio = source["finalAudio"];
-	        this.finalVideo = source["finalVideo"];
-	    }
-	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
-	}
-	export class PipelineConfig {
-	    videoUrl: string;
-	    targetLang: string;
-	    outputDir: string;
-	    audioSettings: Record<string, any>;
-	    textRules: any[];
-	    segmentRules: any[];
+	export class SynthesisSettings {
+	    voice?: string;
+	    seed?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new PipelineConfig(source);
+	        return new SynthesisSettings(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.videoUrl = source["videoUrl"];
-	        this.targetLang = source["targetLang"];
-	        this.outputDir = source["outputDir"];
-	        this.audioSettings = source["audioSettings"];
-	        this.textRules = source["textRules"];
-	        this.segmentRules = source["segmentRules"];
+	        this.voice = source["voice"];
+	        this.seed = source["seed"];
 	    }
 	}
-	export class SegmentRule {
-	    id: string;
-	    type: string;
-	    conditions: Record<string, any>;
-	    actions: Record<string, any>;
-	    enabled: boolean;
-	    createdAt: string;
+	export class AudioSettings {
+	    preventOverlaps: boolean;
+	    minGap: number;
+	    globalCrossfade: boolean;
+	    crossfadeDuration: number;
+	    effectsPreset: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new SegmentRule(source);
+	        return new AudioSettings(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.type = source["type"];
-	        this.conditions = source["conditions"];
-	        this.actions = source["actions"];
-	        this.enabled = source["enabled"];
-	        this.createdAt = source["createdAt"];
+	        this.preventOverlaps = source["preventOverlaps"];
+	        this.minGap = source["minGap"];
+	        this.globalCrossfade = source["globalCrossfade"];
+	        this.crossfadeDuration = source["crossfadeDuration"];
+	        this.effectsPreset = source["effectsPreset"];
 	    }
 	}
-	export class TextRule {
+	export class GlossaryEntry {
 	    id: string;
-	    originalText: string;
-	    replacementText: string;
-	    language: string;
-	    priority: string;
+	    sourceTerm: string;
+	    targetTerm: string;
 	    caseSensitive: boolean;
+	    notes?: string;
 	    createdAt: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TextRule(source);
+	        return new GlossaryEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.originalText = source["originalText"];
-	        this.replacementText = source["replacementText"];
-	        this.language = source["language"];
-	        this.priority = source["priority"];
+	        this.sourceTerm = source["sourceTerm"];
+	        this.targetTerm = source["targetTerm"];
 	        this.caseSensitive = source["caseSensitive"];
+	        this.notes = source["notes"];
 	        this.createdAt = source["createdAt"];
 	    }
 	}
@@ -223,6 +105,7 @@ export namespace main {
 	    simpleModel: string;
 	    cloudProvider?: string;
 	    advancedSettings?: AdvancedTranslationSettings;
+	    glossary?: GlossaryEntry[];
 	
 	    static createFrom(source: any = {}) {
 	        return new TranslationSettings(source);
@@ -234,6 +117,7 @@ export namespace main {
 	        this.simpleModel = source["simpleModel"];
 	        this.cloudProvider = source["cloudProvider"];
 	        this.advancedSettings = this.convertValues(source["advancedSettings"], AdvancedTranslationSettings);
+	        this.glossary = this.convertValues(source["glossary"], GlossaryEntry);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -259,6 +143,9 @@ export namespace main {
 	    enableDiarization: boolean;
 	    language: string;
 	    model?: string;
+	    computeType?: string;
+	    beamSize?: number;
+	    sourceAudioStream?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new TranscriptionSettings(source);
@@ -270,13 +157,18 @@ export namespace main {
 	        this.enableDiarization = source["enableDiarization"];
 	        this.language = source["language"];
 	        this.model = source["model"];
+	        this.computeType = source["computeType"];
+	        this.beamSize = source["beamSize"];
+	        this.sourceAudioStream = source["sourceAudioStream"];
 	    }
 	}
 	export class ProjectSettings {
 	    transcription: TranscriptionSettings;
 	    translation: TranslationSettings;
 	    audio: AudioSettings;
+	    synthesis: SynthesisSettings;
 	    cleanup: CleanupSettings;
+	    output: OutputSettings;
 	
 	    static createFrom(source: any = {}) {
 	        return new ProjectSettings(source);
@@ -287,7 +179,9 @@ export namespace main {
 	        this.transcription = this.convertValues(source["transcription"], TranscriptionSettings);
 	        this.translation = this.convertValues(source["translation"], TranslationSettings);
 	        this.audio = this.convertValues(source["audio"], AudioSettings);
+	        this.synthesis = this.convertValues(source["synthesis"], SynthesisSettings);
 	        this.cleanup = this.convertValues(source["cleanup"], CleanupSettings);
+	        this.output = this.convertValues(source["output"], OutputSettings);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -308,44 +202,139 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class ProjectConfig {
+	export class AppSettings {
+	    defaultProjectsPath: string;
+	    autoCleanup: string;
+	    showOnboarding: boolean;
+	    recentProjects: string[];
+	    exportLocation: string;
+	    customExportPath?: string;
+	    historyDepth?: number;
+	    keepTempFiles?: boolean;
+	    completionWebhookUrl?: string;
+	    apiServerEnabled?: boolean;
+	    apiServerPort?: number;
+	    apiServerToken?: string;
+	    tempDir?: string;
+	    downloadRateLimit?: string;
+	    device?: string;
+	    dedupeInputs?: boolean;
+	    maxConcurrentJobs?: number;
+	    maxConcurrentGpuJobs?: number;
+	    logLevel?: string;
+	    defaultProjectSettings?: ProjectSettings;
+	    customVoicesPath?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.defaultProjectsPath = source["defaultProjectsPath"];
+	        this.autoCleanup = source["autoCleanup"];
+	        this.showOnboarding = source["showOnboarding"];
+	        this.recentProjects = source["recentProjects"];
+	        this.exportLocation = source["exportLocation"];
+	        this.customExportPath = source["customExportPath"];
+	        this.historyDepth = source["historyDepth"];
+	        this.keepTempFiles = source["keepTempFiles"];
+	        this.completionWebhookUrl = source["completionWebhookUrl"];
+	        this.apiServerEnabled = source["apiServerEnabled"];
+	        this.apiServerPort = source["apiServerPort"];
+	        this.apiServerToken = source["apiServerToken"];
+	        this.tempDir = source["tempDir"];
+	        this.downloadRateLimit = source["downloadRateLimit"];
+	        this.device = source["device"];
+	        this.dedupeInputs = source["dedupeInputs"];
+	        this.maxConcurrentJobs = source["maxConcurrentJobs"];
+	        this.maxConcurrentGpuJobs = source["maxConcurrentGpuJobs"];
+	        this.logLevel = source["logLevel"];
+	        this.defaultProjectSettings = this.convertValues(source["defaultProjectSettings"], ProjectSettings);
+	        this.customVoicesPath = source["customVoicesPath"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class BackendInfo {
 	    id: string;
 	    name: string;
-	    created: string;
-	    lastModified: string;
-	    version: number;
-	    sourceType: string;
-	    sourceUrl?: string;
-	    videoId?: string;
-	    originalFilename?: string;
-	    targetLanguage: string;
-	    completedSteps: CompletedSteps;
-	    fileReferences: FileReferences;
-	    settings: ProjectSettings;
-	    textRules: TextRule[];
-	    segmentRules: SegmentRule[];
+	    kind: string;
+	    installed: boolean;
+	    apiKeyEnv?: string;
+	    apiKeyConfigured?: boolean;
+	    available: boolean;
+	    message?: string;
+	    models: string[];
+	    defaultModel: string;
+	    supportsDiarization: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new ProjectConfig(source);
+	        return new BackendInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
 	        this.name = source["name"];
-	        this.created = source["created"];
-	        this.lastModified = source["lastModified"];
-	        this.version = source["version"];
-	        this.sourceType = source["sourceType"];
-	        this.sourceUrl = source["sourceUrl"];
-	        this.videoId = source["videoId"];
-	        this.originalFilename = source["originalFilename"];
-	        this.targetLanguage = source["targetLanguage"];
-	        this.completedSteps = this.convertValues(source["completedSteps"], CompletedSteps);
-	        this.fileReferences = this.convertValues(source["fileReferences"], FileReferences);
-	        this.settings = this.convertValues(source["settings"], ProjectSettings);
-	        this.textRules = this.convertValues(source["textRules"], TextRule);
-	        this.segmentRules = this.convertValues(source["segmentRules"], SegmentRule);
+	        this.kind = source["kind"];
+	        this.installed = source["installed"];
+	        this.apiKeyEnv = source["apiKeyEnv"];
+	        this.apiKeyConfigured = source["apiKeyConfigured"];
+	        this.available = source["available"];
+	        this.message = source["message"];
+	        this.models = source["models"];
+	        this.defaultModel = source["defaultModel"];
+	        this.supportsDiarization = source["supportsDiarization"];
+	    }
+	}
+	export class CacheCategory {
+	    name: string;
+	    path: string;
+	    bytesFreed: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheCategory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.bytesFreed = source["bytesFreed"];
+	        this.error = source["error"];
+	    }
+	}
+	export class CacheReport {
+	    categories: CacheCategory[];
+	    totalBytesFreed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.categories = this.convertValues(source["categories"], CacheCategory);
+	        this.totalBytesFreed = source["totalBytesFreed"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -367,10 +356,1086 @@ export namespace main {
 		}
 	}
 	
+	export class CompatResult {
+	    tool: string;
+	    status: string;
+	    detected?: string;
+	    required: string;
+	    message?: string;
 	
+	    static createFrom(source: any = {}) {
+	        return new CompatResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tool = source["tool"];
+	        this.status = source["status"];
+	        this.detected = source["detected"];
+	        this.required = source["required"];
+	        this.message = source["message"];
+	    }
+	}
+	export class CompletedSteps {
+	    download: boolean;
+	    transcribe: boolean;
+	    translate: boolean;
+	    synthesize: boolean;
+	    combine: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CompletedSteps(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.download = source["download"];
+	        this.transcribe = source["transcribe"];
+	        this.translate = source["translate"];
+	        this.synthesize = source["synthesize"];
+	        this.combine = source["combine"];
+	    }
+	}
+	export class ConfigVersion {
+	    timestamp: string;
+	    savedAt: string;
+	    size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConfigVersion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.timestamp = source["timestamp"];
+	        this.savedAt = source["savedAt"];
+	        this.size = source["size"];
+	    }
+	}
+	export class CustomVoicePack {
+	    id: string;
+	    path: string;
+	    language?: string;
+	    gender?: string;
+	    valid: boolean;
+	    problem?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CustomVoicePack(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.path = source["path"];
+	        this.language = source["language"];
+	        this.gender = source["gender"];
+	        this.valid = source["valid"];
+	        this.problem = source["problem"];
+	    }
+	}
+	export class RegionDrift {
+	    start: number;
+	    end: number;
+	    segments: number;
+	    meanDriftSeconds: number;
+	    maxDriftSeconds: number;
+	    exceedsTolerance: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RegionDrift(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.segments = source["segments"];
+	        this.meanDriftSeconds = source["meanDriftSeconds"];
+	        this.maxDriftSeconds = source["maxDriftSeconds"];
+	        this.exceedsTolerance = source["exceedsTolerance"];
+	    }
+	}
+	export class DriftReport {
+	    sourceDuration: number;
+	    outputDuration: number;
+	    driftSeconds: number;
+	    tolerance: number;
+	    exceedsTolerance: boolean;
+	    regions: RegionDrift[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DriftReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sourceDuration = source["sourceDuration"];
+	        this.outputDuration = source["outputDuration"];
+	        this.driftSeconds = source["driftSeconds"];
+	        this.tolerance = source["tolerance"];
+	        this.exceedsTolerance = source["exceedsTolerance"];
+	        this.regions = this.convertValues(source["regions"], RegionDrift);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ETAReport {
+	    projectId: string;
+	    step: string;
+	    stepPercent: number;
+	    stepRemainingSeconds: number;
+	    remainingSeconds: number;
+	    remainingSteps: string[];
+	    basis: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ETAReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.projectId = source["projectId"];
+	        this.step = source["step"];
+	        this.stepPercent = source["stepPercent"];
+	        this.stepRemainingSeconds = source["stepRemainingSeconds"];
+	        this.remainingSeconds = source["remainingSeconds"];
+	        this.remainingSteps = source["remainingSteps"];
+	        this.basis = source["basis"];
+	    }
+	}
+	export class FileReference {
+	    path: string;
+	    isLinked: boolean;
+	    originalPath?: string;
+	    size?: number;
+	    lastModified?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileReference(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.isLinked = source["isLinked"];
+	        this.originalPath = source["originalPath"];
+	        this.size = source["size"];
+	        this.lastModified = source["lastModified"];
+	    }
+	}
+	export class FileReferences {
+	    videoFile?: FileReference;
+	    audioFile?: FileReference;
+	    segmentsFile?: string;
+	    finalAudio?: string;
+	    finalVideo?: string;
+	    trimmedFile?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileReferences(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.videoFile = this.convertValues(source["videoFile"], FileReference);
+	        this.audioFile = this.convertValues(source["audioFile"], FileReference);
+	        this.segmentsFile = source["segmentsFile"];
+	        this.finalAudio = source["finalAudio"];
+	        this.finalVideo = source["finalVideo"];
+	        this.trimmedFile = source["trimmedFile"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Gap {
+	    start: number;
+	    end: number;
+	    duration: number;
+	    after: number;
+	    before: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Gap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.duration = source["duration"];
+	        this.after = source["after"];
+	        this.before = source["before"];
+	    }
+	}
+	
+	export class IntermediateFile {
+	    relPath: string;
+	    role: string;
+	    size: number;
+	    modTime: string;
+	    segmentIndex?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new IntermediateFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.relPath = source["relPath"];
+	        this.role = source["role"];
+	        this.size = source["size"];
+	        this.modTime = source["modTime"];
+	        this.segmentIndex = source["segmentIndex"];
+	    }
+	}
+	export class LanguageDetection {
+	    language: string;
+	    confidence: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LanguageDetection(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.language = source["language"];
+	        this.confidence = source["confidence"];
+	    }
+	}
+	export class LanguageSupport {
+	    transcribe: string[];
+	    translate: string[];
+	    synthesize: string[];
+	    endToEnd: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LanguageSupport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.transcribe = source["transcribe"];
+	        this.translate = source["translate"];
+	        this.synthesize = source["synthesize"];
+	        this.endToEnd = source["endToEnd"];
+	    }
+	}
+	export class LocaleInfo {
+	    code: string;
+	    name: string;
+	    dateExample: string;
+	    decimalSeparator: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LocaleInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.name = source["name"];
+	        this.dateExample = source["dateExample"];
+	        this.decimalSeparator = source["decimalSeparator"];
+	    }
+	}
+	export class ManifestFile {
+	    role: string;
+	    path: string;
+	    exists: boolean;
+	    size: number;
+	    sha256?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ManifestFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.role = source["role"];
+	        this.path = source["path"];
+	        this.exists = source["exists"];
+	        this.size = source["size"];
+	        this.sha256 = source["sha256"];
+	    }
+	}
+	export class ManifestSettingsSummary {
+	    transcriptionSource: string;
+	    diarization: boolean;
+	    translationMode: string;
+	    translationModel?: string;
+	    glossaryTerms: number;
+	    textRules: number;
+	    segmentRules: number;
+	    effectsPreset: string;
+	    outputFormat: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ManifestSettingsSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.transcriptionSource = source["transcriptionSource"];
+	        this.diarization = source["diarization"];
+	        this.translationMode = source["translationMode"];
+	        this.translationModel = source["translationModel"];
+	        this.glossaryTerms = source["glossaryTerms"];
+	        this.textRules = source["textRules"];
+	        this.segmentRules = source["segmentRules"];
+	        this.effectsPreset = source["effectsPreset"];
+	        this.outputFormat = source["outputFormat"];
+	    }
+	}
+	export class ManifestSource {
+	    type: string;
+	    url?: string;
+	    filename?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ManifestSource(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.url = source["url"];
+	        this.filename = source["filename"];
+	    }
+	}
+	export class ModelInfo {
+	    id: string;
+	    source: string;
+	    purpose: string;
+	    sizeBytes: number;
+	    cached: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModelInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.source = source["source"];
+	        this.purpose = source["purpose"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.cached = source["cached"];
+	    }
+	}
+	export class OrphanInfo {
+	    folderName: string;
+	    path: string;
+	    reason: string;
+	    inputFiles: string[];
+	    outputFiles: string[];
+	    lastModified: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OrphanInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.folderName = source["folderName"];
+	        this.path = source["path"];
+	        this.reason = source["reason"];
+	        this.inputFiles = source["inputFiles"];
+	        this.outputFiles = source["outputFiles"];
+	        this.lastModified = source["lastModified"];
+	    }
+	}
+	export class OutputRef {
+	    projectId: string;
+	    projectName: string;
+	    targetLanguage: string;
+	    kind: string;
+	    path: string;
+	    size: number;
+	    modifiedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OutputRef(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.projectId = source["projectId"];
+	        this.projectName = source["projectName"];
+	        this.targetLanguage = source["targetLanguage"];
+	        this.kind = source["kind"];
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.modifiedAt = source["modifiedAt"];
+	    }
+	}
+	
+	export class PermissionIssue {
+	    path: string;
+	    kind: string;
+	    message: string;
+	    fixable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PermissionIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.kind = source["kind"];
+	        this.message = source["message"];
+	        this.fixable = source["fixable"];
+	    }
+	}
+	export class PipelineConfig {
+	    videoUrl: string;
+	    targetLang: string;
+	    outputDir: string;
+	    audioSettings: Record<string, any>;
+	    textRules: any[];
+	    segmentRules: any[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PipelineConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.videoUrl = source["videoUrl"];
+	        this.targetLang = source["targetLang"];
+	        this.outputDir = source["outputDir"];
+	        this.audioSettings = source["audioSettings"];
+	        this.textRules = source["textRules"];
+	        this.segmentRules = source["segmentRules"];
+	    }
+	}
+	export class PreflightCheck {
+	    name: string;
+	    status: string;
+	    message?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PreflightCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.message = source["message"];
+	    }
+	}
+	export class PreflightReport {
+	    projectId: string;
+	    runnable: boolean;
+	    checks: PreflightCheck[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PreflightReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.projectId = source["projectId"];
+	        this.runnable = source["runnable"];
+	        this.checks = this.convertValues(source["checks"], PreflightCheck);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StepCacheEntry {
+	    inputHash: string;
+	    result: Record<string, any>;
+	    completedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StepCacheEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.inputHash = source["inputHash"];
+	        this.result = source["result"];
+	        this.completedAt = source["completedAt"];
+	    }
+	}
+	export class SegmentRule {
+	    id: string;
+	    type: string;
+	    conditions: Record<string, any>;
+	    actions: Record<string, any>;
+	    enabled: boolean;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SegmentRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.type = source["type"];
+	        this.conditions = source["conditions"];
+	        this.actions = source["actions"];
+	        this.enabled = source["enabled"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class TextRule {
+	    id: string;
+	    originalText: string;
+	    replacementText: string;
+	    language: string;
+	    priority: string;
+	    caseSensitive: boolean;
+	    isRegex?: boolean;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TextRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.originalText = source["originalText"];
+	        this.replacementText = source["replacementText"];
+	        this.language = source["language"];
+	        this.priority = source["priority"];
+	        this.caseSensitive = source["caseSensitive"];
+	        this.isRegex = source["isRegex"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class ProjectConfig {
+	    id: string;
+	    name: string;
+	    created: string;
+	    lastModified: string;
+	    version: number;
+	    sourceType: string;
+	    sourceUrl?: string;
+	    platform?: string;
+	    videoId?: string;
+	    originalFilename?: string;
+	    targetLanguage: string;
+	    outputDir?: string;
+	    pauseAfterStep?: string[];
+	    pausedAfter?: string;
+	    usesMarkup?: boolean;
+	    notes?: string;
+	    category?: string;
+	    locale?: string;
+	    downloadFormat?: string;
+	    trimStart?: number;
+	    trimEnd?: number;
+	    attachedVideo?: boolean;
+	    envOverrides?: Record<string, string>;
+	    completedSteps: CompletedSteps;
+	    fileReferences: FileReferences;
+	    settings: ProjectSettings;
+	    textRules: TextRule[];
+	    segmentRules: SegmentRule[];
+	    stepCache?: Record<string, StepCacheEntry>;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.created = source["created"];
+	        this.lastModified = source["lastModified"];
+	        this.version = source["version"];
+	        this.sourceType = source["sourceType"];
+	        this.sourceUrl = source["sourceUrl"];
+	        this.platform = source["platform"];
+	        this.videoId = source["videoId"];
+	        this.originalFilename = source["originalFilename"];
+	        this.targetLanguage = source["targetLanguage"];
+	        this.outputDir = source["outputDir"];
+	        this.pauseAfterStep = source["pauseAfterStep"];
+	        this.pausedAfter = source["pausedAfter"];
+	        this.usesMarkup = source["usesMarkup"];
+	        this.notes = source["notes"];
+	        this.category = source["category"];
+	        this.locale = source["locale"];
+	        this.downloadFormat = source["downloadFormat"];
+	        this.trimStart = source["trimStart"];
+	        this.trimEnd = source["trimEnd"];
+	        this.attachedVideo = source["attachedVideo"];
+	        this.envOverrides = source["envOverrides"];
+	        this.completedSteps = this.convertValues(source["completedSteps"], CompletedSteps);
+	        this.fileReferences = this.convertValues(source["fileReferences"], FileReferences);
+	        this.settings = this.convertValues(source["settings"], ProjectSettings);
+	        this.textRules = this.convertValues(source["textRules"], TextRule);
+	        this.segmentRules = this.convertValues(source["segmentRules"], SegmentRule);
+	        this.stepCache = this.convertValues(source["stepCache"], StepCacheEntry, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProjectManifest {
+	    manifestVersion: number;
+	    id: string;
+	    name: string;
+	    created: string;
+	    lastModified: string;
+	    source: ManifestSource;
+	    sourceLanguage: string;
+	    targetLanguage: string;
+	    completedSteps: string[];
+	    files: ManifestFile[];
+	    settings: ManifestSettingsSummary;
+	    notes: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectManifest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.manifestVersion = source["manifestVersion"];
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.created = source["created"];
+	        this.lastModified = source["lastModified"];
+	        this.source = this.convertValues(source["source"], ManifestSource);
+	        this.sourceLanguage = source["sourceLanguage"];
+	        this.targetLanguage = source["targetLanguage"];
+	        this.completedSteps = source["completedSteps"];
+	        this.files = this.convertValues(source["files"], ManifestFile);
+	        this.settings = this.convertValues(source["settings"], ManifestSettingsSummary);
+	        this.notes = source["notes"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class RepairedReference {
+	    role: string;
+	    oldPath: string;
+	    newPath?: string;
+	    reason?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepairedReference(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.role = source["role"];
+	        this.oldPath = source["oldPath"];
+	        this.newPath = source["newPath"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class RepairReport {
+	    fixed: RepairedReference[];
+	    broken: RepairedReference[];
+	    unchanged: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepairReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fixed = this.convertValues(source["fixed"], RepairedReference);
+	        this.broken = this.convertValues(source["broken"], RepairedReference);
+	        this.unchanged = source["unchanged"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SegmentAudio {
+	    data: number[];
+	    mimeType: string;
+	    path: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SegmentAudio(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = source["data"];
+	        this.mimeType = source["mimeType"];
+	        this.path = source["path"];
+	    }
+	}
+	
+	export class SelfTestCheck {
+	    name: string;
+	    passed: boolean;
+	    message?: string;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SelfTestCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.passed = source["passed"];
+	        this.message = source["message"];
+	        this.durationMs = source["durationMs"];
+	    }
+	}
+	export class SelfTestReport {
+	    passed: boolean;
+	    appVersion: string;
+	    platform: string;
+	    started: string;
+	    checks: SelfTestCheck[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SelfTestReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.passed = source["passed"];
+	        this.appVersion = source["appVersion"];
+	        this.platform = source["platform"];
+	        this.started = source["started"];
+	        this.checks = this.convertValues(source["checks"], SelfTestCheck);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SourceChange {
+	    role: string;
+	    path: string;
+	    missing: boolean;
+	    recordedSize: number;
+	    currentSize: number;
+	    recordedModified: string;
+	    currentModified: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SourceChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.role = source["role"];
+	        this.path = source["path"];
+	        this.missing = source["missing"];
+	        this.recordedSize = source["recordedSize"];
+	        this.currentSize = source["currentSize"];
+	        this.recordedModified = source["recordedModified"];
+	        this.currentModified = source["currentModified"];
+	    }
+	}
+	
+	export class StreamInfo {
+	    index: number;
+	    typeIndex: number;
+	    codecType: string;
+	    codecName: string;
+	    language?: string;
+	    title?: string;
+	    channels?: number;
+	    sampleRate?: number;
+	    width?: number;
+	    height?: number;
+	    duration?: number;
+	    default: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new StreamInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.typeIndex = source["typeIndex"];
+	        this.codecType = source["codecType"];
+	        this.codecName = source["codecName"];
+	        this.language = source["language"];
+	        this.title = source["title"];
+	        this.channels = source["channels"];
+	        this.sampleRate = source["sampleRate"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.duration = source["duration"];
+	        this.default = source["default"];
+	    }
+	}
+	export class SubtitleOptions {
+	    format: string;
+	    original: boolean;
+	    includeSpeakerLabels: boolean;
+	    position: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubtitleOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.original = source["original"];
+	        this.includeSpeakerLabels = source["includeSpeakerLabels"];
+	        this.position = source["position"];
+	    }
+	}
+	
+	
+	export class TranscriptSegment {
+	    start: number;
+	    end: number;
+	    original_text: string;
+	    translated_text: string;
+	    target_duration: number;
+	    words: any[];
+	    audio_file?: string;
+	    adjusted_speed: number;
+	    actual_start?: number;
+	    actual_end?: number;
+	    buffer_before: number;
+	    buffer_after: number;
+	    priority: number;
+	    speaker: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TranscriptSegment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.original_text = source["original_text"];
+	        this.translated_text = source["translated_text"];
+	        this.target_duration = source["target_duration"];
+	        this.words = source["words"];
+	        this.audio_file = source["audio_file"];
+	        this.adjusted_speed = source["adjusted_speed"];
+	        this.actual_start = source["actual_start"];
+	        this.actual_end = source["actual_end"];
+	        this.buffer_before = source["buffer_before"];
+	        this.buffer_after = source["buffer_after"];
+	        this.priority = source["priority"];
+	        this.speaker = source["speaker"];
+	    }
+	}
+	export class TranscriptDraft {
+	    segments: TranscriptSegment[];
+	    savedAt: string;
+	    newerThanSegments: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TranscriptDraft(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.segments = this.convertValues(source["segments"], TranscriptSegment);
+	        this.savedAt = source["savedAt"];
+	        this.newerThanSegments = source["newerThanSegments"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	
 	
+	export class TranslationProvider {
+	    id: string;
+	    name: string;
+	    languages: string[];
+	    apiKeyEnv: string;
+	    apiKeyConfigured: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TranslationProvider(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.languages = source["languages"];
+	        this.apiKeyEnv = source["apiKeyEnv"];
+	        this.apiKeyConfigured = source["apiKeyConfigured"];
+	    }
+	}
+	
+	export class VersionInfo {
+	    appVersion: string;
+	    commit?: string;
+	    goVersion: string;
+	    platform: string;
+	    scriptsHash: string;
+	    tools: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new VersionInfo(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.appVersion = source["appVersion"];
+	        this.commit = source["commit"];
+	        this.goVersion = source["goVersion"];
+	        this.platform = source["platform"];
+	        this.scriptsHash = source["scriptsHash"];
+	        this.tools = source["tools"];
+	    }
+	}
+	export class VoiceInfo {
+	    id: string;
+	    language: string;
+	    gender?: string;
+	    custom?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new VoiceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.language = source["language"];
+	        this.gender = source["gender"];
+	        this.custom = source["custom"];
+	    }
+	}
 	export class VoiceRequest {
 	    model: string;
 	    voice: string;
@@ -0,0 +1,129 @@
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "os"
+    "os/signal"
+    "path/filepath"
+    "strings"
+    "syscall"
+)
+
+// isHeadlessInvocation reports whether the binary was started with --headless
+func isHeadlessInvocation(args []string) bool {
+    for _, arg := range args {
+        if arg == "--headless" || arg == "-headless" {
+            return true
+        }
+    }
+    return false
+}
+
+// runHeadless creates a project and runs the full pipeline without the GUI, printing
+// progress to stdout. It returns the process exit code.
+func runHeadless(app *App, args []string) int {
+    flags := flag.NewFlagSet("voiceweave --headless", flag.ContinueOnError)
+    flags.Bool("headless", true, "run without the GUI")
+    source := flags.String("source", "", "video URL or path to a local video/audio file")
+    sourceType := flags.String("type", "", "source type: youtube (any video URL), video or audio (detected when omitted)")
+    lang := flags.String("lang", "es", "target language code")
+    name := flags.String("name", "", "project name (defaults to the video ID or file name)")
+    verbose := flags.Bool("verbose", false, "print pipeline log output as well as progress")
+    if err := flags.Parse(args); err != nil {
+        return 2
+    }
+    if *source == "" {
+        fmt.Fprintln(os.Stderr, "Error: --source is required")
+        flags.Usage()
+        return 2
+    }
+    if *sourceType == "" {
+        *sourceType = detectSourceType(*source)
+    }
+
+    app.eventSink = func(name string, data ...interface{}) {
+        printHeadlessEvent(name, data, *verbose)
+    }
+
+    app.applyTempDirSetting()
+    // A private copy of the scripts, so an open app's copy is left alone; OnShutdown removes it
+    tempDir, err := os.MkdirTemp("", "kokoro-studio-python-")
+    if err == nil {
+        err = extractPythonScriptsTo(tempDir)
+    }
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: failed to extract Python scripts: %v\n", err)
+        return 1
+    }
+    os.Setenv("KOKORO_PYTHON_DIR", tempDir)
+    defer app.OnShutdown(context.Background())
+
+    // Ctrl+C stops the subprocesses; RunFullPipeline then returns with an error
+    signals := make(chan os.Signal, 1)
+    signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+    defer signal.Stop(signals)
+    go func() {
+        if _, ok := <-signals; ok {
+            fmt.Println("Interrupted, stopping pipeline...")
+            app.stopAllProcesses()
+        }
+    }()
+
+    project, err := app.CreateProject(*sourceType, *source, *lang, *name)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: failed to create project: %v\n", err)
+        return 1
+    }
+    fmt.Printf("Created project %s (%s)\n", project.Name, project.ID)
+
+    results, err := app.RunFullPipeline(project.ID)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+        return 1
+    }
+    if paused, _ := results["paused"].(bool); paused {
+        fmt.Printf("Pipeline paused after %v; resume it from the app\n", results["pausedAfter"])
+        return 0
+    }
+
+    if manifest, err := app.GetProjectManifest(project.ID); err == nil {
+        for _, file := range manifest.Files {
+            if file.Role == "finalVideo" || file.Role == "finalAudio" {
+                fmt.Printf("Output: %s\n", file.Path)
+            }
+        }
+    }
+    fmt.Println("Pipeline completed successfully")
+    return 0
+}
+
+// detectSourceType treats existing files as video or audio by extension and anything else as a URL
+func detectSourceType(source string) string {
+    if _, err := os.Stat(source); err == nil {
+        if audioExtensions[strings.ToLower(filepath.Ext(source))] {
+            return "audio"
+        }
+        return "video"
+    }
+    return "youtube"
+}
+
+func printHeadlessEvent(name string, data []interface{}, verbose bool) {
+    if len(data) == 0 {
+        return
+    }
+    switch event := data[0].(type) {
+    case string:
+        if name == "app:warning" {
+            fmt.Fprintf(os.Stderr, "Warning: %s\n", event)
+        }
+    case PipelineProgress:
+        fmt.Printf("[%s] %3.0f%% %s\n", event.Step, event.Percent, event.Message)
+    case PipelineLogLine:
+        if verbose {
+            fmt.Printf("[%s] %s\n", event.Step, event.Line)
+        }
+    }
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// inputsCacheDir holds one copy of every input file copied into a project while
+// DedupeInputs is on, named by content hash. It lives in the projects folder so hard
+// links to it stay on the same filesystem.
+const inputsCacheDir = ".inputs-cache"
+
+// errReflinkUnsupported is returned by reflinkFile where copy-on-write clones aren't available
+var errReflinkUnsupported = errors.New("reflinks are not supported on this platform")
+
+// dedupeInputFile places srcPath's content at destPath by cloning or hard-linking a shared
+// copy in the inputs cache, adding it to the cache first if needed. It returns false
+// when DedupeInputs is off, leaving the caller to copy normally.
+func (a *App) dedupeInputFile(srcPath, destPath string) (bool, error) {
+    settings, err := a.GetAppSettings()
+    if err != nil || !settings.DedupeInputs {
+        return false, nil
+    }
+
+    hash, err := fileSHA256(srcPath)
+    if err != nil {
+        return false, fmt.Errorf("failed to hash input: %w", err)
+    }
+
+    cacheDir := filepath.Join(settings.DefaultProjectsPath, inputsCacheDir)
+    if err := os.MkdirAll(cacheDir, 0755); err != nil {
+        return false, fmt.Errorf("failed to create inputs cache: %w", err)
+    }
+    cached := filepath.Join(cacheDir, hash+strings.ToLower(filepath.Ext(srcPath)))
+
+    if !fileExists(cached) {
+        // The source is copied rather than linked: it belongs to the user and may change
+        tmpPath := cached + ".tmp"
+        if err := copyFileContents(srcPath, tmpPath); err != nil {
+            os.Remove(tmpPath)
+            return false, fmt.Errorf("failed to add input to cache: %w", err)
+        }
+        if err := os.Rename(tmpPath, cached); err != nil {
+            os.Remove(tmpPath)
+            return false, fmt.Errorf("failed to add input to cache: %w", err)
+        }
+    }
+
+    os.Remove(destPath)
+    // A clone is independent of the cache; a hard link shares it, which is fine since
+    // the pipeline never writes to its inputs
+    if err := reflinkFile(cached, destPath); err == nil {
+        return true, nil
+    }
+    if err := os.Link(cached, destPath); err == nil {
+        return true, nil
+    }
+    return false, nil
+}
+
+// copyFileContents copies src to a new file at dest
+func copyFileContents(src, dest string) error {
+    in, err := os.Open(src)
+    if err != nil {
+        return err
+    }
+    defer in.Close()
+
+    out, err := os.Create(dest)
+    if err != nil {
+        return err
+    }
+    if _, err := io.Copy(out, in); err != nil {
+        out.Close()
+        return err
+    }
+    return out.Close()
+}
+
+// pruneInputsCache removes cached inputs no project links to any more. Where link counts
+// can't be read nothing is removed.
+func pruneInputsCache(projectsDir string) CacheCategory {
+    dir := filepath.Join(projectsDir, inputsCacheDir)
+    category := CacheCategory{Name: "Unused shared inputs", Path: dir}
+
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        if !os.IsNotExist(err) {
+            category.Error = err.Error()
+        }
+        return category
+    }
+    for _, entry := range entries {
+        info, err := entry.Info()
+        if err != nil || !info.Mode().IsRegular() {
+            continue
+        }
+        if links, ok := fileLinkCount(info); !ok || links > 1 {
+            continue
+        }
+        if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+            category.Error = err.Error()
+            continue
+        }
+        category.BytesFreed += info.Size()
+    }
+    return category
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package main
+
+import (
+    "os"
+    "syscall"
+)
+
+// fileLinkCount returns how many hard links point at a file
+func fileLinkCount(info os.FileInfo) (uint64, bool) {
+    stat, ok := info.Sys().(*syscall.Stat_t)
+    if !ok {
+        return 0, false
+    }
+    return uint64(stat.Nlink), true
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// fileLinkCount isn't available from a FileInfo on Windows
+func fileLinkCount(info os.FileInfo) (uint64, bool) {
+    return 0, false
+}
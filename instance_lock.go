@@ -0,0 +1,87 @@
+package main
+
+import (
+    "errors"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+
+    "github.com/wailsapp/wails/v2/pkg/options"
+    wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// errInstanceRunning is returned when another copy of the app holds the instance lock
+var errInstanceRunning = errors.New("another instance of VoiceWeave Studio is already running")
+
+// singleInstanceID names the channel Wails uses to pass a second launch to the running instance
+const singleInstanceID = "b4e7d2c1-voiceweave-studio"
+
+// acquireInstanceLock creates a PID lock file in the config directory so two copies of the
+// app never extract to (and run from) the same temp Python directory. A lock left behind by
+// a process that no longer exists is taken over.
+func (a *App) acquireInstanceLock() error {
+    settingsPath, err := a.getSettingsPath()
+    if err != nil {
+        return err
+    }
+
+    lockPath := filepath.Join(filepath.Dir(settingsPath), "instance.lock")
+    if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+        return fmt.Errorf("failed to create settings directory: %w", err)
+    }
+
+    for attempt := 0; attempt < 2; attempt++ {
+        file, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+        if err == nil {
+            _, writeErr := file.WriteString(strconv.Itoa(os.Getpid()))
+            file.Close()
+            if writeErr != nil {
+                os.Remove(lockPath)
+                return fmt.Errorf("failed to write instance lock: %w", writeErr)
+            }
+            a.lockPath = lockPath
+            return nil
+        }
+        if !os.IsExist(err) {
+            return fmt.Errorf("failed to create instance lock: %w", err)
+        }
+
+        data, err := os.ReadFile(lockPath)
+        if err != nil {
+            return fmt.Errorf("failed to read instance lock: %w", err)
+        }
+        pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+        if err == nil && pid != os.Getpid() && isProcessAlive(pid) {
+            return errInstanceRunning
+        }
+
+        // Stale lock from a crashed instance
+        if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+            return fmt.Errorf("failed to remove stale instance lock: %w", err)
+        }
+    }
+
+    return errInstanceRunning
+}
+
+// releaseInstanceLock removes the lock file if this instance holds it
+func (a *App) releaseInstanceLock() {
+    if a.lockPath == "" {
+        return
+    }
+    if err := os.Remove(a.lockPath); err != nil && !os.IsNotExist(err) {
+        fmt.Printf("Failed to release instance lock: %v\n", err)
+    }
+    a.lockPath = ""
+}
+
+// onSecondInstanceLaunch brings the window forward when the app is launched again while running
+func (a *App) onSecondInstanceLaunch(data options.SecondInstanceData) {
+    if a.ctx == nil {
+        return
+    }
+    wailsruntime.WindowUnminimise(a.ctx)
+    wailsruntime.WindowShow(a.ctx)
+}
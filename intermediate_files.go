@@ -0,0 +1,188 @@
+package main
+
+import (
+    "fmt"
+    "io/fs"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "regexp"
+    "runtime"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// intermediateDirs are the project folders holding artifacts between pipeline steps
+var intermediateDirs = []string{"transcripts", "audio"}
+
+// IntermediateFile is one artifact a pipeline step left in the project
+type IntermediateFile struct {
+    RelPath      string `json:"relPath"`
+    Role         string `json:"role"`
+    Size         int64  `json:"size"`
+    ModTime      string `json:"modTime"`
+    SegmentIndex *int   `json:"segmentIndex,omitempty"`
+}
+
+var segmentChunkName = regexp.MustCompile(`^chunk_(\d+)\.\w+$`)
+
+// ListIntermediateFiles lists the files in the project's transcripts and audio folders,
+// with what each one is inferred from its name
+func (a *App) ListIntermediateFiles(projectID string) ([]IntermediateFile, error) {
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("project not found: %w", err)
+    }
+
+    files := []IntermediateFile{}
+    for _, dir := range intermediateDirs {
+        root := filepath.Join(projectDir, dir)
+        err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+            if err != nil {
+                if os.IsNotExist(err) && path == root {
+                    return filepath.SkipDir
+                }
+                return err
+            }
+            if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+                return nil
+            }
+            info, err := d.Info()
+            if err != nil {
+                return nil
+            }
+            rel, err := filepath.Rel(projectDir, path)
+            if err != nil {
+                return nil
+            }
+            file := IntermediateFile{
+                RelPath: filepath.ToSlash(rel),
+                Role:    intermediateFileRole(dir, d.Name()),
+                Size:    info.Size(),
+                ModTime: info.ModTime().Format(time.RFC3339),
+            }
+            if m := segmentChunkName.FindStringSubmatch(d.Name()); m != nil && dir == "audio" {
+                if index, err := strconv.Atoi(m[1]); err == nil {
+                    file.SegmentIndex = &index
+                }
+            }
+            files = append(files, file)
+            return nil
+        })
+        if err != nil {
+            return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+        }
+    }
+
+    sort.Slice(files, func(i, j int) bool { return files[i].RelPath < files[j].RelPath })
+    return files, nil
+}
+
+// intermediateFileRole infers what a file is from the folder and the names the pipeline uses
+func intermediateFileRole(dir, name string) string {
+    lower := strings.ToLower(name)
+    switch dir {
+    case "transcripts":
+        switch {
+        case strings.HasSuffix(lower, "_segments.json.draft"):
+            return "segmentsDraft"
+        case strings.HasSuffix(lower, "_segments.json"):
+            return "segments"
+        case strings.HasSuffix(lower, ".wav.stream"):
+            return "transcriptionAudioMarker"
+        case strings.HasSuffix(lower, ".wav"):
+            return "transcriptionAudio"
+        case strings.HasSuffix(lower, ".srt"), strings.HasSuffix(lower, ".vtt"):
+            return "subtitles"
+        case strings.HasSuffix(lower, ".json"):
+            return "transcript"
+        }
+    case "audio":
+        switch {
+        case segmentChunkName.MatchString(lower):
+            return "segmentAudio"
+        case lower == previewFileName:
+            return "preview"
+        case strings.Contains(lower, "_dubbed."):
+            return "dubbedTrack"
+        }
+    }
+    return "other"
+}
+
+// OpenIntermediateFile reveals one of the project's files in the system file explorer.
+// relPath must stay inside the project folder.
+func (a *App) OpenIntermediateFile(projectID, relPath string) error {
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+
+    path, err := safeProjectPath(projectDir, relPath)
+    if err != nil {
+        return err
+    }
+    if !fileExists(path) {
+        return fmt.Errorf("file not found: %s", relPath)
+    }
+    return revealInFileManager(path)
+}
+
+// safeProjectPath resolves relPath inside projectDir, rejecting absolute paths and any that
+// escape the project, including through symlinks
+func safeProjectPath(projectDir, relPath string) (string, error) {
+    if relPath == "" || filepath.IsAbs(relPath) || filepath.VolumeName(relPath) != "" {
+        return "", fmt.Errorf("invalid project path: %q", relPath)
+    }
+    path := filepath.Join(projectDir, filepath.FromSlash(relPath))
+    if _, ok := relativeInside(projectDir, path); !ok {
+        return "", fmt.Errorf("path is outside the project: %q", relPath)
+    }
+
+    realDir, err := filepath.EvalSymlinks(projectDir)
+    if err != nil {
+        return "", fmt.Errorf("project not found: %w", err)
+    }
+    if realPath, err := filepath.EvalSymlinks(path); err == nil {
+        if _, ok := relativeInside(realDir, realPath); !ok {
+            return "", fmt.Errorf("path is outside the project: %q", relPath)
+        }
+    }
+    return path, nil
+}
+
+// revealInFileManager opens the system file explorer at path: with the file selected where
+// the platform supports it, otherwise at the folder holding it
+func revealInFileManager(path string) error {
+    var cmd *exec.Cmd
+    switch runtime.GOOS {
+    case "windows":
+        if fileIsDir(path) {
+            cmd = exec.Command("explorer", path)
+        } else {
+            cmd = exec.Command("explorer", "/select,"+path)
+        }
+    case "darwin":
+        if fileIsDir(path) {
+            cmd = exec.Command("open", path)
+        } else {
+            cmd = exec.Command("open", "-R", path)
+        }
+    case "linux":
+        if !fileIsDir(path) {
+            path = filepath.Dir(path)
+        }
+        cmd = exec.Command("xdg-open", path)
+    default:
+        return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+    }
+
+    return cmd.Start()
+}
+
+func fileIsDir(path string) bool {
+    info, err := os.Stat(path)
+    return err == nil && info.IsDir()
+}
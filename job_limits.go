@@ -0,0 +1,191 @@
+package main
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "sync"
+)
+
+const (
+    defaultMaxConcurrentJobs    = 2
+    defaultMaxConcurrentGPUJobs = 1
+    maxConcurrentJobsLimit      = 32
+)
+
+// jobSemaphore is a weighted semaphore whose size can change between acquisitions, so a
+// new MaxConcurrentJobs setting applies to the next job without a restart
+type jobSemaphore struct {
+    mu      sync.Mutex
+    used    int64
+    changed chan struct{}
+}
+
+// acquire waits until weight fits under limit. A job heavier than the whole limit still
+// runs once nothing else holds the semaphore, rather than waiting forever.
+func (s *jobSemaphore) acquire(ctx context.Context, weight, limit int64) error {
+    for {
+        s.mu.Lock()
+        if s.changed == nil {
+            s.changed = make(chan struct{})
+        }
+        if s.used == 0 || s.used+weight <= limit {
+            s.used += weight
+            s.mu.Unlock()
+            return nil
+        }
+        changed := s.changed
+        s.mu.Unlock()
+
+        select {
+        case <-changed:
+        case <-ctx.Done():
+            return ctx.Err()
+        }
+    }
+}
+
+// tryAcquire is acquire without waiting
+func (s *jobSemaphore) tryAcquire(weight, limit int64) bool {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if s.used == 0 || s.used+weight <= limit {
+        s.used += weight
+        return true
+    }
+    return false
+}
+
+func (s *jobSemaphore) release(weight int64) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.used -= weight
+    if s.changed != nil {
+        close(s.changed)
+    }
+    s.changed = make(chan struct{})
+}
+
+// jobLimiter bounds how many pipeline steps run at once across projects, with a lower
+// bound for steps that load models onto the GPU, while running one step per project at a time
+type jobLimiter struct {
+    all jobSemaphore
+    gpu jobSemaphore
+
+    projectsMu sync.Mutex
+    projects   map[string]*projectSlot
+}
+
+// projectSlot is a project's single-slot semaphore, which keeps its steps one at a time
+// while letting a cancelled step stop waiting for it
+type projectSlot struct {
+    jobSemaphore
+    refs int
+}
+
+// joinProjectSlot returns the project's slot for a step that will hold or wait for it. The
+// returned function must be called once the step is done with the slot; the last one out
+// removes it, so finished projects don't accumulate.
+func (l *jobLimiter) joinProjectSlot(projectID string) (*projectSlot, func()) {
+    l.projectsMu.Lock()
+    defer l.projectsMu.Unlock()
+    if l.projects == nil {
+        l.projects = make(map[string]*projectSlot)
+    }
+    slot, ok := l.projects[projectID]
+    if !ok {
+        slot = &projectSlot{}
+        l.projects[projectID] = slot
+    }
+    slot.refs++
+
+    return slot, func() {
+        l.projectsMu.Lock()
+        defer l.projectsMu.Unlock()
+        slot.refs--
+        if slot.refs == 0 && l.projects[projectID] == slot {
+            delete(l.projects, projectID)
+        }
+    }
+}
+
+// validateJobLimits checks the concurrency settings; zero means the default
+func validateJobLimits(settings *AppSettings) error {
+    if settings.MaxConcurrentJobs < 0 || settings.MaxConcurrentJobs > maxConcurrentJobsLimit {
+        return fmt.Errorf("max concurrent jobs must be between 1 and %d", maxConcurrentJobsLimit)
+    }
+    if settings.MaxConcurrentGPUJobs < 0 || settings.MaxConcurrentGPUJobs > maxConcurrentJobsLimit {
+        return fmt.Errorf("max concurrent GPU jobs must be between 1 and %d", maxConcurrentJobsLimit)
+    }
+    all, gpu := jobLimits(settings)
+    if gpu > all {
+        return fmt.Errorf("max concurrent GPU jobs (%d) can't exceed max concurrent jobs (%d)", gpu, all)
+    }
+    return nil
+}
+
+// jobLimits returns the effective overall and GPU concurrency limits
+func jobLimits(settings *AppSettings) (int64, int64) {
+    all, gpu := int64(defaultMaxConcurrentJobs), int64(defaultMaxConcurrentGPUJobs)
+    if settings != nil && settings.MaxConcurrentJobs > 0 {
+        all = int64(settings.MaxConcurrentJobs)
+    }
+    if settings != nil && settings.MaxConcurrentGPUJobs > 0 {
+        gpu = int64(settings.MaxConcurrentGPUJobs)
+    }
+    if gpu > all {
+        gpu = all
+    }
+    return all, gpu
+}
+
+// gpuBoundStep reports whether a step loads inference models, which is what exhausts GPU
+// memory when several run side by side. On the CPU only the overall limit applies.
+func gpuBoundStep(step string, settings *AppSettings) bool {
+    if step != "transcribe" && step != "synthesize" {
+        return false
+    }
+    return settings == nil || strings.ToLower(strings.TrimSpace(settings.Device)) != "cpu"
+}
+
+// acquireJobSlot waits for this project's previous step to finish and for a free slot
+// under the concurrency limits, or until ctx is cancelled. The returned function releases everything.
+func (a *App) acquireJobSlot(ctx context.Context, projectID, step string) (func(), error) {
+    settings, _ := a.GetAppSettings()
+    all, gpu := jobLimits(settings)
+    useGPU := gpuBoundStep(step, settings)
+
+    slot, leaveSlot := a.jobs.joinProjectSlot(projectID)
+    if err := slot.acquire(ctx, 1, 1); err != nil {
+        leaveSlot()
+        return nil, fmt.Errorf("cancelled while waiting to run %s: %w", step, err)
+    }
+    releaseSlot := func() {
+        slot.release(1)
+        leaveSlot()
+    }
+
+    if !a.jobs.all.tryAcquire(1, all) {
+        a.emitEvent("pipeline:waiting", projectID, step)
+        if err := a.jobs.all.acquire(ctx, 1, all); err != nil {
+            releaseSlot()
+            return nil, fmt.Errorf("cancelled while waiting to run %s: %w", step, err)
+        }
+    }
+    if useGPU && !a.jobs.gpu.tryAcquire(1, gpu) {
+        a.emitEvent("pipeline:waiting", projectID, step)
+        if err := a.jobs.gpu.acquire(ctx, 1, gpu); err != nil {
+            a.jobs.all.release(1)
+            releaseSlot()
+            return nil, fmt.Errorf("cancelled while waiting to run %s: %w", step, err)
+        }
+    }
+
+    return func() {
+        if useGPU {
+            a.jobs.gpu.release(1)
+        }
+        a.jobs.all.release(1)
+        releaseSlot()
+    }, nil
+}
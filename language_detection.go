@@ -0,0 +1,82 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// LanguageDetection is the spoken language identified in a project's source
+type LanguageDetection struct {
+    Language   string  `json:"language"`
+    Confidence float64 `json:"confidence"`
+}
+
+// DetectSourceLanguage identifies the source's spoken language from a short sample. Nothing
+// is saved; call ConfirmSourceLanguage once the user accepts the result. Wails can only bind
+// (value, error) returns, so the code and confidence come back together.
+func (a *App) DetectSourceLanguage(projectID string) (*LanguageDetection, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load project: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("project not found: %w", err)
+    }
+
+    source := project.FileReferences.AudioFile
+    if source == nil {
+        source = project.FileReferences.VideoFile
+    }
+    if !fileReferenceExists(projectDir, source) {
+        return nil, fmt.Errorf("source media not available, run the download step first")
+    }
+
+    pythonDir := a.getPythonScriptsDir()
+    cmd := a.trackedCommand(a.getPythonCommand(), filepath.Join(pythonDir, "detect_language.py"), resolveFileReferencePath(projectDir, source))
+    defer a.untrackCommand(cmd)
+    cmd.Dir = pythonDir
+    cmd.Env = append(os.Environ(), fmt.Sprintf("PYTHONPATH=%s", pythonDir))
+
+    var stderr bytes.Buffer
+    cmd.Stderr = &stderr
+    output, runErr := a.commandOutput(cmd)
+
+    var result struct {
+        Success    bool    `json:"success"`
+        Language   string  `json:"language"`
+        Confidence float64 `json:"confidence"`
+        Error      string  `json:"error"`
+    }
+    if err := parseTrailingJSON(output, &result); err != nil {
+        if runErr != nil {
+            return nil, fmt.Errorf("language detection failed: %v\nOutput: %s", runErr, stderr.String())
+        }
+        return nil, fmt.Errorf("failed to parse language detection output: %w", err)
+    }
+    if !result.Success {
+        return nil, fmt.Errorf("language detection failed: %s", result.Error)
+    }
+
+    return &LanguageDetection{Language: result.Language, Confidence: result.Confidence}, nil
+}
+
+// ConfirmSourceLanguage sets the transcription language, typically to a detected value the
+// user has accepted
+func (a *App) ConfirmSourceLanguage(projectID, language string) error {
+    language = strings.ToLower(strings.TrimSpace(language))
+    if language == "" {
+        return fmt.Errorf("language is empty")
+    }
+
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+
+    project.Settings.Transcription.Language = language
+    return a.UpdateProject(project)
+}
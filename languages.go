@@ -0,0 +1,86 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sort"
+)
+
+// LanguageSupport lists the language codes each pipeline capability supports
+type LanguageSupport struct {
+    Transcribe []string `json:"transcribe"`
+    Translate  []string `json:"translate"`
+    Synthesize []string `json:"synthesize"`
+    // EndToEnd holds the target languages a project can be dubbed into from start to finish
+    EndToEnd []string `json:"endToEnd"`
+}
+
+// GetSupportedLanguages returns the languages supported by the transcription, translation
+// and synthesis backends. The backends are queried once and the answer cached.
+func (a *App) GetSupportedLanguages() (LanguageSupport, error) {
+    a.languagesMu.Lock()
+    defer a.languagesMu.Unlock()
+
+    if a.languages != nil {
+        return *a.languages, nil
+    }
+
+    pythonDir := a.getPythonScriptsDir()
+    cmd := a.trackedCommand(a.getPythonCommand(), filepath.Join(pythonDir, "supported_languages.py"))
+    defer a.untrackCommand(cmd)
+    cmd.Dir = pythonDir
+    cmd.Env = append(os.Environ(), fmt.Sprintf("PYTHONPATH=%s", pythonDir))
+
+    var stderr bytes.Buffer
+    cmd.Stderr = &stderr
+    output, runErr := a.commandOutput(cmd)
+
+    var result struct {
+        LanguageSupport
+        Error string `json:"error"`
+    }
+    if err := parseTrailingJSON(output, &result); err != nil {
+        if runErr != nil {
+            return LanguageSupport{}, fmt.Errorf("failed to query supported languages: %v\nOutput: %s", runErr, stderr.String())
+        }
+        return LanguageSupport{}, fmt.Errorf("failed to parse supported languages: %w", err)
+    }
+    if result.Error != "" {
+        return LanguageSupport{}, fmt.Errorf("failed to query supported languages: %s", result.Error)
+    }
+
+    support := result.LanguageSupport
+    support.EndToEnd = intersectLanguages(support.Translate, support.Synthesize)
+    a.languages = &support
+
+    return support, nil
+}
+
+// intersectLanguages returns the codes present in every list, sorted
+func intersectLanguages(lists ...[]string) []string {
+    if len(lists) == 0 {
+        return []string{}
+    }
+
+    counts := make(map[string]int)
+    for _, list := range lists {
+        seen := make(map[string]bool)
+        for _, code := range list {
+            if !seen[code] {
+                seen[code] = true
+                counts[code]++
+            }
+        }
+    }
+
+    common := []string{}
+    for code, n := range counts {
+        if n == len(lists) {
+            common = append(common, code)
+        }
+    }
+    sort.Strings(common)
+    return common
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+    "archive/zip"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "io/fs"
+    "os"
+    "path"
+    "path/filepath"
+    "strings"
+    "time"
+)
+
+// libraryBackupVersion is bumped whenever the backup archive layout changes
+const libraryBackupVersion = 1
+
+const (
+    backupManifestName = "backup-manifest.json"
+    backupSettingsName = "settings.json"
+    backupProjectsDir  = "projects/"
+)
+
+// LibraryBackupManifest describes a library backup archive
+type LibraryBackupManifest struct {
+    BackupVersion int    `json:"backupVersion"`
+    AppVersion    string `json:"appVersion"`
+    Created       string `json:"created"`
+    ProjectsPath  string `json:"projectsPath"`
+    ProjectCount  int    `json:"projectCount"`
+}
+
+// BackupLibrary writes the whole projects library plus the app settings (minus secrets) to
+// a single zip archive at destPath. Files are streamed in one at a time, with
+// "export:progress" events along the way; CancelExport stops it and removes the archive.
+func (a *App) BackupLibrary(destPath string) error {
+    settings, err := a.GetAppSettings()
+    if err != nil {
+        return fmt.Errorf("failed to get app settings: %w", err)
+    }
+    projectsDir := settings.DefaultProjectsPath
+
+    destAbs, _ := filepath.Abs(destPath)
+
+    // Pre-scan the library size so progress can be reported as a percentage
+    totalBytes, err := dirSize(projectsDir)
+    if err != nil && !os.IsNotExist(err) {
+        return fmt.Errorf("failed to read projects directory: %w", err)
+    }
+
+    progress, done, err := a.beginExport(destPath, totalBytes)
+    if err != nil {
+        return err
+    }
+    defer done()
+
+    if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+        return fmt.Errorf("failed to create destination directory: %w", err)
+    }
+    out, err := os.Create(destPath)
+    if err != nil {
+        return fmt.Errorf("failed to create archive: %w", err)
+    }
+    defer out.Close()
+
+    archive := zip.NewWriter(out)
+    fail := func(format string, args ...interface{}) error {
+        archive.Close()
+        out.Close()
+        os.Remove(destPath)
+        return fmt.Errorf(format, args...)
+    }
+
+    projectCount := 0
+    err = filepath.WalkDir(projectsDir, func(p string, d fs.DirEntry, err error) error {
+        if err != nil {
+            if p == projectsDir && os.IsNotExist(err) {
+                return filepath.SkipDir
+            }
+            return err
+        }
+        // Every project already holds its own link to a shared input
+        if d.IsDir() && p == filepath.Join(projectsDir, inputsCacheDir) {
+            return filepath.SkipDir
+        }
+        // Links may point anywhere, so only real files are backed up
+        if !d.Type().IsRegular() {
+            return nil
+        }
+        if abs, _ := filepath.Abs(p); abs == destAbs {
+            return nil
+        }
+
+        rel, err := filepath.Rel(projectsDir, p)
+        if err != nil {
+            return err
+        }
+        if filepath.Base(p) == "project.json" {
+            projectCount++
+        }
+        return addFileToZip(archive, p, backupProjectsDir+filepath.ToSlash(rel), progress)
+    })
+    if errors.Is(err, errExportCancelled) {
+        return fail("%w", err)
+    }
+    if err != nil {
+        return fail("failed to back up projects: %w", err)
+    }
+
+    // Secrets stay on this machine
+    redacted := *settings
+    redacted.APIServerToken = ""
+    redacted.CompletionWebhookURL = nil
+    if err := writeZipJSON(archive, backupSettingsName, redacted); err != nil {
+        return fail("failed to back up settings: %w", err)
+    }
+
+    manifest := LibraryBackupManifest{
+        BackupVersion: libraryBackupVersion,
+        AppVersion:    appVersion,
+        Created:       time.Now().Format(time.RFC3339),
+        ProjectsPath:  projectsDir,
+        ProjectCount:  projectCount,
+    }
+    if err := writeZipJSON(archive, backupManifestName, manifest); err != nil {
+        return fail("failed to write manifest: %w", err)
+    }
+
+    if err := archive.Close(); err != nil {
+        return fail("failed to finalize archive: %w", err)
+    }
+    // A cancel that lands after the last file was copied still discards the archive
+    if err := progress.cancelled(); err != nil {
+        return fail("%w", err)
+    }
+    return nil
+}
+
+// RestoreLibrary restores a BackupLibrary archive into the current projects directory.
+// Existing files are never overwritten, so restoring over a library only adds what is missing.
+// Settings are restored too, keeping this machine's projects path, temp directory and secrets.
+func (a *App) RestoreLibrary(archivePath string) error {
+    reader, err := zip.OpenReader(archivePath)
+    if err != nil {
+        return fmt.Errorf("failed to open backup: %w", err)
+    }
+    defer reader.Close()
+
+    var manifest LibraryBackupManifest
+    if err := readZipJSON(&reader.Reader, backupManifestName, &manifest); err != nil {
+        return fmt.Errorf("not a library backup: %w", err)
+    }
+    if manifest.BackupVersion > libraryBackupVersion {
+        return fmt.Errorf("backup was made by a newer version of the app (%s)", manifest.AppVersion)
+    }
+
+    settings, err := a.GetAppSettings()
+    if err != nil {
+        return fmt.Errorf("failed to get app settings: %w", err)
+    }
+    projectsDir := settings.DefaultProjectsPath
+
+    for _, file := range reader.File {
+        if !strings.HasPrefix(file.Name, backupProjectsDir) || file.FileInfo().IsDir() {
+            continue
+        }
+
+        // Guard against entries escaping the projects directory
+        rel := path.Clean(strings.TrimPrefix(file.Name, backupProjectsDir))
+        if rel == "." || strings.HasPrefix(rel, "../") || rel == ".." || path.IsAbs(rel) {
+            return fmt.Errorf("backup contains an invalid path: %s", file.Name)
+        }
+        target := filepath.Join(projectsDir, filepath.FromSlash(rel))
+
+        if _, err := os.Lstat(target); err == nil {
+            continue
+        }
+        if err := extractZipFile(file, target); err != nil {
+            return fmt.Errorf("failed to restore %s: %w", rel, err)
+        }
+    }
+
+    var restored AppSettings
+    if err := readZipJSON(&reader.Reader, backupSettingsName, &restored); err != nil {
+        return fmt.Errorf("projects restored, but settings could not be read: %w", err)
+    }
+    restored.DefaultProjectsPath = settings.DefaultProjectsPath
+    restored.TempDir = settings.TempDir
+    restored.APIServerToken = settings.APIServerToken
+    restored.CompletionWebhookURL = settings.CompletionWebhookURL
+    if err := a.SaveAppSettings(&restored); err != nil {
+        return fmt.Errorf("projects restored, but settings could not be saved: %w", err)
+    }
+
+    return nil
+}
+
+// writeZipJSON adds value to the archive as an indented JSON file
+func writeZipJSON(archive *zip.Writer, name string, value interface{}) error {
+    data, err := json.MarshalIndent(value, "", "  ")
+    if err != nil {
+        return err
+    }
+    writer, err := archive.Create(name)
+    if err != nil {
+        return err
+    }
+    _, err = writer.Write(data)
+    return err
+}
+
+// readZipJSON decodes the named JSON file of an archive into value
+func readZipJSON(archive *zip.Reader, name string, value interface{}) error {
+    file, err := archive.Open(name)
+    if err != nil {
+        return err
+    }
+    defer file.Close()
+    return json.NewDecoder(file).Decode(value)
+}
+
+// extractZipFile streams one archive entry to target, creating parent directories
+func extractZipFile(file *zip.File, target string) error {
+    if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+        return err
+    }
+
+    src, err := file.Open()
+    if err != nil {
+        return err
+    }
+    defer src.Close()
+
+    dst, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+    if err != nil {
+        return err
+    }
+    if _, err := io.Copy(dst, src); err != nil {
+        dst.Close()
+        os.Remove(target)
+        return err
+    }
+    if err := dst.Close(); err != nil {
+        return err
+    }
+
+    os.Chtimes(target, file.Modified, file.Modified)
+    return nil
+}
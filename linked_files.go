@@ -0,0 +1,89 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// CopyLinkedFile copies a single linked input ("video" or "audio") into the project
+func (a *App) CopyLinkedFile(projectID, role string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project directory not found: %w", err)
+    }
+
+    ref, err := fileReferenceForRole(project, role)
+    if err != nil {
+        return err
+    }
+    if *ref == nil {
+        return fmt.Errorf("project has no %s file", role)
+    }
+    if !(*ref).IsLinked {
+        return nil // Already copied
+    }
+
+    if err := a.copyFileToProject(projectDir, "input", *ref); err != nil {
+        return fmt.Errorf("failed to copy %s file: %w", role, err)
+    }
+
+    return a.UpdateProject(project)
+}
+
+// RelinkToExternal points a project input back at an external file instead of its copy.
+// The copy inside the project folder is left in place.
+func (a *App) RelinkToExternal(projectID, role, path string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+
+    ref, err := fileReferenceForRole(project, role)
+    if err != nil {
+        return err
+    }
+
+    absPath, err := filepath.Abs(path)
+    if err != nil {
+        return fmt.Errorf("invalid path: %w", err)
+    }
+    if err := a.validateSourceMedia(role, absPath); err != nil {
+        return err
+    }
+
+    info, err := os.Stat(absPath)
+    if err != nil {
+        return fmt.Errorf("source file not found: %w", err)
+    }
+
+    size := info.Size()
+    modTime := info.ModTime().Format(time.RFC3339)
+    *ref = &FileReference{
+        Path:         absPath,
+        IsLinked:     true,
+        OriginalPath: &absPath,
+        Size:         &size,
+        LastModified: &modTime,
+    }
+
+    return a.UpdateProject(project)
+}
+
+// fileReferenceForRole returns the project's file reference slot for "video" or "audio"
+func fileReferenceForRole(project *ProjectConfig, role string) (**FileReference, error) {
+    switch role {
+    case "video":
+        return &project.FileReferences.VideoFile, nil
+    case "audio":
+        return &project.FileReferences.AudioFile, nil
+    default:
+        return nil, fmt.Errorf("invalid file role: %s (expected \"video\" or \"audio\")", role)
+    }
+}
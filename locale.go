@@ -0,0 +1,100 @@
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+)
+
+// LocaleInfo describes how a locale writes dates and numbers in outputs
+type LocaleInfo struct {
+    Code             string `json:"code"`
+    Name             string `json:"name"`
+    DateExample      string `json:"dateExample"`
+    DecimalSeparator string `json:"decimalSeparator"`
+}
+
+// localeFormat is a locale's conventions. Date layouts only use separators that are
+// valid in filenames, since dates end up in output names.
+type localeFormat struct {
+    name       string
+    dateLayout string
+    decimal    string
+}
+
+// defaultDateLayout is used when a project has no locale: ISO order sorts correctly
+const defaultDateLayout = "2006-01-02"
+
+// exampleDate shows the day/month order unambiguously (day 31 can't be a month)
+var exampleDate = time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+var localeFormats = map[string]localeFormat{
+    "en-US": {name: "English (United States)", dateLayout: "01-02-2006", decimal: "."},
+    "en-GB": {name: "English (United Kingdom)", dateLayout: "02-01-2006", decimal: "."},
+    "es-ES": {name: "Spanish (Spain)", dateLayout: "02-01-2006", decimal: ","},
+    "es-MX": {name: "Spanish (Mexico)", dateLayout: "02-01-2006", decimal: "."},
+    "fr-FR": {name: "French (France)", dateLayout: "02-01-2006", decimal: ","},
+    "de-DE": {name: "German (Germany)", dateLayout: "02.01.2006", decimal: ","},
+    "it-IT": {name: "Italian (Italy)", dateLayout: "02-01-2006", decimal: ","},
+    "pt-BR": {name: "Portuguese (Brazil)", dateLayout: "02-01-2006", decimal: ","},
+    "pt-PT": {name: "Portuguese (Portugal)", dateLayout: "02-01-2006", decimal: ","},
+    "nl-NL": {name: "Dutch (Netherlands)", dateLayout: "02-01-2006", decimal: ","},
+    "ru-RU": {name: "Russian (Russia)", dateLayout: "02.01.2006", decimal: ","},
+    "pl-PL": {name: "Polish (Poland)", dateLayout: "02.01.2006", decimal: ","},
+    "ja-JP": {name: "Japanese (Japan)", dateLayout: "2006-01-02", decimal: "."},
+    "zh-CN": {name: "Chinese (China)", dateLayout: "2006-01-02", decimal: "."},
+    "ko-KR": {name: "Korean (Korea)", dateLayout: "2006.01.02", decimal: "."},
+}
+
+// normalizeLocale turns "pt_br" or "PT-br" into the canonical "pt-BR"
+func normalizeLocale(locale string) string {
+    parts := strings.SplitN(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-", 2)
+    if len(parts) != 2 {
+        return strings.ToLower(parts[0])
+    }
+    return strings.ToLower(parts[0]) + "-" + strings.ToUpper(parts[1])
+}
+
+// validateLocale accepts an empty locale (ISO dates) or one from GetAvailableLocales
+func validateLocale(locale string) error {
+    if strings.TrimSpace(locale) == "" {
+        return nil
+    }
+    if _, ok := localeFormats[normalizeLocale(locale)]; !ok {
+        return fmt.Errorf("unsupported locale: %s (supported: %s)", locale, strings.Join(localeCodes(), ", "))
+    }
+    return nil
+}
+
+func localeCodes() []string {
+    codes := make([]string, 0, len(localeFormats))
+    for code := range localeFormats {
+        codes = append(codes, code)
+    }
+    sort.Strings(codes)
+    return codes
+}
+
+// localeDateLayout is the time layout for dates rendered in a project's outputs
+func localeDateLayout(locale string) string {
+    if format, ok := localeFormats[normalizeLocale(locale)]; ok {
+        return format.dateLayout
+    }
+    return defaultDateLayout
+}
+
+// GetAvailableLocales lists the locales a project can format its outputs with
+func (a *App) GetAvailableLocales() ([]LocaleInfo, error) {
+    locales := make([]LocaleInfo, 0, len(localeFormats))
+    for _, code := range localeCodes() {
+        format := localeFormats[code]
+        locales = append(locales, LocaleInfo{
+            Code:             code,
+            Name:             format.name,
+            DateExample:      exampleDate.Format(format.dateLayout),
+            DecimalSeparator: format.decimal,
+        })
+    }
+    return locales, nil
+}
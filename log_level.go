@@ -0,0 +1,98 @@
+package main
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+)
+
+// Log levels for AppSettings.LogLevel, from least to most output. The step logs on disk
+// always get every line; the level decides which lines are emitted as "pipeline:log"
+// events and how chatty the Python scripts are (KOKORO_LOG_LEVEL).
+const (
+    logLevelQuiet   = "quiet"
+    logLevelNormal  = "normal"
+    logLevelVerbose = "verbose"
+    logLevelDebug   = "debug"
+)
+
+const defaultLogLevel = logLevelNormal
+
+// Severity of a single output line
+const (
+    lineDebug = iota
+    lineInfo
+    lineWarning
+    lineError
+)
+
+// logLevelMinSeverity is the least severe line each level emits
+var logLevelMinSeverity = map[string]int{
+    logLevelQuiet:   lineWarning,
+    logLevelNormal:  lineInfo,
+    logLevelVerbose: lineDebug,
+    logLevelDebug:   lineDebug,
+}
+
+// lineLevelPattern finds the level tag of Python logging lines, in the app's format
+// ("2024-01-01 12:00:00,000 - WARNING - ...") as well as the default "WARNING:name:..."
+// and bracketed "[WARNING]" forms
+var lineLevelPattern = regexp.MustCompile(`^(?:\S+ \S+ - (DEBUG|INFO|WARNING|ERROR|CRITICAL) - |(DEBUG|INFO|WARNING|ERROR|CRITICAL):|\[(DEBUG|INFO|WARNING|WARN|ERROR|CRITICAL)\])`)
+
+// validateLogLevel accepts an empty level (the default) or one of the known levels
+func validateLogLevel(level string) error {
+    if level == "" {
+        return nil
+    }
+    if _, ok := logLevelMinSeverity[level]; !ok {
+        return fmt.Errorf("invalid log level %q (expected quiet, normal, verbose or debug)", level)
+    }
+    return nil
+}
+
+// currentLogLevel is the configured log level, or the default
+func (a *App) currentLogLevel() string {
+    settings, err := a.GetAppSettings()
+    if err != nil || validateLogLevel(settings.LogLevel) != nil || settings.LogLevel == "" {
+        return defaultLogLevel
+    }
+    return settings.LogLevel
+}
+
+// lineSeverity classifies a line of subprocess output by its logging tag, falling back to
+// the emoji the scripts prefix their prints with. Untagged lines are info.
+func lineSeverity(line string) int {
+    trimmed := strings.TrimSpace(line)
+    if match := lineLevelPattern.FindStringSubmatch(trimmed); match != nil {
+        level := match[1] + match[2] + match[3]
+        switch level {
+        case "DEBUG":
+            return lineDebug
+        case "INFO":
+            return lineInfo
+        case "WARNING", "WARN":
+            return lineWarning
+        default:
+            return lineError
+        }
+    }
+
+    switch {
+    case strings.HasPrefix(trimmed, "❌"), strings.HasPrefix(trimmed, "Traceback (most recent call last)"):
+        return lineError
+    case strings.HasPrefix(trimmed, "⚠️"):
+        return lineWarning
+    case strings.HasPrefix(trimmed, "🔍 DEBUG"):
+        return lineDebug
+    }
+    return lineInfo
+}
+
+// emitsLine reports whether a line of the given severity is shown at level
+func emitsLine(level string, severity int) bool {
+    minimum, ok := logLevelMinSeverity[level]
+    if !ok {
+        minimum = logLevelMinSeverity[defaultLogLevel]
+    }
+    return severity >= minimum
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"embed"
+	"errors"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -24,13 +25,18 @@ func extractPythonScripts() (string, error) {
 	// Remove existing temp directory
 	os.RemoveAll(tempDir)
 	
+	return tempDir, extractPythonScriptsTo(tempDir)
+}
+
+// extractPythonScriptsTo writes the embedded Python scripts into dir
+func extractPythonScriptsTo(dir string) error {
 	// Create temp directory
-	if err := os.MkdirAll(tempDir, 0755); err != nil {
-		return "", err
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
 	}
 	
 	// Extract all embedded Python files
-	return tempDir, fs.WalkDir(pythonScripts, "python", func(path string, d fs.DirEntry, err error) error {
+	return fs.WalkDir(pythonScripts, "python", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -48,7 +54,7 @@ func extractPythonScripts() (string, error) {
 		
 		// Create target path
 		relPath, _ := filepath.Rel("python", path)
-		targetPath := filepath.Join(tempDir, relPath)
+		targetPath := filepath.Join(dir, relPath)
 		
 		// Create parent directory if needed
 		if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
@@ -63,6 +69,21 @@ func extractPythonScripts() (string, error) {
 func main() {
 	// Create an instance of the app structure
 	app := NewApp()
+	
+	// Batch/server usage: run the pipeline from the command line without a window. It uses
+	// its own copy of the Python scripts, so it can run while the app is open.
+	if isHeadlessInvocation(os.Args[1:]) {
+		os.Exit(runHeadless(app, os.Args[1:]))
+	}
+	
+	// Only one instance may use the shared temp Python directory. A second launch still
+	// starts Wails, which brings the running instance's window forward and exits.
+	if err := app.acquireInstanceLock(); errors.Is(err, errInstanceRunning) {
+		app.secondInstance = true
+	} else if err != nil {
+		println("Error:", err.Error())
+		os.Exit(1)
+	}
 
 	// Create application with options
 	err := wails.Run(&options.App{
@@ -74,12 +95,18 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 81, A: 1},
 		OnStartup:        app.OnStartup,  // Changed from app.startup
+		OnShutdown:       app.OnShutdown,
+		SingleInstanceLock: &options.SingleInstanceLock{
+			UniqueId:               singleInstanceID,
+			OnSecondInstanceLaunch: app.onSecondInstanceLaunch,
+		},
 		Bind: []interface{}{
 			app,
 		},
 	})
 
 	if err != nil {
+		app.releaseInstanceLock()
 		println("Error:", err.Error())
 	}
 }
\ No newline at end of file
@@ -0,0 +1,179 @@
+package main
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+    "sort"
+)
+
+// projectManifestVersion is bumped whenever a ProjectManifest field changes meaning
+const projectManifestVersion = 1
+
+// ProjectManifest is a flattened, stable description of a project for external tools.
+// Unlike project.json its shape only changes together with ManifestVersion.
+type ProjectManifest struct {
+    ManifestVersion int                     `json:"manifestVersion"`
+    ID              string                  `json:"id"`
+    Name            string                  `json:"name"`
+    Created         string                  `json:"created"`
+    LastModified    string                  `json:"lastModified"`
+    Source          ManifestSource          `json:"source"`
+    SourceLanguage  string                  `json:"sourceLanguage"`
+    TargetLanguage  string                  `json:"targetLanguage"`
+    CompletedSteps  []string                `json:"completedSteps"`
+    Files           []ManifestFile          `json:"files"`
+    Settings        ManifestSettingsSummary `json:"settings"`
+    Notes           string                  `json:"notes"`
+
+}
+
+// ManifestSource describes where a project's media came from
+type ManifestSource struct {
+    Type     string `json:"type"`
+    URL      string `json:"url,omitempty"`
+    Filename string `json:"filename,omitempty"`
+}
+
+// ManifestFile is one file the project has produced or references
+type ManifestFile struct {
+    Role   string `json:"role"`
+    Path   string `json:"path"`
+    Exists bool   `json:"exists"`
+    Size   int64  `json:"size"`
+    SHA256 string `json:"sha256,omitempty"`
+}
+
+// ManifestSettingsSummary is the subset of settings that shapes the output
+type ManifestSettingsSummary struct {
+    TranscriptionSource string `json:"transcriptionSource"`
+    Diarization         bool   `json:"diarization"`
+    TranslationMode     string `json:"translationMode"`
+    TranslationModel    string `json:"translationModel,omitempty"`
+    GlossaryTerms       int    `json:"glossaryTerms"`
+    TextRules           int    `json:"textRules"`
+    SegmentRules        int    `json:"segmentRules"`
+    EffectsPreset       string `json:"effectsPreset"`
+    OutputFormat        string `json:"outputFormat"`
+}
+
+// GetProjectManifest returns a structured summary of a project for downstream automation
+func (a *App) GetProjectManifest(projectID string) (ProjectManifest, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return ProjectManifest{}, fmt.Errorf("failed to load project: %w", err)
+    }
+
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return ProjectManifest{}, fmt.Errorf("project not found: %w", err)
+    }
+
+    manifest := ProjectManifest{
+        ManifestVersion: projectManifestVersion,
+        ID:              project.ID,
+        Name:            project.Name,
+        Created:         project.Created,
+        LastModified:    project.LastModified,
+        Notes:           project.Notes,
+        Source:          ManifestSource{Type: project.SourceType},
+        SourceLanguage:  project.Settings.Transcription.Language,
+        TargetLanguage:  project.TargetLanguage,
+        CompletedSteps:  []string{},
+        Files:           []ManifestFile{},
+    }
+
+    if project.SourceUrl != nil {
+        manifest.Source.URL = *project.SourceUrl
+    }
+    if project.OriginalFilename != nil {
+        manifest.Source.Filename = *project.OriginalFilename
+    }
+
+    for _, step := range projectPipelineSteps(project) {
+        if isStepCompleted(project.CompletedSteps, step) {
+            manifest.CompletedSteps = append(manifest.CompletedSteps, step)
+        }
+    }
+
+    refs := project.FileReferences
+    if refs.VideoFile != nil {
+        manifest.Files = append(manifest.Files, manifestFile("sourceVideo", resolveFileReferencePath(projectDir, refs.VideoFile)))
+    }
+    if refs.AudioFile != nil {
+        manifest.Files = append(manifest.Files, manifestFile("sourceAudio", resolveFileReferencePath(projectDir, refs.AudioFile)))
+    }
+    if refs.SegmentsFile != nil {
+        manifest.Files = append(manifest.Files, manifestFile("segments", projectSegmentsPath(projectDir, project)))
+    }
+    for role, path := range map[string]*string{"finalAudio": refs.FinalAudio, "finalVideo": refs.FinalVideo} {
+        if path != nil {
+            manifest.Files = append(manifest.Files, manifestFile(role, resolveProjectPath(projectDir, *path)))
+        }
+    }
+    sortManifestFiles(manifest.Files)
+
+    settings := project.Settings
+    manifest.Settings = ManifestSettingsSummary{
+        TranscriptionSource: settings.Transcription.Source,
+        Diarization:         settings.Transcription.EnableDiarization,
+        TranslationMode:     settings.Translation.Mode,
+        TranslationModel:    settings.Translation.SimpleModel,
+        GlossaryTerms:       len(settings.Translation.Glossary),
+        TextRules:           len(project.TextRules),
+        SegmentRules:        len(project.SegmentRules),
+        EffectsPreset:       settings.Audio.EffectsPreset,
+        OutputFormat:        outputFormatOrDefault(settings.Output.Format),
+    }
+
+    return manifest, nil
+}
+
+// resolveProjectPath resolves a path recorded in project.json against the project folder
+func resolveProjectPath(projectDir, path string) string {
+    if filepath.IsAbs(path) {
+        return path
+    }
+    return filepath.Join(projectDir, path)
+}
+
+// manifestFile stats and checksums a file; missing files are listed with Exists false
+func manifestFile(role, path string) ManifestFile {
+    file := ManifestFile{Role: role, Path: path}
+
+    info, err := os.Stat(path)
+    if err != nil || info.IsDir() {
+        return file
+    }
+    file.Exists = true
+    file.Size = info.Size()
+
+    if sum, err := fileSHA256(path); err == nil {
+        file.SHA256 = sum
+    }
+    return file
+}
+
+func fileSHA256(path string) (string, error) {
+    f, err := os.Open(path)
+    if err != nil {
+        return "", err
+    }
+    defer f.Close()
+
+    hash := sha256.New()
+    if _, err := io.Copy(hash, f); err != nil {
+        return "", err
+    }
+    return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sortManifestFiles orders files by role so the manifest is stable between calls
+func sortManifestFiles(files []ManifestFile) {
+    sort.Slice(files, func(i, j int) bool {
+        return files[i].Role < files[j].Role
+    })
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+    "encoding/xml"
+    "errors"
+    "fmt"
+    "io"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// Synthesis markup
+//
+// Text sent for synthesis may use a small SSML subset:
+//
+//     <break time="500ms"/>             a pause of up to 10s ("ms" or "s")
+//     <emphasis level="strong">…</emphasis>   level is strong, moderate or reduced
+//
+// and may optionally be wrapped in <speak>…</speak>. Kokoro has no SSML support, so the
+// Python side splits the text at these tags (see util/markup.py). Markup is only
+// interpreted for projects with UsesMarkup set.
+
+const maxBreakSeconds = 10.0
+
+var breakTimePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)(ms|s)$`)
+
+var emphasisLevels = map[string]bool{"strong": true, "moderate": true, "reduced": true}
+
+// containsMarkup reports whether text looks like it uses synthesis markup
+func containsMarkup(text string) bool {
+    return strings.Contains(text, "<")
+}
+
+// ValidateSSML checks that text only uses the supported markup subset and is well formed
+func (a *App) ValidateSSML(text string) error {
+    doc := text
+    if !strings.HasPrefix(strings.TrimSpace(doc), "<speak") {
+        doc = "<speak>" + doc + "</speak>"
+    }
+
+    decoder := xml.NewDecoder(strings.NewReader(doc))
+    decoder.Strict = true
+
+    var stack []string
+    for {
+        token, err := decoder.Token()
+        if errors.Is(err, io.EOF) {
+            break
+        }
+        if err != nil {
+            return fmt.Errorf("malformed markup: %w", err)
+        }
+
+        switch t := token.(type) {
+        case xml.StartElement:
+            name := t.Name.Local
+            switch name {
+            case "speak":
+                if len(stack) > 0 {
+                    return fmt.Errorf("<speak> can only wrap the whole text")
+                }
+            case "break":
+                if err := validateBreak(t); err != nil {
+                    return err
+                }
+            case "emphasis":
+                if err := validateEmphasis(t, stack); err != nil {
+                    return err
+                }
+            default:
+                return fmt.Errorf("unsupported markup element <%s> (allowed: break, emphasis)", name)
+            }
+            if len(stack) > 0 && stack[len(stack)-1] == "break" {
+                return fmt.Errorf("<break/> cannot contain other elements")
+            }
+            stack = append(stack, name)
+        case xml.EndElement:
+            stack = stack[:len(stack)-1]
+        case xml.CharData:
+            if len(stack) > 0 && stack[len(stack)-1] == "break" && strings.TrimSpace(string(t)) != "" {
+                return fmt.Errorf("<break/> cannot contain text")
+            }
+        case xml.ProcInst, xml.Directive:
+            return fmt.Errorf("markup cannot contain processing instructions or directives")
+        }
+    }
+
+    return nil
+}
+
+func validateBreak(el xml.StartElement) error {
+    hasTime := false
+    for _, attr := range el.Attr {
+        if attr.Name.Local != "time" {
+            return fmt.Errorf("unsupported <break> attribute %q (allowed: time)", attr.Name.Local)
+        }
+        match := breakTimePattern.FindStringSubmatch(attr.Value)
+        if match == nil {
+            return fmt.Errorf("invalid break time %q (use e.g. \"500ms\" or \"1.5s\")", attr.Value)
+        }
+        seconds, _ := strconv.ParseFloat(match[1], 64)
+        if match[2] == "ms" {
+            seconds /= 1000
+        }
+        if seconds > maxBreakSeconds {
+            return fmt.Errorf("break of %s exceeds the %.0fs maximum", attr.Value, maxBreakSeconds)
+        }
+        hasTime = true
+    }
+    if !hasTime {
+        return fmt.Errorf("<break> requires a time attribute")
+    }
+    return nil
+}
+
+func validateEmphasis(el xml.StartElement, stack []string) error {
+    for _, open := range stack {
+        if open == "emphasis" {
+            return fmt.Errorf("<emphasis> cannot be nested")
+        }
+    }
+    for _, attr := range el.Attr {
+        if attr.Name.Local != "level" {
+            return fmt.Errorf("unsupported <emphasis> attribute %q (allowed: level)", attr.Name.Local)
+        }
+        if !emphasisLevels[attr.Value] {
+            return fmt.Errorf("invalid emphasis level %q (use strong, moderate or reduced)", attr.Value)
+        }
+    }
+    return nil
+}
+
+// validateSegmentMarkup checks the markup of every segment before synthesis, so a typo in
+// one line fails fast with its segment number rather than deep inside the Python step
+func (a *App) validateSegmentMarkup(projectID string) error {
+    _, _, segments, err := a.loadProjectSegments(projectID)
+    if err != nil {
+        return err
+    }
+    for i, segment := range segments {
+        text := segment.TranslatedText
+        if text == "" {
+            text = segment.OriginalText
+        }
+        if !containsMarkup(text) {
+            continue
+        }
+        if err := a.ValidateSSML(text); err != nil {
+            return fmt.Errorf("segment %d: %w", i, err)
+        }
+    }
+    return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os/exec"
+    "strconv"
+)
+
+// StreamInfo describes one stream of a media file as reported by ffprobe
+type StreamInfo struct {
+    // Index is the stream's position in the file; TypeIndex its position among streams of
+    // the same type, which is what SourceAudioStream refers to
+    Index      int     `json:"index"`
+    TypeIndex  int     `json:"typeIndex"`
+    CodecType  string  `json:"codecType"`
+    CodecName  string  `json:"codecName"`
+    Language   string  `json:"language,omitempty"`
+    Title      string  `json:"title,omitempty"`
+    Channels   int     `json:"channels,omitempty"`
+    SampleRate int     `json:"sampleRate,omitempty"`
+    Width      int     `json:"width,omitempty"`
+    Height     int     `json:"height,omitempty"`
+    Duration   float64 `json:"duration,omitempty"`
+    Default    bool    `json:"default"`
+}
+
+// GetMediaStreams lists the audio, video and subtitle streams of a media file
+func (a *App) GetMediaStreams(path string) ([]StreamInfo, error) {
+    ffprobe, err := exec.LookPath("ffprobe")
+    if err != nil {
+        return nil, fmt.Errorf("ffprobe is required to inspect media streams")
+    }
+
+    cmd := a.trackedCommand(ffprobe, "-v", "error", "-show_streams", "-of", "json", path)
+    defer a.untrackCommand(cmd)
+
+    output, err := a.commandOutput(cmd)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read media streams: %w", err)
+    }
+
+    var probe struct {
+        Streams []struct {
+            Index       int               `json:"index"`
+            CodecType   string            `json:"codec_type"`
+            CodecName   string            `json:"codec_name"`
+            Channels    int               `json:"channels"`
+            SampleRate  string            `json:"sample_rate"`
+            Width       int               `json:"width"`
+            Height      int               `json:"height"`
+            Duration    string            `json:"duration"`
+            Tags        map[string]string `json:"tags"`
+            Disposition map[string]int    `json:"disposition"`
+        } `json:"streams"`
+    }
+    if err := json.Unmarshal(output, &probe); err != nil {
+        return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
+    }
+
+    streams := make([]StreamInfo, 0, len(probe.Streams))
+    typeCounts := make(map[string]int)
+    for _, s := range probe.Streams {
+        info := StreamInfo{
+            Index:     s.Index,
+            TypeIndex: typeCounts[s.CodecType],
+            CodecType: s.CodecType,
+            CodecName: s.CodecName,
+            Language:  s.Tags["language"],
+            Title:     s.Tags["title"],
+            Channels:  s.Channels,
+            Width:     s.Width,
+            Height:    s.Height,
+            Default:   s.Disposition["default"] == 1,
+        }
+        info.SampleRate, _ = strconv.Atoi(s.SampleRate)
+        info.Duration, _ = strconv.ParseFloat(s.Duration, 64)
+        typeCounts[s.CodecType]++
+        streams = append(streams, info)
+    }
+
+    return streams, nil
+}
+
+// validateSourceAudioStream checks the chosen audio track exists in the source. It can only
+// check the upper bound when the source is available and ffprobe is installed.
+func (a *App) validateSourceAudioStream(projectDir string, project *ProjectConfig) error {
+    stream := project.Settings.Transcription.SourceAudioStream
+    if stream == nil {
+        return nil
+    }
+    if *stream < 0 {
+        return fmt.Errorf("invalid audio stream index: %d", *stream)
+    }
+
+    source := sourceFileReference(project)
+    if !fileReferenceExists(projectDir, source) {
+        return nil
+    }
+    if _, err := exec.LookPath("ffprobe"); err != nil {
+        return nil
+    }
+
+    streams, err := a.GetMediaStreams(resolveFileReferencePath(projectDir, source))
+    if err != nil {
+        return err
+    }
+    audioStreams := 0
+    for _, s := range streams {
+        if s.CodecType == "audio" {
+            audioStreams++
+        }
+    }
+    if *stream >= audioStreams {
+        return fmt.Errorf("audio stream %d does not exist (source has %d audio streams)", *stream, audioStreams)
+    }
+    return nil
+}
+
+func sameInt(a, b *int) bool {
+    if a == nil || b == nil {
+        return a == b
+    }
+    return *a == *b
+}
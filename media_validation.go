@@ -0,0 +1,73 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "sort"
+    "strings"
+)
+
+// validateSourceMedia checks that a local source file is a media format the pipeline can
+// read, so a mistaken drop (e.g. a .txt) fails here rather than at the first pipeline step
+func (a *App) validateSourceMedia(sourceType, path string) error {
+    info, err := os.Stat(path)
+    if err != nil {
+        return fmt.Errorf("source file not found: %w", err)
+    }
+    if info.IsDir() {
+        return fmt.Errorf("source is a folder, not a media file: %s", path)
+    }
+
+    ext := strings.ToLower(filepath.Ext(path))
+    supported := videoExtensions
+    if sourceType == "audio" {
+        supported = audioExtensions
+    }
+    if !supported[ext] {
+        return fmt.Errorf("unsupported %s format %q (supported: %s)", sourceType, ext, strings.Join(sortedExtensions(supported), ", "))
+    }
+
+    return a.probeSourceMedia(sourceType, path)
+}
+
+// probeSourceMedia asks ffprobe which streams the file holds. It is skipped when
+// ffprobe isn't installed, since the extension check has already passed.
+func (a *App) probeSourceMedia(sourceType, path string) error {
+    ffprobe, err := exec.LookPath("ffprobe")
+    if err != nil {
+        return nil
+    }
+
+    cmd := a.trackedCommand(ffprobe, "-v", "error", "-show_entries", "stream=codec_type", "-of", "csv=p=0", path)
+    defer a.untrackCommand(cmd)
+
+    output, err := a.commandOutput(cmd)
+    if err != nil {
+        return fmt.Errorf("%s is not a readable media file", filepath.Base(path))
+    }
+
+    streams := make(map[string]bool)
+    for _, line := range strings.Split(string(output), "\n") {
+        streams[strings.TrimSpace(line)] = true
+    }
+
+    if sourceType == "video" && !streams["video"] {
+        return fmt.Errorf("%s has no video stream", filepath.Base(path))
+    }
+    if !streams["audio"] {
+        return fmt.Errorf("%s has no audio stream to dub", filepath.Base(path))
+    }
+
+    return nil
+}
+
+func sortedExtensions(extensions map[string]bool) []string {
+    list := make([]string, 0, len(extensions))
+    for ext := range extensions {
+        list = append(list, ext)
+    }
+    sort.Strings(list)
+    return list
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// ModelInfo describes a model a project's pipeline will need
+type ModelInfo struct {
+    // ID is the Hugging Face repo for "huggingface" models and the checkpoint file name
+    // for "torch" models
+    ID      string `json:"id"`
+    Source  string `json:"source"`
+    Purpose string `json:"purpose"`
+    // SizeBytes is an approximate download size
+    SizeBytes int64 `json:"sizeBytes"`
+    Cached    bool  `json:"cached"`
+}
+
+const (
+    modelSourceHuggingFace = "huggingface"
+    modelSourceTorch       = "torch"
+)
+
+const mb = int64(1024 * 1024)
+
+// whisperModels maps the transcription model names WhisperX accepts to the faster-whisper
+// repos they download
+var whisperModels = map[string]struct {
+    repo string
+    size int64
+}{
+    "tiny":     {"Systran/faster-whisper-tiny", 75 * mb},
+    "base":     {"Systran/faster-whisper-base", 145 * mb},
+    "small":    {"Systran/faster-whisper-small", 484 * mb},
+    "medium":   {"Systran/faster-whisper-medium", 1530 * mb},
+    "large-v2": {"Systran/faster-whisper-large-v2", 3090 * mb},
+    "large-v3": {"Systran/faster-whisper-large-v3", 3090 * mb},
+    "large":    {"Systran/faster-whisper-large-v3", 3090 * mb},
+}
+
+const defaultWhisperModel = "large"
+
+// torchAlignModels are the torchaudio checkpoints WhisperX aligns these languages with;
+// other languages use a wav2vec2 model from Hugging Face
+var torchAlignModels = map[string]string{
+    "en": "wav2vec2_fairseq_base_ls960_asr_ls960.pth",
+    "fr": "wav2vec2_voxpopuli_base_10k_asr_fr.pt",
+    "de": "wav2vec2_voxpopuli_base_10k_asr_de.pt",
+    "es": "wav2vec2_voxpopuli_base_10k_asr_es.pt",
+    "it": "wav2vec2_voxpopuli_base_10k_asr_it.pt",
+}
+
+// translationModels maps the simple translation models to their repos
+var translationModels = map[string]struct {
+    repo string
+    size int64
+}{
+    "m2m100_418m": {"facebook/m2m100_418M", 1940 * mb},
+    "m2m100_1.2b": {"facebook/m2m100_1.2B", 4960 * mb},
+}
+
+// GetRequiredModels lists the models a project's settings will need, whether each is
+// already downloaded and roughly how big it is, so the UI can warn before a first run.
+// Synthesis runs in the Kokoro server, which manages its own model.
+func (a *App) GetRequiredModels(projectID string) ([]ModelInfo, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load project: %w", err)
+    }
+    return requiredModels(project), nil
+}
+
+func requiredModels(project *ProjectConfig) []ModelInfo {
+    models := []ModelInfo{}
+    settings := project.Settings
+
+    // Both local backends run the same faster-whisper checkpoints; only WhisperX aligns and
+    // diarizes, and cloud backends download nothing
+    backend, _ := transcriptionBackendFor(settings.Transcription.Source)
+    if backend.kind == "local" {
+        name := backend.defaultModel
+        if settings.Transcription.Model != nil && *settings.Transcription.Model != "" {
+            name = *settings.Transcription.Model
+        }
+        if whisper, ok := whisperModels[name]; ok {
+            models = append(models, ModelInfo{ID: whisper.repo, Source: modelSourceHuggingFace, Purpose: "transcription", SizeBytes: whisper.size})
+        }
+    }
+    if backend.id == "whisperx" {
+        lang := settings.Transcription.Language
+        if checkpoint, ok := torchAlignModels[lang]; ok {
+            models = append(models, ModelInfo{ID: checkpoint, Source: modelSourceTorch, Purpose: "alignment", SizeBytes: 360 * mb})
+        } else if lang != "" {
+            models = append(models, ModelInfo{ID: "jonatasgrosman/wav2vec2-large-xlsr-53-" + alignLanguageName(lang), Source: modelSourceHuggingFace, Purpose: "alignment", SizeBytes: 1260 * mb})
+        }
+
+        if settings.Transcription.EnableDiarization {
+            models = append(models,
+                ModelInfo{ID: "pyannote/speaker-diarization-3.1", Source: modelSourceHuggingFace, Purpose: "diarization", SizeBytes: 1 * mb},
+                ModelInfo{ID: "pyannote/segmentation-3.0", Source: modelSourceHuggingFace, Purpose: "diarization", SizeBytes: 6 * mb},
+                ModelInfo{ID: "pyannote/wespeaker-voxceleb-resnet34-LM", Source: modelSourceHuggingFace, Purpose: "diarization", SizeBytes: 27 * mb},
+            )
+        }
+    }
+
+    // Cloud providers translate remotely; only the simple local models are downloaded
+    translation := settings.Translation
+    if translation.Mode == "simple" && translation.CloudProvider == nil {
+        if m, ok := translationModels[strings.ToLower(translation.SimpleModel)]; ok {
+            models = append(models, ModelInfo{ID: m.repo, Source: modelSourceHuggingFace, Purpose: "translation", SizeBytes: m.size})
+        }
+    }
+
+    // A project can point the caches elsewhere through its environment overrides
+    getenv := func(name string) string {
+        if value, ok := project.EnvOverrides[name]; ok {
+            return value
+        }
+        return os.Getenv(name)
+    }
+    for i := range models {
+        models[i].Cached = modelCached(models[i], getenv)
+    }
+    return models
+}
+
+// alignLanguageName names a language the way the jonatasgrosman wav2vec2 repos do
+func alignLanguageName(lang string) string {
+    names := map[string]string{
+        "ja": "japanese", "zh": "chinese-zh-cn", "nl": "dutch", "pt": "portuguese", "ru": "russian",
+        "pl": "polish", "ar": "arabic", "fi": "finnish", "hu": "hungarian", "el": "greek", "fa": "persian",
+    }
+    if name, ok := names[lang]; ok {
+        return name
+    }
+    return lang
+}
+
+// modelCached reports whether a model is already in the local Hugging Face or torch cache
+func modelCached(model ModelInfo, getenv func(string) string) bool {
+    switch model.Source {
+    case modelSourceHuggingFace:
+        snapshots := filepath.Join(huggingFaceCacheDir(getenv), "models--"+strings.ReplaceAll(model.ID, "/", "--"), "snapshots")
+        entries, err := os.ReadDir(snapshots)
+        return err == nil && len(entries) > 0
+    case modelSourceTorch:
+        return fileExists(filepath.Join(torchCacheDir(getenv), "hub", "checkpoints", model.ID))
+    }
+    return false
+}
+
+// huggingFaceCacheDir mirrors huggingface_hub's cache location rules
+func huggingFaceCacheDir(getenv func(string) string) string {
+    if dir := getenv("HF_HUB_CACHE"); dir != "" {
+        return dir
+    }
+    if dir := getenv("HF_HOME"); dir != "" {
+        return filepath.Join(dir, "hub")
+    }
+    return filepath.Join(xdgCacheHome(getenv), "huggingface", "hub")
+}
+
+// torchCacheDir mirrors torch.hub's cache location rules
+func torchCacheDir(getenv func(string) string) string {
+    if dir := getenv("TORCH_HOME"); dir != "" {
+        return dir
+    }
+    return filepath.Join(xdgCacheHome(getenv), "torch")
+}
+
+// xdgCacheHome is the cache root Python ML libraries use on every platform
+func xdgCacheHome(getenv func(string) string) string {
+    if dir := getenv("XDG_CACHE_HOME"); dir != "" {
+        return dir
+    }
+    home, _ := os.UserHomeDir()
+    return filepath.Join(home, ".cache")
+}
+
+// PrepareModels downloads the models a project needs without running the pipeline, so a
+// slow first-time setup can happen ahead of time (or before going offline). Progress is
+// reported as "pipeline:progress" events for the "models" step.
+func (a *App) PrepareModels(projectID string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+
+    var missing []ModelInfo
+    for _, model := range requiredModels(project) {
+        if !model.Cached {
+            missing = append(missing, model)
+        }
+    }
+    if len(missing) == 0 {
+        a.emitEvent("pipeline:progress", PipelineProgress{ProjectID: projectID, Step: "models", Percent: 100, Message: "Models ready"})
+        return nil
+    }
+
+    modelsJSON, err := marshalToJSON(missing)
+    if err != nil {
+        return fmt.Errorf("failed to serialize model list: %w", err)
+    }
+
+    pythonDir := a.getPythonScriptsDir()
+    cmd := a.trackedCommand(a.getPythonCommand(), filepath.Join(pythonDir, "prepare_models.py"), modelsJSON)
+    defer a.untrackCommand(cmd)
+    cmd.Dir = pythonDir
+    cmd.Env = append(os.Environ(), fmt.Sprintf("PYTHONPATH=%s", pythonDir))
+    // Models must land in the caches the project's pipeline steps will look in
+    cmd.Env = append(cmd.Env, envOverrideList(project.EnvOverrides)...)
+
+    logPath := ""
+    if projectDir, err := a.findProjectDirectory(projectID); err == nil {
+        logPath = stepLogPath(projectDir, "models")
+    }
+    output, combined, _, runErr := a.runStreamingCommand(cmd, projectID, "models", logPath)
+
+    var result struct {
+        Success bool `json:"success"`
+        Failed  []struct {
+            ID    string `json:"id"`
+            Error string `json:"error"`
+        } `json:"failed"`
+        Error string `json:"error"`
+    }
+    if err := parseTrailingJSON(output, &result); err != nil {
+        if runErr != nil {
+            return fmt.Errorf("model download failed: %v\nOutput: %s", runErr, string(combined))
+        }
+        return fmt.Errorf("failed to parse model download result: %w", err)
+    }
+    if result.Error != "" {
+        return fmt.Errorf("model download failed: %s", result.Error)
+    }
+    if !result.Success {
+        failures := make([]string, 0, len(result.Failed))
+        for _, f := range result.Failed {
+            failures = append(failures, fmt.Sprintf("%s (%s)", f.ID, f.Error))
+        }
+        return fmt.Errorf("failed to download: %s", strings.Join(failures, "; "))
+    }
+
+    return nil
+}
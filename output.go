@@ -0,0 +1,214 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path"
+    "path/filepath"
+    "regexp"
+    "runtime"
+    "strings"
+    "time"
+    "unicode/utf8"
+)
+
+// defaultOutputFilenameTemplate matches the name the combine step has always produced
+const defaultOutputFilenameTemplate = "{videoId}_final"
+
+const defaultOutputFormat = "mp4"
+
+// Container formats keep the video; audio formats skip video muxing entirely
+var videoOutputFormats = []string{"mp4", "mkv", "mov"}
+var audioOutputFormats = []string{"mp3", "wav", "flac"}
+
+var outputTemplateToken = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// renderOutputFilename expands an output filename template (without extension) for a project.
+// Supported tokens are {name}, {lang}, {date} and {videoId}; anything else is an error.
+// {date} follows the project's locale.
+func renderOutputFilename(template string, project *ProjectConfig) (string, error) {
+    if strings.TrimSpace(template) == "" {
+        template = defaultOutputFilenameTemplate
+    }
+
+    videoID := ""
+    if project.VideoId != nil {
+        videoID = *project.VideoId
+    }
+    values := map[string]string{
+        "name":    project.Name,
+        "lang":    strings.ToUpper(project.TargetLanguage),
+        "date":    time.Now().Format(localeDateLayout(project.Locale)),
+        "videoId": videoID,
+    }
+
+    // Literal text must already be filename safe; token values are sanitized
+    for _, literal := range outputTemplateToken.Split(template, -1) {
+        if strings.ContainsAny(literal, "{}") {
+            return "", fmt.Errorf("unbalanced braces in template: %s", template)
+        }
+        if strings.IndexFunc(literal, unsafeFilenameRune) >= 0 || utf8.RuneCountInString(literal) > maxFilenameRunes {
+            return "", fmt.Errorf("template contains characters not allowed in filenames: %q", literal)
+        }
+    }
+
+    var unknown []string
+    rendered := outputTemplateToken.ReplaceAllStringFunc(template, func(token string) string {
+        key := token[1 : len(token)-1]
+        value, ok := values[key]
+        if !ok {
+            unknown = append(unknown, token)
+            return ""
+        }
+        return sanitizeForFilename(value)
+    })
+    if len(unknown) > 0 {
+        return "", fmt.Errorf("unknown template tokens: %s", strings.Join(unknown, ", "))
+    }
+
+    rendered = strings.TrimSpace(rendered)
+    if rendered == "" || rendered == "." || rendered == ".." {
+        return "", fmt.Errorf("template produces an empty filename: %s", template)
+    }
+
+    return rendered, nil
+}
+
+// validateOutputFormat checks the combine output format is one the pipeline can produce
+func validateOutputFormat(format string) error {
+    format = outputFormatOrDefault(format)
+    for _, supported := range append(append([]string{}, videoOutputFormats...), audioOutputFormats...) {
+        if format == supported {
+            return nil
+        }
+    }
+    return fmt.Errorf("unsupported output format: %s (supported: %s, %s)", format,
+        strings.Join(videoOutputFormats, ", "), strings.Join(audioOutputFormats, ", "))
+}
+
+func outputFormatOrDefault(format string) string {
+    format = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(format), "."))
+    if format == "" {
+        return defaultOutputFormat
+    }
+    return format
+}
+
+// isAudioOutputFormat reports whether the format produces audio only
+func isAudioOutputFormat(format string) bool {
+    format = outputFormatOrDefault(format)
+    for _, f := range audioOutputFormats {
+        if format == f {
+            return true
+        }
+    }
+    return false
+}
+
+// SetProjectOutputDir routes a project's final outputs to dir, e.g. on a larger drive.
+// An empty dir restores the default "output" folder inside the project.
+func (a *App) SetProjectOutputDir(projectID, dir string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+
+    if strings.TrimSpace(dir) == "" {
+        project.OutputDir = nil
+        return a.UpdateProject(project)
+    }
+
+    absDir, err := filepath.Abs(dir)
+    if err != nil {
+        return fmt.Errorf("invalid output directory: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+    if err := validateOutputDir(absDir, projectInputDirs(projectDir)...); err != nil {
+        return err
+    }
+    if err := checkDirWritable(absDir); err != nil {
+        return err
+    }
+
+    project.OutputDir = &absDir
+    return a.UpdateProject(project)
+}
+
+// projectInputDirs are the project folders the pipeline reads from. Outputs written into
+// them would be picked up again as inputs on the next run.
+func projectInputDirs(projectDir string) []string {
+    return []string{
+        filepath.Join(projectDir, "input"),
+        filepath.Join(projectDir, "transcripts"),
+        filepath.Join(projectDir, "audio"),
+    }
+}
+
+// validateOutputDir rejects an output directory that is one of inputDirs or inside one
+func validateOutputDir(outputDir string, inputDirs ...string) error {
+    for _, inputDir := range inputDirs {
+        if pathWithin(inputDir, outputDir) {
+            return fmt.Errorf("output directory %s is inside the input directory %s; choose a folder outside it", outputDir, inputDir)
+        }
+    }
+    return nil
+}
+
+// pathWithin reports whether target is dir or inside it. Both / and \ count as separators,
+// since paths typed or pasted by users on Windows often mix them, and symlinks are
+// resolved as far as the paths exist. Windows paths compare case-insensitively.
+func pathWithin(dir, target string) bool {
+    dir, target = comparablePath(dir), comparablePath(target)
+    if dir == "" || target == "" {
+        return false
+    }
+    return target == dir || strings.HasPrefix(target, strings.TrimSuffix(dir, "/")+"/")
+}
+
+// comparablePath cleans a path into slash-separated form for pathWithin
+func comparablePath(p string) string {
+    if strings.TrimSpace(p) == "" {
+        return ""
+    }
+    p = resolveExistingPrefix(filepath.Clean(p))
+    p = path.Clean(strings.ReplaceAll(p, `\`, "/"))
+    if runtime.GOOS == "windows" {
+        p = strings.ToLower(p)
+    }
+    return p
+}
+
+// resolveExistingPrefix resolves symlinks in the longest part of p that exists, keeping
+// the rest (e.g. an output folder not created yet) as is
+func resolveExistingPrefix(p string) string {
+    rest := ""
+    for current := p; ; current = filepath.Dir(current) {
+        if resolved, err := filepath.EvalSymlinks(current); err == nil {
+            return filepath.Join(resolved, rest)
+        }
+        parent := filepath.Dir(current)
+        if parent == current {
+            return p
+        }
+        rest = filepath.Join(filepath.Base(current), rest)
+    }
+}
+
+// checkDirWritable creates dir if needed and confirms a file can be written in it
+func checkDirWritable(dir string) error {
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return fmt.Errorf("failed to create output directory: %w", err)
+    }
+
+    probe, err := os.CreateTemp(dir, ".write-test-*")
+    if err != nil {
+        return fmt.Errorf("output directory is not writable: %w", err)
+    }
+    probe.Close()
+    os.Remove(probe.Name())
+
+    return nil
+}
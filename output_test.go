@@ -0,0 +1,151 @@
+package main
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+func TestPathWithin(t *testing.T) {
+    root := t.TempDir()
+    join := func(parts ...string) string {
+        return filepath.Join(append([]string{root}, parts...)...)
+    }
+    backslashed := func(p string) string {
+        return strings.ReplaceAll(p, "/", `\`)
+    }
+
+    tests := []struct {
+        name   string
+        dir    string
+        target string
+        want   bool
+    }{
+        {"equal paths", join("a", "b"), join("a", "b"), true},
+        {"equal with trailing separator", join("a", "b") + "/", join("a", "b"), true},
+        {"equal after cleaning", join("a", "x", "..", "b"), join("a", "b", "."), true},
+        {"nested path", join("a", "b"), join("a", "b", "c"), true},
+        {"deeply nested path", join("a", "b"), join("a", "b", "c", "d", "e"), true},
+        {"parent is not within child", join("a", "b", "c"), join("a", "b"), false},
+        {"sibling sharing a prefix", join("a", "b"), join("a", "bc"), false},
+        {"sibling sharing a prefix, nested", join("a", "b"), join("a", "bc", "d"), false},
+        {"unrelated path", join("a", "b"), join("x", "y"), false},
+        {"empty dir", "", join("a"), false},
+        {"empty target", join("a"), "  ", false},
+        {"backslashed nested path", `C:\Media\Input`, `C:\Media\Input\Dub`, true},
+        {"backslashed equal path", `C:\Media\Input\`, `C:\Media\Input`, true},
+        {"backslashed sibling prefix", `C:\Media\Input`, `C:\Media\InputDub`, false},
+        {"mixed separators nested", `C:\Media/Input`, `C:/Media\Input\Dub`, true},
+        {"mixed separators sibling prefix", `C:/Media\In`, `C:\Media/Input`, false},
+    }
+    if filepath.Separator == '/' {
+        tests = append(tests, []struct {
+            name   string
+            dir    string
+            target string
+            want   bool
+        }{
+            {"backslashed target under slashed dir", "/media/input", backslashed("/media/input/dub"), true},
+            {"backslashed sibling under slashed dir", "/media/input", backslashed("/media/inputs"), false},
+        }...)
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := pathWithin(tt.dir, tt.target); got != tt.want {
+                t.Errorf("pathWithin(%q, %q) = %v, want %v", tt.dir, tt.target, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestPathWithinResolvesSymlinks(t *testing.T) {
+    root := t.TempDir()
+    input := filepath.Join(root, "input")
+    if err := os.Mkdir(input, 0755); err != nil {
+        t.Fatal(err)
+    }
+    link := filepath.Join(root, "link")
+    if err := os.Symlink(input, link); err != nil {
+        t.Skipf("symlinks unavailable: %v", err)
+    }
+
+    if !pathWithin(input, filepath.Join(link, "output")) {
+        t.Errorf("output under a symlink to the input directory was not detected")
+    }
+    if pathWithin(link, filepath.Join(root, "inputs")) {
+        t.Errorf("sibling of the symlink target was reported as inside it")
+    }
+}
+
+func TestValidateOutputDir(t *testing.T) {
+    root := t.TempDir()
+    videoDir := filepath.Join(root, "videos")
+    audioDir := filepath.Join(root, "audio")
+
+    tests := []struct {
+        name      string
+        outputDir string
+        inputDirs []string
+        wantErr   bool
+    }{
+        {"no input dirs", filepath.Join(root, "out"), nil, false},
+        {"outside every input", filepath.Join(root, "out"), []string{videoDir, audioDir}, false},
+        {"same as an input", videoDir, []string{videoDir}, true},
+        {"inside the second input", filepath.Join(audioDir, "dub"), []string{videoDir, audioDir}, true},
+        {"sibling sharing a prefix", videoDir + "-dubbed", []string{videoDir}, false},
+        {"empty input dir is ignored", filepath.Join(root, "out"), []string{""}, false},
+        {"backslashed inside input", `D:\clips\dub`, []string{`D:\clips`}, true},
+        {"backslashed sibling prefix", `D:\clips2`, []string{`D:\clips`}, false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := validateOutputDir(tt.outputDir, tt.inputDirs...)
+            if (err != nil) != tt.wantErr {
+                t.Errorf("validateOutputDir(%q, %q) error = %v, wantErr %v", tt.outputDir, tt.inputDirs, err, tt.wantErr)
+            }
+        })
+    }
+}
+
+func TestRenderOutputFilename(t *testing.T) {
+    videoID := "abc123"
+    project := &ProjectConfig{Name: "Talk: Part 1", TargetLanguage: "es", VideoId: &videoID}
+
+    tests := []struct {
+        template string
+        want     string
+        wantErr  bool
+    }{
+        {"", "abc123_final", false},
+        {"{videoId}_final", "abc123_final", false},
+        {"{videoId}", "abc123", false},
+        {"{name}.{lang}", "Talk- Part 1.ES", false},
+        {"{name} v2.", "Talk- Part 1 v2.", false},
+        {"dub {lang}", "dub ES", false},
+        {"東京 {lang}", "東京 ES", false},
+        {"{name}/{lang}", "", true},
+        {`{name}\{lang}`, "", true},
+        {"{name}: {lang}", "", true},
+        {"{name}?", "", true},
+        {"{name}\t{lang}", "", true},
+        {"{title}", "", true},
+        {"{name", "", true},
+        {".", "", true},
+        {strings.Repeat("x", maxFilenameRunes+1), "", true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.template, func(t *testing.T) {
+            got, err := renderOutputFilename(tt.template, project)
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("renderOutputFilename(%q) error = %v, wantErr %v", tt.template, err, tt.wantErr)
+            }
+            if got != tt.want {
+                t.Errorf("renderOutputFilename(%q) = %q, want %q", tt.template, got, tt.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "sort"
+)
+
+// errPipelineCancelled is returned by pipeline work stopped with CancelProject or CancelAll
+var errPipelineCancelled = errors.New("pipeline cancelled")
+
+// projectRun is the shared cancellation scope of a project's in-flight pipeline work. A full
+// run and the step it is running share one scope, so cancelling stops both.
+type projectRun struct {
+    ctx    context.Context
+    cancel context.CancelFunc
+    refs   int
+}
+
+// beginProjectRun joins (or opens) the project's cancellation scope. The returned function
+// must be called when the work is done.
+func (a *App) beginProjectRun(projectID string) (context.Context, func()) {
+    a.projectRunsMu.Lock()
+    defer a.projectRunsMu.Unlock()
+
+    if a.projectRuns == nil {
+        a.projectRuns = make(map[string]*projectRun)
+    }
+    run, ok := a.projectRuns[projectID]
+    if !ok {
+        ctx, cancel := context.WithCancel(a.runCtx)
+        run = &projectRun{ctx: ctx, cancel: cancel}
+        a.projectRuns[projectID] = run
+    }
+    run.refs++
+
+    return run.ctx, func() {
+        a.projectRunsMu.Lock()
+        defer a.projectRunsMu.Unlock()
+        run.refs--
+        if run.refs == 0 {
+            run.cancel()
+            if a.projectRuns[projectID] == run {
+                delete(a.projectRuns, projectID)
+            }
+        }
+    }
+}
+
+// CancelProject stops the project's running or waiting pipeline work
+func (a *App) CancelProject(projectID string) error {
+    a.projectRunsMu.Lock()
+    run, ok := a.projectRuns[projectID]
+    if ok {
+        // Detach it so a new run started afterwards isn't born cancelled
+        delete(a.projectRuns, projectID)
+    }
+    a.projectRunsMu.Unlock()
+
+    if ok {
+        run.cancel()
+    }
+    return nil
+}
+
+// CancelAll stops every project's pipeline work, both steps waiting for a free job slot and
+// steps already running, and emits "queue:cancelled" with the affected project IDs
+func (a *App) CancelAll() error {
+    a.projectRunsMu.Lock()
+    cancelled := make([]string, 0, len(a.projectRuns))
+    runs := make([]*projectRun, 0, len(a.projectRuns))
+    for projectID, run := range a.projectRuns {
+        cancelled = append(cancelled, projectID)
+        runs = append(runs, run)
+    }
+    clear(a.projectRuns)
+    a.projectRunsMu.Unlock()
+
+    for _, run := range runs {
+        run.cancel()
+    }
+
+    sort.Strings(cancelled)
+    a.emitEvent("queue:cancelled", cancelled)
+    return nil
+}
+
+// resetCancelledStep marks a step that was stopped part way, and every step after it, as
+// not completed: its outputs may be half-written and later outputs no longer match them
+func (a *App) resetCancelledStep(projectID, step string) error {
+    if err := a.markStepIncomplete(projectID, step); err != nil {
+        return err
+    }
+    return a.invalidateStepsAfter(projectID, step)
+}
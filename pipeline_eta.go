@@ -0,0 +1,325 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// ETAReport estimates how long a running pipeline has left. It is also the "pipeline:eta" payload.
+type ETAReport struct {
+    ProjectID            string   `json:"projectId"`
+    Step                 string   `json:"step"`
+    StepPercent          float64  `json:"stepPercent"`
+    StepRemainingSeconds float64  `json:"stepRemainingSeconds"`
+    RemainingSeconds     float64  `json:"remainingSeconds"`
+    RemainingSteps       []string `json:"remainingSteps"`
+    // Basis is "history" when past runs were used, otherwise "heuristic"
+    Basis string `json:"basis"`
+}
+
+// stepTimingSample is one finished step: how long it took for how much media
+type stepTimingSample struct {
+    Seconds      float64 `json:"seconds"`
+    MediaSeconds float64 `json:"mediaSeconds"`
+}
+
+const (
+    // stepTimingHistory is how many recent runs of each step are kept
+    stepTimingHistory = 20
+    // etaInterval is how often "pipeline:eta" is emitted during a run
+    etaInterval = 5 * time.Second
+    // assumedMediaSeconds stands in when the source's duration can't be probed
+    assumedMediaSeconds = 600
+    // progressExtrapolationMin is the step progress (percent) from which elapsed time
+    // predicts the rest of the step better than history does
+    progressExtrapolationMin = 5
+)
+
+// heuristicStepRates are rough seconds of work per second of media for a first run
+var heuristicStepRates = map[string]float64{
+    "download":   0.2,
+    "import":     0.05,
+    "transcribe": 0.5,
+    "translate":  0.1,
+    "synthesize": 1.0,
+    "combine":    0.2,
+}
+
+// etaRun is the state of a project's running pipeline as far as the ETA is concerned
+type etaRun struct {
+    steps        []string
+    step         string
+    stepStarted  time.Time
+    percent      float64
+    mediaSeconds float64
+    // mediaKnown is false while mediaSeconds is assumedMediaSeconds, e.g. before download
+    mediaKnown bool
+    stop       chan struct{}
+}
+
+// trackETA starts estimating a run of steps, emitting "pipeline:eta" until the returned
+// function is called. Inside a run that is already tracked it does nothing.
+func (a *App) trackETA(projectID string, steps []string) func() {
+    a.etaRunsMu.Lock()
+    if a.etaRuns == nil {
+        a.etaRuns = make(map[string]*etaRun)
+    }
+    if _, ok := a.etaRuns[projectID]; ok {
+        a.etaRunsMu.Unlock()
+        return func() {}
+    }
+    run := &etaRun{steps: append([]string{}, steps...), mediaSeconds: assumedMediaSeconds, stop: make(chan struct{})}
+    a.etaRuns[projectID] = run
+    a.etaRunsMu.Unlock()
+
+    a.refreshETAMedia(projectID)
+
+    go func() {
+        ticker := time.NewTicker(etaInterval)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-ticker.C:
+                if report, err := a.GetPipelineETA(projectID); err == nil {
+                    a.emitEvent("pipeline:eta", report)
+                }
+            case <-run.stop:
+                return
+            }
+        }
+    }()
+
+    return func() {
+        a.etaRunsMu.Lock()
+        if a.etaRuns[projectID] == run {
+            delete(a.etaRuns, projectID)
+        }
+        a.etaRunsMu.Unlock()
+        close(run.stop)
+    }
+}
+
+// refreshETAMedia probes the run's media length until it is known; a URL source only has
+// one after the download step
+func (a *App) refreshETAMedia(projectID string) {
+    a.etaRunsMu.Lock()
+    run, ok := a.etaRuns[projectID]
+    known := ok && run.mediaKnown
+    a.etaRunsMu.Unlock()
+    if !ok || known {
+        return
+    }
+
+    seconds, ok := a.projectMediaSeconds(projectID)
+    if !ok {
+        return
+    }
+    a.etaRunsMu.Lock()
+    run.mediaSeconds = seconds
+    run.mediaKnown = true
+    a.etaRunsMu.Unlock()
+}
+
+// etaStepStarted marks step as the one now running
+func (a *App) etaStepStarted(projectID, step string) {
+    a.etaRunsMu.Lock()
+    defer a.etaRunsMu.Unlock()
+    if run, ok := a.etaRuns[projectID]; ok {
+        run.step = step
+        run.stepStarted = time.Now()
+        run.percent = 0
+    }
+}
+
+// etaStepProgress records the running step's reported progress
+func (a *App) etaStepProgress(projectID, step string, percent float64) {
+    a.etaRunsMu.Lock()
+    defer a.etaRunsMu.Unlock()
+    if run, ok := a.etaRuns[projectID]; ok && run.step == step {
+        run.percent = percent
+    }
+}
+
+// etaStepFinished drops a step from the run's remaining steps
+func (a *App) etaStepFinished(projectID, step string) {
+    a.etaRunsMu.Lock()
+    defer a.etaRunsMu.Unlock()
+    run, ok := a.etaRuns[projectID]
+    if !ok {
+        return
+    }
+    run.steps = append([]string{}, run.steps...)
+    for i, s := range run.steps {
+        if s == step {
+            run.steps = append(run.steps[:i], run.steps[i+1:]...)
+            break
+        }
+    }
+    if run.step == step {
+        run.step = ""
+        run.percent = 0
+    }
+}
+
+// GetPipelineETA estimates the time left in the project's running pipeline from its
+// current step's progress and how long each step took in earlier runs, falling back to
+// rough per-step rates when there is no history
+func (a *App) GetPipelineETA(projectID string) (ETAReport, error) {
+    a.etaRunsMu.Lock()
+    run, ok := a.etaRuns[projectID]
+    var snapshot etaRun
+    if ok {
+        snapshot = *run
+        snapshot.steps = append([]string{}, run.steps...)
+    }
+    a.etaRunsMu.Unlock()
+    if !ok {
+        return ETAReport{}, fmt.Errorf("no pipeline is running for this project")
+    }
+
+    timings := a.loadStepTimings()
+    report := ETAReport{
+        ProjectID:      projectID,
+        Step:           snapshot.step,
+        StepPercent:    snapshot.percent,
+        RemainingSteps: []string{},
+        Basis:          "history",
+    }
+
+    for _, step := range snapshot.steps {
+        expected, fromHistory := expectedStepSeconds(timings, step, snapshot.mediaSeconds)
+        if !fromHistory {
+            report.Basis = "heuristic"
+        }
+
+        if step != snapshot.step {
+            report.RemainingSteps = append(report.RemainingSteps, step)
+            report.RemainingSeconds += expected
+            continue
+        }
+
+        elapsed := time.Since(snapshot.stepStarted).Seconds()
+        remaining := expected - elapsed
+        if snapshot.percent >= progressExtrapolationMin && snapshot.percent < 100 {
+            remaining = elapsed/snapshot.percent*100 - elapsed
+        }
+        report.StepRemainingSeconds = max(remaining, 0)
+        report.RemainingSeconds += report.StepRemainingSeconds
+    }
+
+    return report, nil
+}
+
+// expectedStepSeconds predicts a step's duration for mediaSeconds of media from the
+// average rate of its recorded runs, or the heuristic rate without any
+func expectedStepSeconds(timings map[string][]stepTimingSample, step string, mediaSeconds float64) (float64, bool) {
+    var seconds, media float64
+    for _, sample := range timings[step] {
+        seconds += sample.Seconds
+        media += sample.MediaSeconds
+    }
+    if media > 0 {
+        return seconds / media * mediaSeconds, true
+    }
+    return heuristicStepRates[step] * mediaSeconds, false
+}
+
+// projectMediaSeconds is the length of media the pipeline works on: the trimmed clip when
+// there is one, otherwise the source
+func (a *App) projectMediaSeconds(projectID string) (float64, bool) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return 0, false
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return 0, false
+    }
+
+    path := ""
+    if trimmed := project.FileReferences.TrimmedFile; trimmed != nil {
+        path = filepath.Join(projectDir, *trimmed)
+    } else if source := sourceFileReference(project); source != nil {
+        path = resolveFileReferencePath(projectDir, source)
+    }
+    if path == "" || !fileExists(path) {
+        return 0, false
+    }
+
+    duration, ok, err := a.probeMediaDuration(path)
+    if err != nil || !ok || duration <= 0 {
+        return 0, false
+    }
+    return duration, true
+}
+
+// stepTimingsPath keeps the timing history beside settings.json, since it spans projects
+func (a *App) stepTimingsPath() (string, error) {
+    settingsPath, err := a.getSettingsPath()
+    if err != nil {
+        return "", err
+    }
+    return filepath.Join(filepath.Dir(settingsPath), "step-timings.json"), nil
+}
+
+func (a *App) loadStepTimings() map[string][]stepTimingSample {
+    timings := make(map[string][]stepTimingSample)
+    path, err := a.stepTimingsPath()
+    if err != nil {
+        return timings
+    }
+    if data, err := os.ReadFile(path); err == nil {
+        json.Unmarshal(data, &timings)
+    }
+    return timings
+}
+
+// recordStepTiming adds a finished step to the timing history. Runs served from the step
+// cache aren't recorded since they didn't do the work, nor are runs of unknown length.
+func (a *App) recordStepTiming(projectID, step string, seconds float64) {
+    a.refreshETAMedia(projectID)
+    a.etaRunsMu.Lock()
+    run, ok := a.etaRuns[projectID]
+    known := ok && run.mediaKnown
+    var mediaSeconds float64
+    if known {
+        mediaSeconds = run.mediaSeconds
+    }
+    a.etaRunsMu.Unlock()
+    if !known {
+        return
+    }
+
+    path, err := a.stepTimingsPath()
+    if err != nil {
+        return
+    }
+
+    a.stepTimingsMu.Lock()
+    defer a.stepTimingsMu.Unlock()
+
+    timings := a.loadStepTimings()
+    samples := append(timings[step], stepTimingSample{Seconds: seconds, MediaSeconds: mediaSeconds})
+    if len(samples) > stepTimingHistory {
+        samples = samples[len(samples)-stepTimingHistory:]
+    }
+    timings[step] = samples
+
+    data, err := json.MarshalIndent(timings, "", "  ")
+    if err != nil {
+        return
+    }
+    if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+        return
+    }
+    tmpPath := path + ".tmp"
+    if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+        return
+    }
+    if err := os.Rename(tmpPath, path); err != nil {
+        os.Remove(tmpPath)
+    }
+}
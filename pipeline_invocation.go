@@ -0,0 +1,40 @@
+package main
+
+import (
+    "os"
+    "path/filepath"
+)
+
+// dubbingPipelineScript is the standalone full-pipeline script
+const dubbingPipelineScript = "dubbing_pipeline.py"
+
+// PipelineInvocation is everything dubbing_pipeline.py is called with. It is the only place
+// that knows the script's command line, so a CLI change on the Python side is a change here.
+type PipelineInvocation struct {
+    // ScriptPath is the absolute path of dubbing_pipeline.py
+    ScriptPath string
+    // ConfigPath is the run configuration file written by writePipelineRunConfig
+    ConfigPath string
+}
+
+// newPipelineInvocation writes the run configuration for config and returns the invocation
+// that runs it. The caller must call cleanup once the script has finished.
+func newPipelineInvocation(pythonDir string, config PipelineConfig, outputDir string) (PipelineInvocation, func(), error) {
+    configPath, err := writePipelineRunConfig(config, outputDir)
+    if err != nil {
+        return PipelineInvocation{}, func() {}, err
+    }
+
+    invocation := PipelineInvocation{
+        ScriptPath: filepath.Join(pythonDir, dubbingPipelineScript),
+        ConfigPath: configPath,
+    }
+    return invocation, func() { os.Remove(configPath) }, nil
+}
+
+// Args returns the interpreter arguments for the invocation:
+//
+//     dubbing_pipeline.py --config <run_config.json>
+func (p PipelineInvocation) Args() []string {
+    return []string{p.ScriptPath, "--config", p.ConfigPath}
+}
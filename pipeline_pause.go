@@ -0,0 +1,81 @@
+package main
+
+import (
+    "fmt"
+)
+
+// PipelinePaused is the payload of a "pipeline:paused" event
+type PipelinePaused struct {
+    ProjectID string `json:"projectId"`
+    Step      string `json:"step"`
+}
+
+// ResumePipeline continues a full pipeline run that stopped at one of the project's
+// PauseAfterStep gates, starting with the step after the one it paused on
+func (a *App) ResumePipeline(projectID string) (map[string]interface{}, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load project: %w", err)
+    }
+    if project.PausedAfter == nil {
+        return nil, fmt.Errorf("pipeline is not paused")
+    }
+
+    for i, step := range projectPipelineSteps(project) {
+        if sameStep(step, *project.PausedAfter) {
+            return a.runPipelineFrom(projectID, i+1)
+        }
+    }
+
+    return nil, fmt.Errorf("pipeline paused after unknown step: %s", *project.PausedAfter)
+}
+
+// pausesAfterStep reports whether the project has an approval gate after step
+func (a *App) pausesAfterStep(projectID, step string) bool {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return false
+    }
+    for _, s := range project.PauseAfterStep {
+        if sameStep(s, step) {
+            return true
+        }
+    }
+    return false
+}
+
+// setPipelinePaused records (or, with nil, clears) the step a pipeline is paused after
+func (a *App) setPipelinePaused(projectID string, step *string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    if project.PausedAfter == nil && step == nil {
+        return nil
+    }
+
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+
+    project.PausedAfter = step
+    return a.saveProjectConfig(projectDir, project)
+}
+
+// validatePauseSteps checks that every pause gate names a pipeline step
+func validatePauseSteps(steps []string) error {
+    for _, step := range steps {
+        valid := false
+        for _, s := range localPipelineSteps {
+            if sameStep(s, step) {
+                valid = true
+                break
+            }
+        }
+        if !valid {
+            return fmt.Errorf("invalid pause step: %s", step)
+        }
+    }
+    return nil
+}
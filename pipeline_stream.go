@@ -0,0 +1,391 @@
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "io"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "strings"
+    "sync"
+    "time"
+
+    wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Progress protocol
+//
+// Python scripts report progress by printing a single line to stdout of the form
+//
+//     PROGRESS {"step": "transcribe", "percent": 42.5, "message": "Aligning words"}
+//
+// The prefix must start the line and the payload must be one line of JSON. percent runs
+// from 0 to 100. Such lines are emitted to the frontend as "pipeline:progress" events and
+// are not part of the step's output. Every other line is emitted as a "pipeline:log" event
+// if the log level shows it, except lines ended by a bare carriage return (progress bars redrawn in place), which are
+// emitted as "pipeline:progress-line" events and not logged.
+const progressLinePrefix = "PROGRESS "
+
+// PipelineProgress is the payload of a "pipeline:progress" event
+type PipelineProgress struct {
+    ProjectID string  `json:"projectId"`
+    Step      string  `json:"step"`
+    Percent   float64 `json:"percent"`
+    Message   string  `json:"message"`
+}
+
+// progressLineInterval limits how often a redrawn progress line is sent to the frontend
+const progressLineInterval = 100 * time.Millisecond
+
+// progressLineEmitter turns carriage-return redraws into throttled "pipeline:progress-line"
+// events: one updating line per stream rather than a log entry per redraw
+type progressLineEmitter struct {
+    app       *App
+    projectID string
+    step      string
+    mu        sync.Mutex
+    last      map[string]time.Time
+    // download, when set, also turns yt-dlp progress into "download:progress" events
+    download  *downloadProgressEmitter
+}
+
+func (e *progressLineEmitter) observeDownload(line string) {
+    if e.download != nil {
+        e.download.observe(line)
+    }
+}
+
+func (e *progressLineEmitter) updater(stream string) func(line string) {
+    return func(line string) {
+        e.observeDownload(line)
+
+        e.mu.Lock()
+        if e.last == nil {
+            e.last = make(map[string]time.Time)
+        }
+        now := time.Now()
+        if now.Sub(e.last[stream]) < progressLineInterval {
+            e.mu.Unlock()
+            return
+        }
+        e.last[stream] = now
+        e.mu.Unlock()
+
+        e.app.emitEvent("pipeline:progress-line", PipelineLogLine{ProjectID: e.projectID, Step: e.step, Stream: stream, Line: line})
+    }
+}
+
+// PipelineLogLine is the payload of a "pipeline:log" event
+type PipelineLogLine struct {
+    ProjectID string `json:"projectId"`
+    Step      string `json:"step"`
+    Stream    string `json:"stream"`
+    Line      string `json:"line"`
+}
+
+// maxRetainedOutput caps how much of a command's output is kept in memory. A runaway
+// script can print gigabytes; only the tail is kept for results and error messages, while
+// the full output still goes to the log file.
+const maxRetainedOutput = 512 * 1024
+
+// maxLineLength caps a single output line; the rest of an over-long line is dropped
+const maxLineLength = 64 * 1024
+
+// runStreamingCommand runs cmd, emitting its output line by line as it arrives and writing
+// all of it to logPath (if given). It returns the tail of stdout (minus progress and warning
+// lines), the tail of the combined output for error reporting, and the warnings reported.
+// Only lines at or above the configured log level are emitted, see log_level.go.
+func (a *App) runStreamingCommand(cmd *exec.Cmd, projectID, step, logPath string) ([]byte, []byte, []PipelineWarning, error) {
+    logLevel := a.currentLogLevel()
+    if cmd.Env == nil {
+        cmd.Env = os.Environ()
+    }
+    cmd.Env = append(cmd.Env, fmt.Sprintf("KOKORO_LOG_LEVEL=%s", logLevel))
+    emitLog := func(stream, line string) {
+        if emitsLine(logLevel, lineSeverity(line)) {
+            a.emitEvent("pipeline:log", PipelineLogLine{ProjectID: projectID, Step: step, Stream: stream, Line: line})
+        }
+    }
+
+    stdoutPipe, err := cmd.StdoutPipe()
+    if err != nil {
+        return nil, nil, nil, err
+    }
+    stderrPipe, err := cmd.StderrPipe()
+    if err != nil {
+        return nil, nil, nil, err
+    }
+
+    // Logging is best-effort; a missing log never fails the step
+    var logFile *os.File
+    if logPath != "" {
+        if err := os.MkdirAll(filepath.Dir(logPath), 0755); err == nil {
+            logFile, err = os.Create(logPath)
+            if err != nil {
+                fmt.Printf("Warning: failed to create step log: %v\n", err)
+            }
+        }
+    }
+    if logFile != nil {
+        defer logFile.Close()
+    }
+
+    if err := a.startCommand(cmd); err != nil {
+        return nil, nil, nil, err
+    }
+
+    var mu sync.Mutex
+    var warnings []PipelineWarning
+    stdout := newTailBuffer(maxRetainedOutput)
+    combined := newTailBuffer(maxRetainedOutput)
+    record := func(line string, toStdout bool) {
+        mu.Lock()
+        defer mu.Unlock()
+        if toStdout {
+            stdout.WriteString(line + "\n")
+        }
+        combined.WriteString(line + "\n")
+        if logFile != nil {
+            logFile.WriteString(line + "\n")
+        }
+    }
+
+    progressLines := &progressLineEmitter{app: a, projectID: projectID, step: step}
+    // yt-dlp redraws its progress with "\r" but prints it line by line under --newline
+    if step == "download" {
+        progressLines.download = &downloadProgressEmitter{app: a, projectID: projectID}
+    }
+
+    // Scripts may redraw PROGRESS lines in place with "\r" as well as print them line by line
+    emitProgress := func(line string) bool {
+        progress, ok := parseProgressLine(line)
+        if !ok {
+            return false
+        }
+        progress.ProjectID = projectID
+        if progress.Step == "" {
+            progress.Step = step
+        }
+        a.etaStepProgress(projectID, progress.Step, progress.Percent)
+        a.emitEvent("pipeline:progress", progress)
+        return true
+    }
+    stdoutUpdate := progressLines.updater("stdout")
+
+    var wg sync.WaitGroup
+    wg.Add(2)
+    go func() {
+        defer wg.Done()
+        scanLines(stdoutPipe, func(line string) {
+            if emitProgress(line) {
+                return
+            }
+            if warning, ok := parseWarningLine(line); ok {
+                warning.ProjectID = projectID
+                warning.Step = step
+                record(line, false)
+                mu.Lock()
+                warnings = append(warnings, warning)
+                mu.Unlock()
+                a.emitEvent("pipeline:warning", warning)
+                return
+            }
+
+            progressLines.observeDownload(line)
+            record(line, true)
+            emitLog("stdout", line)
+        }, func(line string) {
+            if !emitProgress(line) {
+                stdoutUpdate(line)
+            }
+        })
+    }()
+    go func() {
+        defer wg.Done()
+        scanLines(stderrPipe, func(line string) {
+            progressLines.observeDownload(line)
+            record(line, false)
+            emitLog("stderr", line)
+        }, progressLines.updater("stderr"))
+    }()
+
+    // Pipes must be drained before Wait closes them
+    wg.Wait()
+    err = cmd.Wait()
+
+    return stdout.Bytes(), combined.Bytes(), warnings, err
+}
+
+// scanLines calls handle for every line read from r and update for every carriage-return
+// terminated line, which is how tools like yt-dlp and ffmpeg redraw a progress bar in place.
+// "\r\n" counts as an ordinary line ending. Lines longer than maxLineLength are cut short
+// rather than buffered whole.
+func scanLines(r io.Reader, handle func(line string), update func(line string)) {
+    var line []byte
+    truncated := false
+    pendingCR := false
+
+    flush := func(asUpdate bool) {
+        if truncated {
+            line = append(line, " [line truncated]"...)
+        }
+        if asUpdate {
+            if len(line) > 0 {
+                update(string(line))
+            }
+        } else {
+            handle(string(line))
+        }
+        line = line[:0]
+        truncated = false
+    }
+    appendBytes := func(b []byte) {
+        room := maxLineLength - len(line)
+        if len(b) > room {
+            b = b[:max(room, 0)]
+            truncated = true
+        }
+        line = append(line, b...)
+    }
+
+    chunk := make([]byte, 64*1024)
+    for {
+        n, err := r.Read(chunk)
+        data := chunk[:n]
+        for len(data) > 0 {
+            // A "\r" is only an in-place update if it isn't the start of "\r\n"
+            if pendingCR {
+                pendingCR = false
+                if data[0] == '\n' {
+                    flush(false)
+                    data = data[1:]
+                    continue
+                }
+                flush(true)
+            }
+
+            i := bytes.IndexAny(data, "\r\n")
+            if i < 0 {
+                appendBytes(data)
+                break
+            }
+            appendBytes(data[:i])
+            if data[i] == '\r' {
+                pendingCR = true
+            } else {
+                flush(false)
+            }
+            data = data[i+1:]
+        }
+
+        if err != nil {
+            // Whatever is left is the final state of the output; a trailing "\r" after a
+            // line that was already flushed leaves nothing to report
+            if len(line) > 0 || truncated {
+                flush(false)
+            }
+            return
+        }
+    }
+}
+
+// tailBuffer keeps the last max bytes written to it
+type tailBuffer struct {
+    max     int
+    buf     []byte
+    dropped int64
+}
+
+func newTailBuffer(max int) *tailBuffer {
+    return &tailBuffer{max: max}
+}
+
+func (t *tailBuffer) WriteString(s string) {
+    t.buf = append(t.buf, s...)
+    // Compact only once twice the limit has built up, so trimming stays cheap per write
+    if len(t.buf) > 2*t.max {
+        t.trim()
+    }
+}
+
+// trim drops the oldest output beyond max
+func (t *tailBuffer) trim() {
+    if over := len(t.buf) - t.max; over > 0 {
+        // Drop whole lines so the kept output starts cleanly
+        cut := over
+        if i := bytes.IndexByte(t.buf[over:], '\n'); i >= 0 {
+            cut = over + i + 1
+        }
+        t.dropped += int64(cut)
+        t.buf = append(t.buf[:0], t.buf[cut:]...)
+    }
+}
+
+// Bytes returns the kept output, noting how much was dropped before it
+func (t *tailBuffer) Bytes() []byte {
+    t.trim()
+    if t.dropped == 0 {
+        return t.buf
+    }
+    return append([]byte(fmt.Sprintf("[%d earlier bytes of output omitted]\n", t.dropped)), t.buf...)
+}
+
+// parseProgressLine decodes a progress protocol line
+func parseProgressLine(line string) (PipelineProgress, bool) {
+    var progress PipelineProgress
+    if !strings.HasPrefix(line, progressLinePrefix) {
+        return progress, false
+    }
+    if err := json.Unmarshal([]byte(strings.TrimPrefix(line, progressLinePrefix)), &progress); err != nil {
+        return progress, false
+    }
+    progress.Percent = min(max(progress.Percent, 0), 100)
+    return progress, true
+}
+
+// parseTrailingJSON decodes the JSON object a script prints last, ignoring any log
+// output printed before it
+func parseTrailingJSON(output []byte, v interface{}) error {
+    trimmed := bytes.TrimSpace(output)
+    err := json.Unmarshal(trimmed, v)
+    if err == nil {
+        return nil
+    }
+
+    // Walk back through lines that open an object until one parses
+    for i := len(trimmed) - 1; i >= 0; i-- {
+        if trimmed[i] != '{' || (i > 0 && trimmed[i-1] != '\n') {
+            continue
+        }
+        if json.Unmarshal(trimmed[i:], v) == nil {
+            return nil
+        }
+    }
+
+    return fmt.Errorf("no JSON result found: %w", err)
+}
+
+// emitEvent sends an event to the frontend once the app has started, or to the
+// headless event sink when running without a window
+func (a *App) emitEvent(name string, data ...interface{}) {
+    if a.eventSink != nil {
+        a.eventSink(name, data...)
+        return
+    }
+    if a.ctx == nil {
+        return
+    }
+    wailsruntime.EventsEmit(a.ctx, name, data...)
+}
+
+// emitWarning reports a problem the user should know about as an "app:warning" event. With
+// neither a window nor a headless sink to show it yet, it goes to stdout instead.
+func (a *App) emitWarning(message string) {
+    if a.eventSink == nil && a.ctx == nil {
+        fmt.Println("Warning:", message)
+        return
+    }
+    a.emitEvent("app:warning", message)
+}
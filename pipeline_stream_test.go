@@ -0,0 +1,163 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "io"
+    "os/exec"
+    "strings"
+    "sync"
+    "testing"
+)
+
+func TestTailBufferKeepsTailOfHugeOutput(t *testing.T) {
+    tail := newTailBuffer(maxRetainedOutput)
+    line := strings.Repeat("x", 99) + "\n"
+    total := 0
+    for total < 8*maxRetainedOutput {
+        tail.WriteString(line)
+        total += len(line)
+    }
+    tail.WriteString("last line\n")
+    total += len("last line\n")
+
+    out := tail.Bytes()
+    header, kept, ok := bytes.Cut(out, []byte("\n"))
+    if !ok || !bytes.HasPrefix(header, []byte("[")) || !bytes.Contains(header, []byte("earlier bytes of output omitted")) {
+        t.Fatalf("missing omitted-output header, got %q", header)
+    }
+    if len(kept) > maxRetainedOutput {
+        t.Errorf("kept %d bytes, want at most %d", len(kept), maxRetainedOutput)
+    }
+    if !bytes.HasSuffix(kept, []byte("last line\n")) {
+        t.Errorf("kept output does not end with the last line written")
+    }
+    if kept[0] != 'x' || len(kept)%len(line) != len("last line\n") {
+        t.Errorf("kept output does not start on a line boundary")
+    }
+    if int(tail.dropped)+len(kept) != total {
+        t.Errorf("dropped %d + kept %d bytes, want %d written", tail.dropped, len(kept), total)
+    }
+}
+
+func TestTailBufferUnderLimitIsUnchanged(t *testing.T) {
+    tail := newTailBuffer(maxRetainedOutput)
+    tail.WriteString("one\n")
+    tail.WriteString("two\n")
+    if got := string(tail.Bytes()); got != "one\ntwo\n" {
+        t.Errorf("Bytes() = %q, want %q", got, "one\ntwo\n")
+    }
+}
+
+// chunkedReader returns at most size bytes per Read, so lines span several reads
+type chunkedReader struct {
+    r    io.Reader
+    size int
+}
+
+func (c chunkedReader) Read(p []byte) (int, error) {
+    if len(p) > c.size {
+        p = p[:c.size]
+    }
+    return c.r.Read(p)
+}
+
+func TestScanLinesTruncatesOverLongLines(t *testing.T) {
+    long := strings.Repeat("a", 3*maxLineLength)
+    input := "short\n" + long + "\nafter\r\nprogress 1\rprogress 2\rdone"
+
+    for _, size := range []int{1 << 20, 4096, 7} {
+        var lines, updates []string
+        scanLines(chunkedReader{strings.NewReader(input), size},
+            func(line string) { lines = append(lines, line) },
+            func(line string) { updates = append(updates, line) })
+
+        if len(lines) != 4 {
+            t.Fatalf("chunk %d: got %d lines, want 4", size, len(lines))
+        }
+        if lines[0] != "short" || lines[2] != "after" || lines[3] != "done" {
+            t.Errorf("chunk %d: unexpected lines %q, %q, %q", size, lines[0], lines[2], lines[3])
+        }
+        want := long[:maxLineLength] + " [line truncated]"
+        if lines[1] != want {
+            t.Errorf("chunk %d: long line has %d bytes, want %d ending in the truncation marker", size, len(lines[1]), len(want))
+        }
+        if strings.Join(updates, "|") != "progress 1|progress 2" {
+            t.Errorf("chunk %d: updates = %q", size, updates)
+        }
+    }
+}
+
+func TestScanLinesLineOfExactlyMaxLength(t *testing.T) {
+    exact := strings.Repeat("b", maxLineLength)
+    var lines []string
+    scanLines(strings.NewReader(exact+"\n"), func(line string) { lines = append(lines, line) }, func(string) {})
+    if len(lines) != 1 || lines[0] != exact {
+        t.Errorf("line of exactly maxLineLength bytes was altered")
+    }
+}
+
+func TestScanLinesTrailingCarriageReturn(t *testing.T) {
+    tests := []struct {
+        input       string
+        wantLines   []string
+        wantUpdates []string
+    }{
+        {"done\n\r", []string{"done"}, nil},
+        {"\r", nil, nil},
+        {"50%\r100%\r", []string{"100%"}, []string{"50%"}},
+        {"50%\r\r", nil, []string{"50%"}},
+    }
+
+    for _, tt := range tests {
+        var lines, updates []string
+        scanLines(strings.NewReader(tt.input),
+            func(line string) { lines = append(lines, line) },
+            func(line string) { updates = append(updates, line) })
+        if strings.Join(lines, "|") != strings.Join(tt.wantLines, "|") {
+            t.Errorf("%q: lines = %q, want %q", tt.input, lines, tt.wantLines)
+        }
+        if strings.Join(updates, "|") != strings.Join(tt.wantUpdates, "|") {
+            t.Errorf("%q: updates = %q, want %q", tt.input, updates, tt.wantUpdates)
+        }
+    }
+}
+
+func TestRunStreamingCommandParsesRedrawnProgressLines(t *testing.T) {
+    if _, err := exec.LookPath("sh"); err != nil {
+        t.Skip("sh not available")
+    }
+    app, _ := newTestApp(t)
+
+    var mu sync.Mutex
+    var percents []float64
+    var logged []string
+    app.eventSink = func(name string, data ...interface{}) {
+        mu.Lock()
+        defer mu.Unlock()
+        switch name {
+        case "pipeline:progress":
+            percents = append(percents, data[0].(PipelineProgress).Percent)
+        case "pipeline:log", "pipeline:progress-line":
+            logged = append(logged, data[0].(PipelineLogLine).Line)
+        }
+    }
+
+    script := `printf 'PROGRESS {"percent": 25}\rPROGRESS {"percent": 50}\rPROGRESS {"percent": 75}\nresult\n\r'`
+    stdout, _, _, err := app.runStreamingCommand(exec.Command("sh", "-c", script), "p1", "translate", "")
+    if err != nil {
+        t.Fatalf("runStreamingCommand: %v", err)
+    }
+
+    if got := fmt.Sprint(percents); got != "[25 50 75]" {
+        t.Errorf("progress percents = %s, want [25 50 75]", got)
+    }
+    for _, line := range logged {
+        if strings.HasPrefix(line, progressLinePrefix) || line == "" {
+            t.Errorf("progress or empty line leaked into the log: %q", line)
+        }
+    }
+    if string(stdout) != "result\n" {
+        t.Errorf("stdout = %q, want %q", stdout, "result\n")
+    }
+}
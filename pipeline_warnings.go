@@ -0,0 +1,52 @@
+package main
+
+import (
+    "encoding/json"
+    "strings"
+)
+
+// warningLinePrefix marks a soft-problem line in a script's stdout, see util/pipeline_warnings.py
+const warningLinePrefix = "WARNING "
+
+// PipelineWarning is a problem that didn't fail a step but that the user should know about,
+// such as a segment that couldn't be synthesized. It is also the "pipeline:warning" payload.
+type PipelineWarning struct {
+    ProjectID string `json:"projectId"`
+    Step      string `json:"step"`
+    Message   string `json:"message"`
+    Code      string `json:"code,omitempty"`
+    Segment   *int   `json:"segment,omitempty"`
+}
+
+// parseWarningLine decodes a warning protocol line
+func parseWarningLine(line string) (PipelineWarning, bool) {
+    var warning PipelineWarning
+    if !strings.HasPrefix(line, warningLinePrefix) {
+        return warning, false
+    }
+    if err := json.Unmarshal([]byte(strings.TrimPrefix(line, warningLinePrefix)), &warning); err != nil || warning.Message == "" {
+        return warning, false
+    }
+    return warning, true
+}
+
+// stepWarnings returns the warnings recorded in a step result. Results replayed from the
+// step cache have been through JSON, so the slice is decoded rather than type-asserted.
+func stepWarnings(result map[string]interface{}) []PipelineWarning {
+    raw, ok := result["warnings"]
+    if !ok || raw == nil {
+        return nil
+    }
+    if warnings, ok := raw.([]PipelineWarning); ok {
+        return warnings
+    }
+    data, err := json.Marshal(raw)
+    if err != nil {
+        return nil
+    }
+    var warnings []PipelineWarning
+    if err := json.Unmarshal(data, &warnings); err != nil {
+        return nil
+    }
+    return warnings
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "net/url"
+    "regexp"
+    "strings"
+)
+
+// URL sources keep the "youtube" source type they've always had, whatever site they come
+// from; ProjectConfig.Platform says which site it is. yt-dlp does the actual download, so
+// any site it supports works, the known ones just get readable IDs.
+
+// genericPlatform is a URL from a site detectPlatform doesn't know
+const genericPlatform = "generic"
+
+// platformHosts maps a host (without "www.") to its platform
+var platformHosts = map[string]string{
+    "youtube.com":       "youtube",
+    "m.youtube.com":     "youtube",
+    "music.youtube.com": "youtube",
+    "youtu.be":          "youtube",
+    "vimeo.com":         "vimeo",
+    "player.vimeo.com":  "vimeo",
+    "twitch.tv":         "twitch",
+    "m.twitch.tv":       "twitch",
+    "clips.twitch.tv":   "twitch",
+    "dailymotion.com":   "dailymotion",
+    "dai.ly":            "dailymotion",
+    "tiktok.com":        "tiktok",
+    "soundcloud.com":    "soundcloud",
+    "bilibili.com":      "bilibili",
+    "archive.org":       "archive",
+    "rumble.com":        "rumble",
+}
+
+// platformIDPatterns pull a site's own video ID out of a URL path
+var platformIDPatterns = map[string]*regexp.Regexp{
+    "vimeo":       regexp.MustCompile(`(?:^|/)(\d+)(?:/|$)`),
+    "twitch":      regexp.MustCompile(`^/(?:videos/(\d+)|[^/]+/clip/([\w-]+)|([\w-]+)$)`),
+    "dailymotion": regexp.MustCompile(`^/(?:video/)?([a-zA-Z0-9]+)`),
+    "tiktok":      regexp.MustCompile(`/video/(\d+)`),
+    "bilibili":    regexp.MustCompile(`^/video/([a-zA-Z0-9]+)`),
+    "archive":     regexp.MustCompile(`^/details/([^/]+)`),
+    "rumble":      regexp.MustCompile(`^/(v[a-z0-9]+)`),
+}
+
+// unsafeIDChars are replaced in IDs, which end up in file and folder names
+var unsafeIDChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// detectPlatform works out which site a URL source is on and the video ID to use for it.
+// YouTube IDs are used as is, for backward compatibility with existing projects; others
+// are prefixed with the platform, and URLs without a recognizable ID get a hash of the
+// URL. Returns "", "" for something that isn't a URL or YouTube ID at all.
+func detectPlatform(rawURL string) (platform, id string) {
+    rawURL = strings.TrimSpace(rawURL)
+    if videoID := extractVideoID(rawURL); videoID != "" {
+        return "youtube", videoID
+    }
+
+    parsed, err := url.Parse(rawURL)
+    if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+        return "", ""
+    }
+
+    host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+    platform, known := platformHosts[host]
+    if !known {
+        platform = genericPlatform
+    }
+
+    // YouTube URLs extractVideoID doesn't handle, e.g. shorts and live
+    if platform == "youtube" {
+        parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+        if len(parts) == 2 && (parts[0] == "shorts" || parts[0] == "live" || parts[0] == "embed") {
+            return "youtube", unsafeIDChars.ReplaceAllString(parts[1], "")
+        }
+    }
+
+    if pattern, ok := platformIDPatterns[platform]; ok {
+        if match := pattern.FindStringSubmatch(parsed.Path); match != nil {
+            for _, group := range match[1:] {
+                if id := unsafeIDChars.ReplaceAllString(group, "_"); id != "" {
+                    return platform, platform + "_" + id
+                }
+            }
+        }
+    }
+
+    sum := sha256.Sum256([]byte(rawURL))
+    return platform, platform + "_" + hex.EncodeToString(sum[:])[:12]
+}
+
+// platformDisplayName is the name used for a URL project the user didn't name
+func platformDisplayName(platform string) string {
+    switch platform {
+    case "youtube":
+        return "YouTube"
+    case "tiktok":
+        return "TikTok"
+    case "soundcloud":
+        return "SoundCloud"
+    case "archive":
+        return "Internet Archive"
+    case genericPlatform, "":
+        return "Online"
+    }
+    return strings.ToUpper(platform[:1]) + platform[1:]
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+    "fmt"
+    "net/http"
+    "os"
+    "strings"
+    "time"
+)
+
+// PreflightReport says whether a project can be run end to end, check by check, so
+// problems are fixed before a long batch starts rather than hours into it
+type PreflightReport struct {
+    ProjectID string           `json:"projectId"`
+    Runnable  bool             `json:"runnable"`
+    Checks    []PreflightCheck `json:"checks"`
+}
+
+// PreflightCheck is one check of a preflight. Warnings don't stop a run.
+type PreflightCheck struct {
+    Name    string `json:"name"`
+    Status  string `json:"status"` // "pass", "warn" or "fail"
+    Message string `json:"message,omitempty"`
+}
+
+const (
+    sourceProbeTimeout = 10 * time.Second
+    // preflightWorkFactor is how many times the source's size a run writes: extracted
+    // audio, synthesized segments and the final mix
+    preflightWorkFactor = 5
+    // unknownSourceSize stands in for URL sources that haven't been downloaded yet
+    unknownSourceSize = 2048 * mb
+)
+
+// PreflightProject checks a project's remaining steps can run: the source is there, the
+// target language is supported by every step, tools, packages and API keys are in place and
+// the disk has room. Steps already completed aren't checked again.
+func (a *App) PreflightProject(projectID string) (PreflightReport, error) {
+    report := PreflightReport{ProjectID: projectID, Runnable: true, Checks: []PreflightCheck{}}
+
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return report, fmt.Errorf("failed to load project: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return report, err
+    }
+
+    add := func(name string, err error, warning string) {
+        check := PreflightCheck{Name: name, Status: "pass"}
+        switch {
+        case err != nil:
+            check.Status = "fail"
+            check.Message = err.Error()
+            report.Runnable = false
+        case warning != "":
+            check.Status = "warn"
+            check.Message = warning
+        }
+        report.Checks = append(report.Checks, check)
+    }
+
+    // Project env overrides apply to the pipeline, so they count as configured keys
+    getenv := func(name string) string {
+        if value, ok := project.EnvOverrides[name]; ok {
+            return value
+        }
+        return os.Getenv(name)
+    }
+    pending := func(step string) bool {
+        return !isStepCompleted(project.CompletedSteps, step)
+    }
+
+    sourceSize, err := a.preflightSource(projectDir, project)
+    add("Source", err, "")
+
+    add("Languages", nil, a.preflightLanguages(project))
+
+    if pending("download") && project.SourceType == "youtube" {
+        add("yt-dlp", a.preflightTool("yt-dlp"), "")
+    }
+    add("ffmpeg", a.preflightTool("ffmpeg"), "")
+    add("ffprobe", a.preflightTool("ffprobe"), "")
+
+    if pending("transcribe") {
+        _, err := a.requireTranscriptionBackend(project.Settings.Transcription)
+        add("Transcription backend", err, "")
+    }
+    if pending("translate") {
+        add("Translation", preflightTranslation(project, getenv), "")
+    }
+    if pending("synthesize") && project.Settings.Synthesis.Voice != "" {
+        add("Voice", a.ValidateVoiceLanguage(project.Settings.Synthesis.Voice, project.TargetLanguage), "")
+    }
+
+    var modelBytes int64
+    var missing []string
+    for _, model := range requiredModels(project) {
+        if !model.Cached {
+            modelBytes += model.SizeBytes
+            missing = append(missing, model.ID)
+        }
+    }
+    modelWarning := ""
+    if len(missing) > 0 {
+        modelWarning = fmt.Sprintf("not downloaded yet, so the first run needs network access: %s", strings.Join(missing, ", "))
+    }
+    add("Models", nil, modelWarning)
+
+    add("Disk space", preflightDiskSpace(projectDir, sourceSize*preflightWorkFactor+modelBytes), "")
+
+    return report, nil
+}
+
+// preflightSource checks the source file exists, or for a URL source that hasn't been
+// downloaded, that the URL answers. It returns the source's size when known.
+func (a *App) preflightSource(projectDir string, project *ProjectConfig) (int64, error) {
+    if ref := sourceFileReference(project); ref != nil {
+        path := resolveFileReferencePath(projectDir, ref)
+        info, err := os.Stat(path)
+        if err != nil {
+            return 0, fmt.Errorf("source file not found: %s", path)
+        }
+        return info.Size(), nil
+    }
+
+    if project.SourceUrl == nil || *project.SourceUrl == "" {
+        return 0, fmt.Errorf("project has no source file or URL")
+    }
+    client := &http.Client{Timeout: sourceProbeTimeout}
+    resp, err := client.Head(*project.SourceUrl)
+    if err != nil {
+        return unknownSourceSize, fmt.Errorf("source URL is not reachable: %w", err)
+    }
+    resp.Body.Close()
+    // Some sites refuse HEAD requests; only a missing page means the source is gone
+    if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+        return unknownSourceSize, fmt.Errorf("source URL returned %s", resp.Status)
+    }
+    return unknownSourceSize, nil
+}
+
+// preflightLanguages warns when a step doesn't list the project's languages. The lists
+// come from the backends, which can handle more than they report, so this never fails.
+func (a *App) preflightLanguages(project *ProjectConfig) string {
+    support, err := a.GetSupportedLanguages()
+    if err != nil {
+        return fmt.Sprintf("could not check language support: %v", err)
+    }
+
+    var problems []string
+    source := project.Settings.Transcription.Language
+    if source != "" && source != "auto" && !containsString(support.Transcribe, source) {
+        problems = append(problems, fmt.Sprintf("transcription doesn't list source language %s", source))
+    }
+    if !containsString(support.Translate, project.TargetLanguage) {
+        problems = append(problems, fmt.Sprintf("translation doesn't list target language %s", project.TargetLanguage))
+    }
+    if !containsString(support.Synthesize, project.TargetLanguage) {
+        problems = append(problems, fmt.Sprintf("synthesis doesn't list target language %s", project.TargetLanguage))
+    }
+    return strings.Join(problems, "; ")
+}
+
+func (a *App) preflightTool(name string) error {
+    for _, req := range toolRequirements {
+        if req.name != name {
+            continue
+        }
+        if result := a.checkToolVersion(name, req); result.Status == "fail" {
+            return fmt.Errorf("%s", result.Message)
+        }
+    }
+    return nil
+}
+
+// preflightTranslation checks the translation settings and, for a cloud provider, that it
+// takes the target language and has an API key
+func preflightTranslation(project *ProjectConfig, getenv func(string) string) error {
+    translation := project.Settings.Translation
+    if err := validateTranslationProvider(translation, project.TargetLanguage); err != nil {
+        return err
+    }
+    if translation.CloudProvider == nil || *translation.CloudProvider == "" {
+        return nil
+    }
+
+    providerID := strings.ToLower(*translation.CloudProvider)
+    for _, p := range translationProviders {
+        if p.id != providerID {
+            continue
+        }
+        for _, env := range p.keyEnvs {
+            if getenv(env) != "" {
+                return nil
+            }
+        }
+        return fmt.Errorf("%s needs an API key: set %s", p.name, p.keyEnvs[0])
+    }
+    return nil
+}
+
+// preflightDiskSpace checks the projects volume has room for what a run will write
+func preflightDiskSpace(projectDir string, needed int64) error {
+    free, err := diskFreeBytes(projectDir)
+    if err != nil {
+        return fmt.Errorf("could not check free space: %w", err)
+    }
+    if free < uint64(needed) {
+        return fmt.Errorf("about %d MB is needed but only %d MB is free", needed/mb, free/uint64(mb))
+    }
+    return nil
+}
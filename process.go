@@ -0,0 +1,129 @@
+package main
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "os/exec"
+    "time"
+)
+
+// shutdownGracePeriod is how long running subprocesses get to exit after being asked to stop
+const shutdownGracePeriod = 3 * time.Second
+
+// trackedCommand creates a command that is registered with the app so it (and any
+// children it spawns) can be stopped on shutdown. Run it with startCommand, runCommand,
+// commandOutput or commandCombinedOutput, and untrackCommand when done.
+func (a *App) trackedCommand(name string, args ...string) *exec.Cmd {
+    return a.trackedCommandContext(a.runCtx, name, args...)
+}
+
+// trackedCommandContext is trackedCommand for a command that ctx can also stop, such as a
+// pipeline step the user cancels. ctx must derive from a.runCtx.
+func (a *App) trackedCommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+    cmd := exec.CommandContext(ctx, name, args...)
+    configureProcessGroup(cmd)
+    cmd.Cancel = func() error {
+        return terminateProcessTree(cmd)
+    }
+    cmd.WaitDelay = shutdownGracePeriod
+
+    a.procMu.Lock()
+    a.procs[cmd] = nil
+    a.procMu.Unlock()
+
+    return cmd
+}
+
+// startCommand starts a tracked command and records its process. Start sets cmd.Process
+// without the registry's lock, so the registry only reads the process recorded here.
+func (a *App) startCommand(cmd *exec.Cmd) error {
+    if err := cmd.Start(); err != nil {
+        return err
+    }
+
+    a.procMu.Lock()
+    if _, tracked := a.procs[cmd]; tracked {
+        a.procs[cmd] = cmd.Process
+    }
+    a.procMu.Unlock()
+    return nil
+}
+
+// runCommand is cmd.Run for a tracked command
+func (a *App) runCommand(cmd *exec.Cmd) error {
+    if err := a.startCommand(cmd); err != nil {
+        return err
+    }
+    return cmd.Wait()
+}
+
+// commandOutput is cmd.Output for a tracked command
+func (a *App) commandOutput(cmd *exec.Cmd) ([]byte, error) {
+    if cmd.Stdout != nil {
+        return nil, errors.New("exec: Stdout already set")
+    }
+    var stdout bytes.Buffer
+    cmd.Stdout = &stdout
+    err := a.runCommand(cmd)
+    return stdout.Bytes(), err
+}
+
+// commandCombinedOutput is cmd.CombinedOutput for a tracked command
+func (a *App) commandCombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+    if cmd.Stdout != nil || cmd.Stderr != nil {
+        return nil, errors.New("exec: Stdout or Stderr already set")
+    }
+    var output bytes.Buffer
+    cmd.Stdout = &output
+    cmd.Stderr = &output
+    err := a.runCommand(cmd)
+    return output.Bytes(), err
+}
+
+// untrackCommand removes a finished command from the registry
+func (a *App) untrackCommand(cmd *exec.Cmd) {
+    a.procMu.Lock()
+    delete(a.procs, cmd)
+    a.procMu.Unlock()
+}
+
+// stopAllProcesses cancels every running command, waits up to the grace period for
+// them to exit and then force-kills whatever is left
+func (a *App) stopAllProcesses() {
+    a.cancelRuns()
+
+    deadline := time.Now().Add(shutdownGracePeriod)
+    for time.Now().Before(deadline) {
+        if a.runningProcessCount() == 0 {
+            return
+        }
+        time.Sleep(50 * time.Millisecond)
+    }
+
+    a.procMu.Lock()
+    defer a.procMu.Unlock()
+    for cmd, process := range a.procs {
+        if process == nil {
+            continue
+        }
+        if err := killProcessTree(cmd); err != nil {
+            fmt.Printf("Warning: failed to kill process %d: %v\n", process.Pid, err)
+        }
+    }
+}
+
+func (a *App) runningProcessCount() int {
+    a.procMu.Lock()
+    defer a.procMu.Unlock()
+
+    count := 0
+    for _, process := range a.procs {
+        // Commands that never started have nothing to wait for
+        if process != nil {
+            count++
+        }
+    }
+    return count
+}
@@ -0,0 +1,35 @@
+//go:build !windows
+
+package main
+
+import (
+    "os/exec"
+    "syscall"
+)
+
+// configureProcessGroup starts the command in its own process group so its children can be signalled together
+func configureProcessGroup(cmd *exec.Cmd) {
+    cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// terminateProcessTree asks the command's process group to exit
+func terminateProcessTree(cmd *exec.Cmd) error {
+    if cmd.Process == nil {
+        return nil
+    }
+    return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+}
+
+// killProcessTree forcibly kills the command's process group
+func killProcessTree(cmd *exec.Cmd) error {
+    if cmd.Process == nil {
+        return nil
+    }
+    return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
+
+// isProcessAlive reports whether a process with the given PID exists
+func isProcessAlive(pid int) bool {
+    err := syscall.Kill(pid, 0)
+    return err == nil || err == syscall.EPERM
+}
@@ -0,0 +1,49 @@
+//go:build windows
+
+package main
+
+import (
+    "os/exec"
+    "strconv"
+    "syscall"
+)
+
+// configureProcessGroup starts the command in a new process group so it doesn't receive the app's console signals
+func configureProcessGroup(cmd *exec.Cmd) {
+    cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// terminateProcessTree stops the command and its children. Windows has no SIGTERM,
+// so this asks taskkill to end the whole tree.
+func terminateProcessTree(cmd *exec.Cmd) error {
+    if cmd.Process == nil {
+        return nil
+    }
+    return exec.Command("taskkill", "/T", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+}
+
+// killProcessTree forcibly kills the command and its children
+func killProcessTree(cmd *exec.Cmd) error {
+    if cmd.Process == nil {
+        return nil
+    }
+    return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+}
+
+// isProcessAlive reports whether a process with the given PID exists
+func isProcessAlive(pid int) bool {
+    const processQueryLimitedInformation = 0x1000
+    const stillActive = 259
+
+    handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+    if err != nil {
+        return false
+    }
+    defer syscall.CloseHandle(handle)
+
+    var exitCode uint32
+    if err := syscall.GetExitCodeProcess(handle, &exitCode); err != nil {
+        return false
+    }
+    return exitCode == stillActive
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+    "fmt"
+    "time"
+)
+
+// projectSaveDebounce is how long a queued project save waits for further edits
+const projectSaveDebounce = 750 * time.Millisecond
+
+// queuedProjectSave is the latest edit of a project waiting to be written
+type queuedProjectSave struct {
+    project *ProjectConfig
+    timer   *time.Timer
+}
+
+// QueueProjectSave saves a project after a short quiet period, so a burst of UI edits is
+// written once. Reading the project writes any queued save first. The save goes through
+// the same validation as UpdateProject; if it fails, "project:save-failed" is emitted with the error.
+func (a *App) QueueProjectSave(project *ProjectConfig) {
+    if project == nil || project.ID == "" {
+        return
+    }
+
+    a.projectSavesMu.Lock()
+    defer a.projectSavesMu.Unlock()
+
+    if a.projectSaves == nil {
+        a.projectSaves = make(map[string]*queuedProjectSave)
+    }
+    if queued, ok := a.projectSaves[project.ID]; ok {
+        queued.project = project
+        queued.timer.Reset(projectSaveDebounce)
+        return
+    }
+
+    projectID := project.ID
+    a.projectSaves[projectID] = &queuedProjectSave{
+        project: project,
+        timer: time.AfterFunc(projectSaveDebounce, func() {
+            a.flushProjectSave(projectID)
+        }),
+    }
+}
+
+// flushProjectSave writes a project's queued save now, if it has one. Writes are
+// serialized, so a read that flushes also waits for a save the timer already started.
+func (a *App) flushProjectSave(projectID string) error {
+    a.projectSaveWriteMu.Lock()
+    defer a.projectSaveWriteMu.Unlock()
+
+    a.projectSavesMu.Lock()
+    queued, ok := a.projectSaves[projectID]
+    delete(a.projectSaves, projectID)
+    a.projectSavesMu.Unlock()
+    if !ok {
+        return nil
+    }
+    queued.timer.Stop()
+
+    if err := a.updateProject(queued.project); err != nil {
+        fmt.Printf("Failed to save project %s: %v\n", projectID, err)
+        a.emitEvent("project:save-failed", projectID, err.Error())
+        return err
+    }
+    return nil
+}
+
+// flushAllProjectSaves writes every queued save, for shutdown and for reads that list
+// every project
+func (a *App) flushAllProjectSaves() {
+    a.projectSavesMu.Lock()
+    projectIDs := make([]string, 0, len(a.projectSaves))
+    for projectID := range a.projectSaves {
+        projectIDs = append(projectIDs, projectID)
+    }
+    a.projectSavesMu.Unlock()
+
+    for _, projectID := range projectIDs {
+        a.flushProjectSave(projectID)
+    }
+}
+
+// dropQueuedProjectSave discards a queued save that a direct write has superseded
+func (a *App) dropQueuedProjectSave(projectID string) {
+    a.projectSavesMu.Lock()
+    defer a.projectSavesMu.Unlock()
+
+    if queued, ok := a.projectSaves[projectID]; ok {
+        queued.timer.Stop()
+        delete(a.projectSaves, projectID)
+    }
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "time"
+)
+
+const (
+    projectHistoryDir      = ".history"
+    historyTimestampFormat = "20060102-150405.000"
+    defaultHistoryDepth    = 10
+)
+
+// ConfigVersion describes an archived copy of a project's project.json
+type ConfigVersion struct {
+    Timestamp string `json:"timestamp"`
+    SavedAt   string `json:"savedAt"`
+    Size      int64  `json:"size"`
+}
+
+// GetProjectHistory returns the archived config versions of a project, newest first
+func (a *App) GetProjectHistory(projectID string) ([]ConfigVersion, error) {
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("project not found: %w", err)
+    }
+
+    versions := make([]ConfigVersion, 0)
+    for _, name := range listHistoryFiles(projectDir) {
+        timestamp := historyTimestamp(name)
+        savedAt, err := time.ParseInLocation(historyTimestampFormat, timestamp, time.Local)
+        if err != nil {
+            continue
+        }
+
+        info, err := os.Stat(filepath.Join(projectDir, projectHistoryDir, name))
+        if err != nil {
+            continue
+        }
+
+        versions = append(versions, ConfigVersion{
+            Timestamp: timestamp,
+            SavedAt:   savedAt.Format(time.RFC3339),
+            Size:      info.Size(),
+        })
+    }
+
+    return versions, nil
+}
+
+// RestoreProjectVersion replaces project.json with an archived version. It is saved like
+// any other edit, so it is validated, replaces a pending autosave and emits "project:updated".
+// The current config is archived first, so a restore can itself be undone.
+func (a *App) RestoreProjectVersion(projectID, versionTimestamp string) error {
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+
+    if strings.ContainsAny(versionTimestamp, `/\`) {
+        return fmt.Errorf("invalid version timestamp: %s", versionTimestamp)
+    }
+
+    historyPath := filepath.Join(projectDir, projectHistoryDir, historyFileName(versionTimestamp))
+    if _, err := os.Stat(historyPath); os.IsNotExist(err) {
+        return fmt.Errorf("version not found: %s", versionTimestamp)
+    }
+
+    data, err := os.ReadFile(historyPath)
+    if err != nil {
+        return fmt.Errorf("failed to read archived version: %w", err)
+    }
+
+    var project ProjectConfig
+    if err := json.Unmarshal(data, &project); err != nil {
+        return fmt.Errorf("failed to parse archived version: %w", err)
+    }
+
+    // Archived copies always belong to this project, whatever they say
+    project.ID = projectID
+
+    if err := a.UpdateProject(&project); err != nil {
+        return fmt.Errorf("failed to restore version %s: %w", versionTimestamp, err)
+    }
+    return nil
+}
+
+// archiveProjectConfig copies the current project.json into the history folder
+// and prunes the history to the configured depth
+func (a *App) archiveProjectConfig(projectDir string) error {
+    configPath := filepath.Join(projectDir, "project.json")
+    current, err := os.ReadFile(configPath)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil
+        }
+        return err
+    }
+
+    historyDir := filepath.Join(projectDir, projectHistoryDir)
+    if err := os.MkdirAll(historyDir, 0755); err != nil {
+        return err
+    }
+
+    // Skip identical consecutive versions
+    existing := listHistoryFiles(projectDir)
+    if len(existing) > 0 {
+        latest, err := os.ReadFile(filepath.Join(historyDir, existing[0]))
+        if err == nil && bytes.Equal(latest, current) {
+            return nil
+        }
+    }
+
+    timestamp := time.Now().Format(historyTimestampFormat)
+    if err := os.WriteFile(filepath.Join(historyDir, historyFileName(timestamp)), current, 0644); err != nil {
+        return err
+    }
+
+    depth := defaultHistoryDepth
+    if settings, err := a.GetAppSettings(); err == nil && settings.HistoryDepth > 0 {
+        depth = settings.HistoryDepth
+    }
+
+    files := listHistoryFiles(projectDir)
+    for _, name := range files[min(depth, len(files)):] {
+        os.Remove(filepath.Join(historyDir, name))
+    }
+
+    return nil
+}
+
+// listHistoryFiles returns the archived config filenames, newest first
+func listHistoryFiles(projectDir string) []string {
+    files := make([]string, 0)
+    entries, err := os.ReadDir(filepath.Join(projectDir, projectHistoryDir))
+    if err != nil {
+        return files
+    }
+
+    for _, entry := range entries {
+        name := entry.Name()
+        if !entry.IsDir() && strings.HasPrefix(name, "project-") && strings.HasSuffix(name, ".json") {
+            files = append(files, name)
+        }
+    }
+
+    // Timestamps sort lexically
+    sort.Sort(sort.Reverse(sort.StringSlice(files)))
+    return files
+}
+
+func historyFileName(timestamp string) string {
+    return "project-" + timestamp + ".json"
+}
+
+func historyTimestamp(fileName string) string {
+    return strings.TrimSuffix(strings.TrimPrefix(fileName, "project-"), ".json")
+}
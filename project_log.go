@@ -0,0 +1,37 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// projectLogPath is the append-only log kept inside each project folder
+func projectLogPath(projectDir string) string {
+    return filepath.Join(projectDir, "logs", "pipeline.log")
+}
+
+// stepLogPath is where the full output of a project's latest run of a step is kept
+func stepLogPath(projectDir, step string) string {
+    return filepath.Join(projectDir, "logs", step+".log")
+}
+
+// appendProjectLog writes a timestamped line to the project's log. Logging is best-effort:
+// failures are reported on the console but never fail the caller.
+func appendProjectLog(projectDir, format string, args ...interface{}) {
+    path := projectLogPath(projectDir)
+    if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+        fmt.Printf("Warning: failed to create project log directory: %v\n", err)
+        return
+    }
+
+    f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+    if err != nil {
+        fmt.Printf("Warning: failed to open project log: %v\n", err)
+        return
+    }
+    defer f.Close()
+
+    fmt.Fprintf(f, "%s %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "runtime"
+    "strings"
+)
+
+// PermissionIssue is a file or folder of a project the app can't use as the pipeline needs to
+type PermissionIssue struct {
+    Path string `json:"path"`
+    // Kind is "missing", "read" or "write"
+    Kind    string `json:"kind"`
+    Message string `json:"message"`
+    // Fixable is false for what FixProjectPermissions won't touch, such as linked files
+    // outside the project or Windows ACLs
+    Fixable bool `json:"fixable"`
+}
+
+const (
+    projectDirMode  fs.FileMode = 0755
+    projectFileMode fs.FileMode = 0644
+)
+
+// windowsPermissionHint is added to issues on Windows, where the POSIX mode bits only map to
+// the read-only attribute and real access is decided by ACLs
+const windowsPermissionHint = "On Windows access is controlled by the folder's Security settings (ACLs), which the app can't change; check them in Explorer under Properties > Security"
+
+// CheckProjectPermissions checks the app can read the project's inputs and files and write
+// to each of its folders
+func (a *App) CheckProjectPermissions(projectID string) ([]PermissionIssue, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load project: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("project not found: %w", err)
+    }
+
+    issues := []PermissionIssue{}
+    dirs := append([]string{projectDir}, projectSubdirs(projectDir)...)
+    if project.OutputDir != nil {
+        dirs = append(dirs, *project.OutputDir)
+    }
+    for _, dir := range dirs {
+        if issue, ok := checkDirPermissions(dir, strings.HasPrefix(dir, projectDir)); ok {
+            issues = append(issues, issue)
+        }
+    }
+
+    // Every file inside the project has to be readable, and writable since steps rewrite
+    // their outputs in place
+    filepath.WalkDir(projectDir, func(path string, entry fs.DirEntry, err error) error {
+        if err != nil {
+            issues = append(issues, permissionIssue(path, "read", fmt.Sprintf("can't be listed: %v", err), true))
+            return filepath.SkipDir
+        }
+        if !entry.Type().IsRegular() {
+            return nil
+        }
+        if issue, ok := checkFilePermissions(path, true, true); ok {
+            issues = append(issues, issue)
+        }
+        return nil
+    })
+
+    // Linked sources live outside the project and only need to be readable
+    for _, ref := range []*FileReference{project.FileReferences.VideoFile, project.FileReferences.AudioFile} {
+        if ref == nil || !ref.IsLinked {
+            continue
+        }
+        if issue, ok := checkFilePermissions(ref.Path, false, false); ok {
+            issues = append(issues, issue)
+        }
+    }
+
+    return issues, nil
+}
+
+// FixProjectPermissions gives the project's folders and files the usual 0755 and 0644
+// modes where they are missing owner access. Files outside the project are left alone.
+// Returns an error describing whatever is still wrong afterwards.
+func (a *App) FixProjectPermissions(projectID string) error {
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+
+    for _, dir := range projectSubdirs(projectDir) {
+        if err := os.MkdirAll(dir, projectDirMode); err != nil {
+            return fmt.Errorf("failed to create %s: %w", dir, err)
+        }
+    }
+
+    // WalkDir visits a folder before listing it, so a folder fixed here can be listed next
+    var failures []string
+    filepath.WalkDir(projectDir, func(path string, entry fs.DirEntry, err error) error {
+        if err != nil || entry.Type()&fs.ModeSymlink != 0 {
+            return nil
+        }
+        info, err := entry.Info()
+        if err != nil {
+            return nil
+        }
+
+        want, need := projectFileMode, fs.FileMode(0600)
+        if entry.IsDir() {
+            want, need = projectDirMode, 0700
+        }
+        if info.Mode().Perm()&need != need {
+            if err := os.Chmod(path, want); err != nil {
+                failures = append(failures, fmt.Sprintf("%s: %v", path, err))
+            }
+        }
+        return nil
+    })
+
+    issues, err := a.CheckProjectPermissions(projectID)
+    if err != nil {
+        return err
+    }
+    if len(issues) == 0 {
+        return nil
+    }
+
+    problems := make([]string, 0, len(issues))
+    for _, issue := range issues {
+        problems = append(problems, fmt.Sprintf("%s: %s", issue.Path, issue.Message))
+    }
+    message := fmt.Sprintf("%d permission problem(s) remain:\n%s", len(issues), strings.Join(problems, "\n"))
+    if len(failures) > 0 {
+        message += "\nchmod failed for:\n" + strings.Join(failures, "\n")
+    }
+    if runtime.GOOS == "windows" {
+        message += "\n" + windowsPermissionHint
+    }
+    return fmt.Errorf("%s", message)
+}
+
+// projectSubdirs are the folders every project has
+func projectSubdirs(projectDir string) []string {
+    dirs := make([]string, 0, len(mergedDataDirs))
+    for _, sub := range mergedDataDirs {
+        dirs = append(dirs, filepath.Join(projectDir, sub))
+    }
+    return dirs
+}
+
+// checkDirPermissions confirms dir exists and a file can be created in it, without
+// creating the folder the way checkDirWritable does
+func checkDirPermissions(dir string, fixable bool) (PermissionIssue, bool) {
+    info, err := os.Stat(dir)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return permissionIssue(dir, "missing", "folder does not exist", fixable), true
+        }
+        return permissionIssue(dir, "read", fmt.Sprintf("can't be accessed: %v", err), fixable), true
+    }
+    if !info.IsDir() {
+        return permissionIssue(dir, "missing", "is a file, not a folder", false), true
+    }
+
+    probe, err := os.CreateTemp(dir, ".write-test-*")
+    if err != nil {
+        return permissionIssue(dir, "write", fmt.Sprintf("folder is not writable: %v", err), fixable), true
+    }
+    probe.Close()
+    os.Remove(probe.Name())
+    return PermissionIssue{}, false
+}
+
+// checkFilePermissions opens path for reading and, if write is set, for writing
+func checkFilePermissions(path string, write, fixable bool) (PermissionIssue, bool) {
+    f, err := os.Open(path)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return permissionIssue(path, "missing", "file does not exist", false), true
+        }
+        return permissionIssue(path, "read", fmt.Sprintf("file is not readable: %v", err), fixable), true
+    }
+    f.Close()
+
+    if write {
+        f, err := os.OpenFile(path, os.O_WRONLY, 0)
+        if err != nil {
+            return permissionIssue(path, "write", fmt.Sprintf("file is not writable: %v", err), fixable), true
+        }
+        f.Close()
+    }
+    return PermissionIssue{}, false
+}
+
+// permissionIssue builds an issue. On Windows only a read-only file or folder can be fixed
+// with chmod, so anything else is reported as an ACL problem.
+func permissionIssue(path, kind, message string, fixable bool) PermissionIssue {
+    if runtime.GOOS == "windows" && kind != "missing" {
+        readOnly := false
+        if info, err := os.Stat(path); err == nil {
+            readOnly = info.Mode().Perm()&0200 == 0
+        }
+        if !readOnly {
+            fixable = false
+            message += ". " + windowsPermissionHint
+        }
+    }
+    return PermissionIssue{Path: path, Kind: kind, Message: message, Fixable: fixable}
+}
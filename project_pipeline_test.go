@@ -0,0 +1,28 @@
+package main
+
+import (
+    "os"
+    "os/exec"
+    "path/filepath"
+    "testing"
+)
+
+// Runs each step path of python/project_pipeline.py with ffmpeg, yt-dlp and the ML
+// packages faked; see testdata/project_pipeline_paths.py for the checks
+func TestProjectPipelineStepPaths(t *testing.T) {
+    app, _ := newTestApp(t)
+    python := app.getPythonCommand()
+    if _, err := exec.LookPath(python); err != nil {
+        t.Skip("Python interpreter not available")
+    }
+
+    script, err := filepath.Abs(filepath.Join("testdata", "project_pipeline_paths.py"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    cmd := exec.Command(python, script, app.getPythonScriptsDir())
+    cmd.Env = append(os.Environ(), "PYTHONDONTWRITEBYTECODE=1", "PYTHONIOENCODING=utf-8")
+    if output, err := cmd.CombinedOutput(); err != nil {
+        t.Fatalf("%v\n%s", err, output)
+    }
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+    "time"
+)
+
+// OrphanInfo describes a project folder that has data but no usable project.json
+type OrphanInfo struct {
+    // FolderName is relative to the projects root: "<category>/<folder>" inside a category
+    FolderName   string   `json:"folderName"`
+    Path         string   `json:"path"`
+    Reason       string   `json:"reason"`
+    InputFiles   []string `json:"inputFiles"`
+    OutputFiles  []string `json:"outputFiles"`
+    LastModified string   `json:"lastModified"`
+}
+
+// Folder names are generated as "<name> [<videoId>] [<LANG>]", optionally followed by " (<n>)"
+var projectFolderPattern = regexp.MustCompile(`^(.*) \[([^\]]+)\] \[([^\]]+)\](?: \((\d+)\))?$`)
+
+var videoExtensions = map[string]bool{".mp4": true, ".mkv": true, ".mov": true, ".webm": true, ".avi": true}
+var audioExtensions = map[string]bool{".mp3": true, ".wav": true, ".m4a": true, ".flac": true, ".ogg": true, ".aac": true}
+
+// ScanForOrphanedProjects finds project folders with input/output data but no valid config,
+// both directly under the projects root and inside category folders
+func (a *App) ScanForOrphanedProjects() ([]OrphanInfo, error) {
+    settings, err := a.GetAppSettings()
+    if err != nil {
+        return nil, fmt.Errorf("failed to get app settings: %w", err)
+    }
+
+    orphans := make([]OrphanInfo, 0)
+
+    entries, err := os.ReadDir(settings.DefaultProjectsPath)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return orphans, nil
+        }
+        return nil, fmt.Errorf("failed to read projects directory: %w", err)
+    }
+
+    for _, entry := range entries {
+        // Hidden folders such as the shared inputs cache are the app's own, not projects
+        if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+            continue
+        }
+
+        dir := filepath.Join(settings.DefaultProjectsPath, entry.Name())
+        if orphan, ok := orphanedProject(dir, entry.Name()); ok {
+            orphans = append(orphans, orphan)
+            continue
+        }
+        if fileExists(filepath.Join(dir, "project.json")) {
+            continue
+        }
+
+        // Neither a project nor an orphan, so possibly a category
+        children, err := os.ReadDir(dir)
+        if err != nil {
+            continue
+        }
+        for _, child := range children {
+            if !child.IsDir() || strings.HasPrefix(child.Name(), ".") {
+                continue
+            }
+            if orphan, ok := orphanedProject(filepath.Join(dir, child.Name()), entry.Name()+"/"+child.Name()); ok {
+                orphans = append(orphans, orphan)
+            }
+        }
+    }
+
+    return orphans, nil
+}
+
+// orphanedProject reports whether projectDir has data worth recovering but no valid config
+func orphanedProject(projectDir, folderName string) (OrphanInfo, bool) {
+    reason := ""
+    data, err := os.ReadFile(filepath.Join(projectDir, "project.json"))
+    if err != nil {
+        reason = "project.json missing"
+    } else {
+        var config ProjectConfig
+        if err := json.Unmarshal(data, &config); err != nil {
+            reason = "project.json corrupted"
+        } else if config.ID == "" {
+            reason = "project.json has no ID"
+        }
+    }
+    if reason == "" {
+        return OrphanInfo{}, false
+    }
+
+    inputFiles := listDataFiles(filepath.Join(projectDir, "input"))
+    outputFiles := append(listDataFiles(filepath.Join(projectDir, "audio")), listDataFiles(filepath.Join(projectDir, "output"))...)
+    transcripts := listDataFiles(filepath.Join(projectDir, "transcripts"))
+    if len(inputFiles) == 0 && len(outputFiles) == 0 && len(transcripts) == 0 {
+        // Nothing worth recovering
+        return OrphanInfo{}, false
+    }
+
+    lastModified := ""
+    if info, err := os.Stat(projectDir); err == nil {
+        lastModified = info.ModTime().Format(time.RFC3339)
+    }
+
+    return OrphanInfo{
+        FolderName:   folderName,
+        Path:         projectDir,
+        Reason:       reason,
+        InputFiles:   inputFiles,
+        OutputFiles:  outputFiles,
+        LastModified: lastModified,
+    }, true
+}
+
+// RecoverProject reconstructs a minimal project.json from the contents of an orphaned folder,
+// named as ScanForOrphanedProjects reports it
+func (a *App) RecoverProject(folderName string) (*ProjectConfig, error) {
+    projectDir, err := a.resolveProjectFolder(folderName)
+    if err != nil {
+        return nil, err
+    }
+
+    configPath := filepath.Join(projectDir, "project.json")
+    if existing, err := readProjectConfig(projectDir); err == nil && existing.ID != "" {
+        return nil, fmt.Errorf("project folder already has a valid project.json: %s", folderName)
+    }
+
+    projectID, err := generateProjectID()
+    if err != nil {
+        return nil, fmt.Errorf("failed to generate project ID: %w", err)
+    }
+
+    // A folder inside a category stays in it
+    category := ""
+    if parent, _, found := strings.Cut(strings.ReplaceAll(strings.TrimSpace(folderName), `\`, "/"), "/"); found {
+        category = parent
+    }
+
+    // Recover what we can from the generated folder name
+    baseName := filepath.Base(projectDir)
+    displayName := baseName
+    videoID := fmt.Sprintf("local_%d", time.Now().Unix())
+    targetLang := ""
+    version := 1
+    if m := projectFolderPattern.FindStringSubmatch(baseName); m != nil {
+        displayName = m[1]
+        videoID = m[2]
+        targetLang = strings.ToLower(m[3])
+        if m[4] != "" {
+            fmt.Sscanf(m[4], "%d", &version)
+        }
+    }
+
+    now := time.Now().Format(time.RFC3339)
+    project := &ProjectConfig{
+        ID:             projectID,
+        Name:           displayName,
+        Created:        now,
+        LastModified:   now,
+        Version:        version,
+        TargetLanguage: targetLang,
+        VideoId:        &videoID,
+        Category:       category,
+        CompletedSteps: CompletedSteps{},
+        FileReferences: FileReferences{},
+        Settings:       a.newProjectSettings(),
+        TextRules:      []TextRule{},
+        SegmentRules:   []SegmentRule{},
+    }
+
+    // Pick up the input media, preferring video over audio
+    for _, name := range listDataFiles(filepath.Join(projectDir, "input")) {
+        ext := strings.ToLower(filepath.Ext(name))
+        if !videoExtensions[ext] && !audioExtensions[ext] {
+            continue
+        }
+
+        ref := recoveredFileReference(projectDir, filepath.Join("input", name))
+        if videoExtensions[ext] && project.FileReferences.VideoFile == nil {
+            project.FileReferences.VideoFile = ref
+        } else if audioExtensions[ext] && project.FileReferences.AudioFile == nil {
+            project.FileReferences.AudioFile = ref
+        }
+    }
+
+    switch {
+    case project.FileReferences.VideoFile != nil:
+        project.SourceType = "video"
+        filename := filepath.Base(project.FileReferences.VideoFile.Path)
+        project.OriginalFilename = &filename
+    case project.FileReferences.AudioFile != nil:
+        project.SourceType = "audio"
+        filename := filepath.Base(project.FileReferences.AudioFile.Path)
+        project.OriginalFilename = &filename
+    default:
+        project.SourceType = "youtube"
+        // Only a YouTube ID can be turned back into its URL
+        if platform, _ := detectPlatform(videoID); platform == "youtube" {
+            sourceURL := "https://www.youtube.com/watch?v=" + videoID
+            project.SourceUrl = &sourceURL
+            project.Platform = platform
+        }
+    }
+    project.CompletedSteps.Download = project.FileReferences.VideoFile != nil || project.FileReferences.AudioFile != nil
+
+    // Segments file implies transcription (and possibly translation) happened
+    segmentsRel := filepath.Join("transcripts", videoID+"_segments.json")
+    if data, err := os.ReadFile(filepath.Join(projectDir, segmentsRel)); err == nil {
+        project.FileReferences.SegmentsFile = &segmentsRel
+        project.CompletedSteps.Transcribe = true
+
+        var segments []map[string]interface{}
+        if json.Unmarshal(data, &segments) == nil && len(segments) > 0 {
+            translated := true
+            for _, segment := range segments {
+                if text, _ := segment["translated_text"].(string); text == "" {
+                    translated = false
+                    break
+                }
+            }
+            project.CompletedSteps.Translate = translated
+        }
+    }
+
+    project.CompletedSteps.Synthesize = len(listDataFiles(filepath.Join(projectDir, "audio"))) > 0
+
+    for _, name := range listDataFiles(filepath.Join(projectDir, "audio")) {
+        if strings.HasPrefix(name, videoID+"_dubbed") {
+            finalAudio := filepath.Join("audio", name)
+            project.FileReferences.FinalAudio = &finalAudio
+        }
+    }
+    for _, name := range listDataFiles(filepath.Join(projectDir, "output")) {
+        if strings.HasPrefix(name, videoID+"_final") {
+            finalVideo := filepath.Join("output", name)
+            project.FileReferences.FinalVideo = &finalVideo
+            project.CompletedSteps.Combine = true
+        }
+    }
+
+    // Keep the broken config around rather than silently overwriting it
+    if _, err := os.Stat(configPath); err == nil {
+        backupPath := configPath + ".corrupt-" + time.Now().Format("20060102-150405")
+        if err := os.Rename(configPath, backupPath); err != nil {
+            return nil, fmt.Errorf("failed to back up corrupted project config: %w", err)
+        }
+    }
+
+    if err := a.saveProjectConfig(projectDir, project); err != nil {
+        return nil, fmt.Errorf("failed to save recovered project config: %w", err)
+    }
+
+    if err := a.addToRecentProjects(project.ID); err != nil {
+        fmt.Printf("Warning: failed to update recent projects: %v\n", err)
+    }
+
+    return project, nil
+}
+
+// listDataFiles returns the names of regular, non-hidden files in a directory
+func listDataFiles(dir string) []string {
+    files := make([]string, 0)
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return files
+    }
+    for _, entry := range entries {
+        if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+            continue
+        }
+        files = append(files, entry.Name())
+    }
+    return files
+}
+
+func recoveredFileReference(projectDir, relPath string) *FileReference {
+    ref := &FileReference{
+        Path:     relPath,
+        IsLinked: false,
+    }
+    if info, err := os.Stat(filepath.Join(projectDir, relPath)); err == nil {
+        size := info.Size()
+        modTime := info.ModTime().Format(time.RFC3339)
+        ref.Size = &size
+        ref.LastModified = &modTime
+    }
+    return ref
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+    "fmt"
+    "sort"
+)
+
+// Project completion states, derived from CompletedSteps
+const (
+    projectStateNew        = "new"
+    projectStateInProgress = "in-progress"
+    projectStateCompleted  = "completed"
+)
+
+// projectState classifies a project by how many pipeline steps it has completed
+func projectState(steps CompletedSteps) string {
+    done := 0
+    for _, completed := range []bool{steps.Download, steps.Transcribe, steps.Translate, steps.Synthesize, steps.Combine} {
+        if completed {
+            done++
+        }
+    }
+
+    switch done {
+    case 0:
+        return projectStateNew
+    case len(pipelineSteps):
+        return projectStateCompleted
+    default:
+        return projectStateInProgress
+    }
+}
+
+// GetProjectsByState lists the projects in a completion state ("new", "in-progress" or
+// "completed"), most recently modified first
+func (a *App) GetProjectsByState(state string) ([]ProjectConfig, error) {
+    switch state {
+    case projectStateNew, projectStateInProgress, projectStateCompleted:
+    default:
+        return nil, fmt.Errorf("invalid project state: %s (expected %s, %s or %s)", state, projectStateNew, projectStateInProgress, projectStateCompleted)
+    }
+
+    entries, err := a.listProjects()
+    if err != nil {
+        return nil, err
+    }
+
+    projects := make([]ProjectConfig, 0)
+    for _, entry := range entries {
+        if projectState(entry.Config.CompletedSteps) == state {
+            projects = append(projects, *entry.Config)
+        }
+    }
+
+    sort.SliceStable(projects, func(i, j int) bool {
+        return projects[i].LastModified > projects[j].LastModified
+    })
+    return projects, nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+)
+
+// ErrInvalidProject is matched (via errors.Is) by the error LoadProject returns for a
+// project.json that parses but fails validation
+var ErrInvalidProject = errors.New("invalid project")
+
+// ProjectValidationError lists every problem found in a project.json
+type ProjectValidationError struct {
+    ProjectID string
+    Problems  []string
+}
+
+func (e *ProjectValidationError) Error() string {
+    return fmt.Sprintf("invalid project %s:\n- %s", e.ProjectID, strings.Join(e.Problems, "\n- "))
+}
+
+func (e *ProjectValidationError) Is(target error) bool {
+    return target == ErrInvalidProject
+}
+
+var validSourceTypes = map[string]bool{"youtube": true, "video": true, "audio": true}
+
+// validateProjectConfig checks a loaded project against the rules the rest of the app
+// relies on, collecting all problems rather than stopping at the first
+func validateProjectConfig(project *ProjectConfig) error {
+    var problems []string
+
+    if strings.TrimSpace(project.ID) == "" {
+        problems = append(problems, "id is empty")
+    }
+
+    if !validSourceTypes[project.SourceType] {
+        problems = append(problems, fmt.Sprintf("sourceType %q is not one of youtube, video, audio", project.SourceType))
+    }
+    if project.SourceType == "youtube" && (project.SourceUrl == nil || *project.SourceUrl == "") {
+        problems = append(problems, "youtube project has no sourceUrl")
+    }
+
+    if project.TargetLanguage == "" {
+        problems = append(problems, "targetLanguage is empty")
+    } else if !isKnownLanguage(project.TargetLanguage) {
+        problems = append(problems, fmt.Sprintf("targetLanguage %q is not a known language code", project.TargetLanguage))
+    }
+
+    if project.Settings.Transcription.Source == "" {
+        problems = append(problems, "settings.transcription is missing")
+    } else if _, ok := transcriptionBackendFor(project.Settings.Transcription.Source); !ok {
+        problems = append(problems, fmt.Sprintf("settings.transcription.source %q is not a known transcription backend", project.Settings.Transcription.Source))
+    }
+    switch project.Settings.Translation.Mode {
+    case "simple", "advanced":
+    case "":
+        problems = append(problems, "settings.translation is missing")
+    default:
+        problems = append(problems, fmt.Sprintf("settings.translation.mode %q is not simple or advanced", project.Settings.Translation.Mode))
+    }
+    if project.Settings.Cleanup.Mode == "" {
+        problems = append(problems, "settings.cleanup is missing")
+    }
+
+    for i, rule := range project.TextRules {
+        if rule.ID == "" {
+            problems = append(problems, fmt.Sprintf("textRules[%d] has no id", i))
+        }
+        if rule.OriginalText == "" {
+            problems = append(problems, fmt.Sprintf("textRules[%d] has no originalText", i))
+        }
+    }
+    for i, rule := range project.SegmentRules {
+        if rule.ID == "" {
+            problems = append(problems, fmt.Sprintf("segmentRules[%d] has no id", i))
+        }
+        if rule.Type == "" {
+            problems = append(problems, fmt.Sprintf("segmentRules[%d] has no type", i))
+        }
+    }
+
+    if len(problems) > 0 {
+        return &ProjectValidationError{ProjectID: project.ID, Problems: problems}
+    }
+    return nil
+}
+
+// isKnownLanguage reports whether the language code is one a project can target
+func isKnownLanguage(code string) bool {
+    return containsString(knownLanguages, strings.ToLower(code))
+}
+
+// knownLanguages are the ISO 639-1 codes of the languages the local translation models and
+// cloud providers cover between them
+var knownLanguages = []string{
+    "af", "ar", "bg", "bn", "ca", "cs", "da", "de", "el", "en", "es", "et", "fa", "fi", "fr", "he",
+    "hi", "hr", "hu", "id", "it", "ja", "ko", "lt", "lv", "ms", "nb", "nl", "no", "pl", "pt", "ro",
+    "ru", "sk", "sl", "sr", "sv", "sw", "ta", "th", "tl", "tr", "uk", "ur", "vi", "zh",
+}
+
+// RepairProject fills in defaults for missing settings and rule fields of a project that
+// fails validation. Problems that can't be fixed safely (like an unknown target language)
+// are returned in the error.
+func (a *App) RepairProject(projectID string) (*ProjectConfig, error) {
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("project not found: %w", err)
+    }
+
+    project, err := readProjectConfig(projectDir)
+    if err != nil {
+        return nil, err
+    }
+
+    defaults := defaultProjectSettings()
+    settings := &project.Settings
+    if settings.Transcription.Source == "" {
+        language := settings.Transcription.Language
+        settings.Transcription = defaults.Transcription
+        if language != "" {
+            settings.Transcription.Language = language
+        }
+    }
+    if settings.Translation.Mode == "" {
+        glossary := settings.Translation.Glossary
+        settings.Translation = defaults.Translation
+        settings.Translation.Glossary = glossary
+    }
+    if settings.Audio == (AudioSettings{}) {
+        settings.Audio = defaults.Audio
+    }
+    if settings.Cleanup.Mode == "" {
+        settings.Cleanup = defaults.Cleanup
+    }
+    if settings.Output.FilenameTemplate == "" {
+        settings.Output.FilenameTemplate = defaults.Output.FilenameTemplate
+    }
+    if settings.Output.Format == "" {
+        settings.Output.Format = defaults.Output.Format
+    }
+
+    if !validSourceTypes[project.SourceType] {
+        switch {
+        case project.SourceUrl != nil && *project.SourceUrl != "":
+            project.SourceType = "youtube"
+        case project.FileReferences.VideoFile != nil:
+            project.SourceType = "video"
+        case project.FileReferences.AudioFile != nil:
+            project.SourceType = "audio"
+        }
+    }
+    if project.SourceType == "youtube" && project.Platform == "" && project.SourceUrl != nil {
+        project.Platform, _ = detectPlatform(*project.SourceUrl)
+    }
+
+    // Rules without text or type can't do anything; rules without an ID get one
+    now := time.Now().Format(time.RFC3339)
+    textRules := []TextRule{}
+    for _, rule := range project.TextRules {
+        if rule.OriginalText == "" {
+            continue
+        }
+        if rule.ID == "" {
+            if rule.ID, err = generateProjectID(); err != nil {
+                return nil, err
+            }
+            rule.CreatedAt = now
+        }
+        textRules = append(textRules, rule)
+    }
+    project.TextRules = textRules
+
+    segmentRules := []SegmentRule{}
+    for _, rule := range project.SegmentRules {
+        if rule.Type == "" {
+            continue
+        }
+        if rule.ID == "" {
+            if rule.ID, err = generateProjectID(); err != nil {
+                return nil, err
+            }
+            rule.CreatedAt = now
+        }
+        segmentRules = append(segmentRules, rule)
+    }
+    project.SegmentRules = segmentRules
+
+    if err := a.saveProjectConfig(projectDir, project); err != nil {
+        return nil, fmt.Errorf("failed to save repaired project: %w", err)
+    }
+
+    if err := validateProjectConfig(project); err != nil {
+        return project, err
+    }
+    return project, nil
+}
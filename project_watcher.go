@@ -0,0 +1,171 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/fsnotify/fsnotify"
+)
+
+const projectsChangedDebounce = 500 * time.Millisecond
+
+// projectWatcher emits "projects:changed" when project folders or project.json files change on disk
+type projectWatcher struct {
+    watcher *fsnotify.Watcher
+    root    string
+    done    chan struct{}
+    wg      sync.WaitGroup
+}
+
+// startProjectWatcher begins watching the projects root, replacing any running watcher
+func (a *App) startProjectWatcher() error {
+    settings, err := a.GetAppSettings()
+    if err != nil {
+        return fmt.Errorf("failed to get app settings: %w", err)
+    }
+
+    root := settings.DefaultProjectsPath
+    if err := os.MkdirAll(root, 0755); err != nil {
+        return fmt.Errorf("failed to create projects directory: %w", err)
+    }
+
+    watcher, err := fsnotify.NewWatcher()
+    if err != nil {
+        return fmt.Errorf("failed to create file watcher: %w", err)
+    }
+
+    if err := watcher.Add(root); err != nil {
+        watcher.Close()
+        return fmt.Errorf("failed to watch projects directory: %w", err)
+    }
+
+    // fsnotify is not recursive, so each project and category folder is watched for
+    // project.json changes, and each category's project folders too
+    if entries, err := os.ReadDir(root); err == nil {
+        for _, entry := range entries {
+            if entry.IsDir() {
+                watcher.Add(filepath.Join(root, entry.Name()))
+            }
+        }
+    }
+    if projectDirs, err := projectFolders(root); err == nil {
+        for _, projectDir := range projectDirs {
+            if filepath.Dir(projectDir) != root {
+                watcher.Add(projectDir)
+            }
+        }
+    }
+
+    a.stopProjectWatcher()
+
+    pw := &projectWatcher{
+        watcher: watcher,
+        root:    root,
+        done:    make(chan struct{}),
+    }
+    pw.wg.Add(1)
+    go a.runProjectWatcher(pw)
+
+    a.watcherMu.Lock()
+    a.watcher = pw
+    a.watcherMu.Unlock()
+
+    return nil
+}
+
+// stopProjectWatcher stops the running watcher, if any, and waits for it to exit
+func (a *App) stopProjectWatcher() {
+    a.watcherMu.Lock()
+    pw := a.watcher
+    a.watcher = nil
+    a.watcherMu.Unlock()
+
+    if pw == nil {
+        return
+    }
+
+    close(pw.done)
+    pw.watcher.Close()
+    pw.wg.Wait()
+}
+
+func (a *App) runProjectWatcher(pw *projectWatcher) {
+    defer pw.wg.Done()
+
+    var debounce *time.Timer
+    defer func() {
+        if debounce != nil {
+            debounce.Stop()
+        }
+    }()
+
+    for {
+        select {
+        case <-pw.done:
+            return
+        case event, ok := <-pw.watcher.Events:
+            if !ok {
+                return
+            }
+            if !pw.isRelevant(event) {
+                continue
+            }
+
+            // Watch newly created project and category folders too
+            if pw.depth(event.Name) <= 2 && event.Has(fsnotify.Create) {
+                if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+                    pw.watcher.Add(event.Name)
+                }
+            }
+
+            // Collapse bursts (e.g. a sync tool copying a whole folder) into one event
+            if debounce != nil {
+                debounce.Stop()
+            }
+            debounce = time.AfterFunc(projectsChangedDebounce, func() {
+                a.emitEvent("projects:changed")
+            })
+        case err, ok := <-pw.watcher.Errors:
+            if !ok {
+                return
+            }
+            fmt.Printf("Warning: project watcher error: %v\n", err)
+        }
+    }
+}
+
+// isRelevant reports whether an event is a project folder appearing/disappearing
+// or a project.json changing
+func (pw *projectWatcher) isRelevant(event fsnotify.Event) bool {
+    if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+        return false
+    }
+
+    depth := pw.depth(event.Name)
+    if filepath.Base(event.Name) == "project.json" && (depth == 2 || depth == 3) {
+        return true
+    }
+
+    // Project folders sit at the top level or inside a category folder (which, unlike a
+    // project folder, has no project.json of its own)
+    if depth == 2 && fileExists(filepath.Join(filepath.Dir(event.Name), "project.json")) {
+        return false
+    }
+    if depth == 1 || depth == 2 {
+        return event.Has(fsnotify.Create) || event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename)
+    }
+    return false
+}
+
+// depth returns how many path elements a watched path sits below the projects root
+func (pw *projectWatcher) depth(path string) int {
+    rel, err := filepath.Rel(pw.root, path)
+    if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+        return 0
+    }
+    return len(strings.Split(rel, string(filepath.Separator)))
+}
@@ -10,8 +10,8 @@ def check_video_exists(video_id: str, output_dir: str = None) -> Optional[str]:
     if output_dir is None:
         output_dir = os.path.join(project_root, "data", "audio_clips")
     
-    # Common video extensions that yt-dlp might download
-    extensions = ['mp4', 'webm', 'mkv', 'avi', 'mov', 'flv', 'm4v']
+    # Common video extensions that yt-dlp might download, then audio-only downloads
+    extensions = ['mp4', 'webm', 'mkv', 'avi', 'mov', 'flv', 'm4v', 'm4a', 'opus', 'mp3']
     
     for ext in extensions:
         video_path = os.path.join(output_dir, f"{video_id}.{ext}")
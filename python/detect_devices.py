@@ -0,0 +1,39 @@
+#!/usr/bin/env python3
+"""
+Report which inference devices the Python environment can use.
+Prints a single JSON object: {"devices": ["cpu", ...]}
+"""
+
+import sys
+import json
+import contextlib
+
+
+def available_devices():
+    devices = ["cpu"]
+    try:
+        import torch
+        if torch.cuda.is_available():
+            devices.append("cuda")
+        mps = getattr(torch.backends, "mps", None)
+        if mps is not None and mps.is_available():
+            devices.append("mps")
+    except Exception:
+        # Without torch only the CPU is usable
+        pass
+    return devices
+
+
+def main():
+    try:
+        # torch can print warnings to stdout; keep it off the JSON channel
+        with contextlib.redirect_stdout(sys.stderr):
+            devices = available_devices()
+        print(json.dumps({"devices": devices}))
+    except Exception as e:
+        print(json.dumps({"error": str(e)}))
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,68 @@
+#!/usr/bin/env python3
+"""
+Identify the spoken language of a media file from a short sample.
+Prints {"success": true, "language": "es", "confidence": 0.97}.
+"""
+
+import os
+import sys
+import json
+import subprocess
+import tempfile
+import contextlib
+
+SAMPLE_SECONDS = 30
+
+
+def media_duration(path: str) -> float:
+    try:
+        result = subprocess.run(
+            ["ffprobe", "-v", "error", "-show_entries", "format=duration",
+             "-of", "default=noprint_wrappers=1:nokey=1", path],
+            check=True, capture_output=True, text=True
+        )
+        return float(result.stdout.strip())
+    except Exception:
+        return 0.0
+
+
+def extract_sample(path: str, out_path: str):
+    """Take 30s of 16kHz mono audio, skipping intros where possible"""
+    duration = media_duration(path)
+    offset = min(duration * 0.1, 120.0) if duration > SAMPLE_SECONDS * 2 else 0.0
+    subprocess.run([
+        "ffmpeg", "-y", "-ss", f"{offset:.2f}", "-t", str(SAMPLE_SECONDS), "-i", path,
+        "-vn", "-ac", "1", "-ar", "16000", out_path
+    ], check=True, capture_output=True)
+
+
+def detect_language(path: str):
+    from faster_whisper import WhisperModel
+
+    with tempfile.TemporaryDirectory() as tmp:
+        sample_path = os.path.join(tmp, "sample.wav")
+        extract_sample(path, sample_path)
+
+        # A small model is plenty for language ID
+        model = WhisperModel("base", device="cpu", compute_type="int8")
+        _, info = model.transcribe(sample_path, beam_size=1)
+        return info.language, float(info.language_probability)
+
+
+def main():
+    if len(sys.argv) < 2:
+        print(json.dumps({"success": False, "error": "missing media path argument"}))
+        sys.exit(1)
+
+    try:
+        # Model loading prints to stdout; keep it off the JSON channel
+        with contextlib.redirect_stdout(sys.stderr):
+            language, confidence = detect_language(sys.argv[1])
+        print(json.dumps({"success": True, "language": language, "confidence": confidence}))
+    except Exception as e:
+        print(json.dumps({"success": False, "error": str(e)}))
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
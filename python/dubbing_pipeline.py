@@ -40,6 +40,31 @@ except ImportError:
 except Exception as e:
     print(f"⚠️ Could not load .env file: {e}")
 
+def load_pipeline_config_file(path: str) -> Dict:
+    """Load the run configuration written by the GUI and apply it to config"""
+    with open(path, 'r', encoding='utf-8') as f:
+        run_config = json.load(f)
+
+    # The download and transcription helpers find their directories through the
+    # environment, so set it as well as config
+    directory_keys = {
+        "videoOutputDir": ("video_output_dir", "KOKORO_VIDEO_OUTPUT_DIR"),
+        "audioOutputDir": ("audio_output_dir", "KOKORO_AUDIO_OUTPUT_DIR"),
+        "transcriptOutputDir": ("transcript_output_dir", "KOKORO_TRANSCRIPT_OUTPUT_DIR"),
+    }
+    for key, (config_key, env_var) in directory_keys.items():
+        if run_config.get(key):
+            config[config_key] = run_config[key]
+            os.environ[env_var] = run_config[key]
+
+    # Rules passed by the GUI take precedence over the rules file
+    config["pipeline_rules"] = {
+        "textRules": run_config.get("textRules") or [],
+        "segmentRules": run_config.get("segmentRules") or [],
+        "audioSettings": run_config.get("audioSettings") or {},
+    }
+    return run_config
+
 # Override config directories with environment variables if provided
 if os.getenv("KOKORO_VIDEO_OUTPUT_DIR"):
     config["video_output_dir"] = os.getenv("KOKORO_VIDEO_OUTPUT_DIR")
@@ -489,8 +514,17 @@ def main(youtube_url_or_id: str, target_lang: str = "es"):
 if __name__ == "__main__":
     if len(sys.argv) < 2:
         print("Usage: python dubbing_pipeline.py <YouTube_URL_or_ID> [target_lang_code]")
+        print("       python dubbing_pipeline.py --config <run_config.json>")
         sys.exit(1)
 
-    url = sys.argv[1]
-    target_lang = sys.argv[2] if len(sys.argv) > 2 else "es"
+    if sys.argv[1] == "--config":
+        if len(sys.argv) < 3:
+            print("❌ --config requires a path to a run configuration file")
+            sys.exit(1)
+        run_config = load_pipeline_config_file(sys.argv[2])
+        url = run_config.get("videoUrl", "")
+        target_lang = run_config.get("targetLang") or "es"
+    else:
+        url = sys.argv[1]
+        target_lang = sys.argv[2] if len(sys.argv) > 2 else "es"
     main(url, target_lang)
\ No newline at end of file
@@ -0,0 +1,65 @@
+#!/usr/bin/env python3
+"""
+List the Kokoro voices available for synthesis with their language and gender.
+Prints a single JSON object: {"voices": [{"id": ..., "language": ..., "gender": ...}, ...]}
+"""
+
+import sys
+import json
+import contextlib
+
+from supported_languages import KOKORO_VOICE_PREFIXES
+
+# Voices shipped with Kokoro v1.0, used when the Kokoro server isn't running
+KOKORO_DEFAULT_VOICES = [
+    "af_heart", "af_alloy", "af_aoede", "af_bella", "af_jessica", "af_kore", "af_nicole",
+    "af_nova", "af_river", "af_sarah", "af_sky", "am_adam", "am_echo", "am_eric", "am_fenrir",
+    "am_liam", "am_michael", "am_onyx", "am_puck", "am_santa",
+    "bf_alice", "bf_emma", "bf_isabella", "bf_lily", "bm_daniel", "bm_fable", "bm_george", "bm_lewis",
+    "jf_alpha", "jf_gongitsune", "jf_nezumi", "jf_tebukuro", "jm_kumo",
+    "zf_xiaobei", "zf_xiaoni", "zf_xiaoxiao", "zf_xiaoyi", "zm_yunjian", "zm_yunxi", "zm_yunxia", "zm_yunyang",
+    "ef_dora", "em_alex", "em_santa",
+    "ff_siwis",
+    "hf_alpha", "hf_beta", "hm_omega", "hm_psi",
+    "if_sara", "im_nicola",
+    "pf_dora", "pm_alex", "pm_santa",
+]
+
+GENDERS = {"f": "female", "m": "male"}
+
+
+def kokoro_voices():
+    try:
+        import requests
+        from config import config
+        response = requests.get(f"{config['kokoro_endpoint']}/v1/audio/voices", timeout=5)
+        if response.status_code == 200:
+            voices = [v for v in response.json().get("voices", []) if isinstance(v, str)]
+            if voices:
+                return voices
+    except Exception:
+        pass
+    return KOKORO_DEFAULT_VOICES
+
+
+def describe(voice: str) -> dict:
+    return {
+        "id": voice,
+        "language": KOKORO_VOICE_PREFIXES.get(voice[:1], ""),
+        "gender": GENDERS.get(voice[1:2], ""),
+    }
+
+
+def main():
+    try:
+        # Backends print setup messages to stdout; keep it off the JSON channel
+        with contextlib.redirect_stdout(sys.stderr):
+            voices = [describe(v) for v in sorted(kokoro_voices())]
+        print(json.dumps({"voices": voices}))
+    except Exception as e:
+        print(json.dumps({"error": str(e)}))
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,71 @@
+#!/usr/bin/env python3
+"""
+Download the models a project needs ahead of a pipeline run, e.g. to prepare for offline use.
+Takes a JSON list of models ({"id", "source", "purpose"}) as its argument, reports progress
+with the PROGRESS protocol and prints a single JSON result object last.
+"""
+
+import os
+import sys
+import json
+
+from util.progress import report_progress
+
+# torchaudio bundles behind the alignment checkpoints WhisperX uses
+TORCH_ALIGN_BUNDLES = {
+    "wav2vec2_fairseq_base_ls960_asr_ls960.pth": "WAV2VEC2_ASR_BASE_960H",
+    "wav2vec2_voxpopuli_base_10k_asr_fr.pt": "VOXPOPULI_ASR_BASE_10K_FR",
+    "wav2vec2_voxpopuli_base_10k_asr_de.pt": "VOXPOPULI_ASR_BASE_10K_DE",
+    "wav2vec2_voxpopuli_base_10k_asr_es.pt": "VOXPOPULI_ASR_BASE_10K_ES",
+    "wav2vec2_voxpopuli_base_10k_asr_it.pt": "VOXPOPULI_ASR_BASE_10K_IT",
+}
+
+
+def download_model(model: dict):
+    source = model.get("source")
+    model_id = model.get("id", "")
+
+    if source == "huggingface":
+        from huggingface_hub import snapshot_download
+        # pyannote's diarization models are gated behind an access token
+        token = os.getenv("HF_TOKEN") or os.getenv("HUGGINGFACE_TOKEN") or None
+        snapshot_download(repo_id=model_id, token=token)
+    elif source == "torch":
+        import torchaudio
+        bundle_name = TORCH_ALIGN_BUNDLES.get(model_id)
+        if not bundle_name:
+            raise ValueError(f"Unknown alignment checkpoint: {model_id}")
+        getattr(torchaudio.pipelines, bundle_name).get_model()
+    else:
+        raise ValueError(f"Unknown model source: {source}")
+
+
+def main():
+    if len(sys.argv) < 2:
+        print(json.dumps({"success": False, "error": "missing models argument"}))
+        sys.exit(1)
+
+    try:
+        models = json.loads(sys.argv[1])
+    except Exception as e:
+        print(json.dumps({"success": False, "error": f"invalid models argument: {e}"}))
+        sys.exit(1)
+
+    failed = []
+    for i, model in enumerate(models):
+        model_id = model.get("id", "")
+        report_progress("models", i * 100 / max(len(models), 1), f"Downloading {model_id}")
+        try:
+            download_model(model)
+            print(f"✅ Downloaded {model_id}")
+        except Exception as e:
+            print(f"❌ Failed to download {model_id}: {e}")
+            failed.append({"id": model_id, "error": str(e)})
+
+    report_progress("models", 100, "Models ready" if not failed else "Some models failed to download")
+    print(json.dumps({"success": not failed, "failed": failed}))
+    sys.exit(1 if failed else 0)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,80 @@
+#!/usr/bin/env python3
+"""
+Translate a single piece of text with a project's translation settings.
+Used by the GUI to sanity-check a model/provider before running the translate step.
+"""
+
+import sys
+import json
+import contextlib
+
+ERROR_MARKERS = ("[API ERROR]", "[NETWORK ERROR]", "[TRANSLATION ERROR]")
+
+
+def translate_with_provider(text: str, target_lang: str, provider: str, glossary: list) -> str:
+    from util.translation_service import TranslationService
+    from config import config
+
+    service_config = dict(config)
+    service_config["target_language"] = target_lang
+    service_config["translation_glossary"] = glossary
+    service_config["translation_provider"] = provider
+
+    translator = TranslationService(config=service_config)
+    translation = translator.translate_single_text(text, target_lang)
+    if translation in ERROR_MARKERS:
+        raise RuntimeError(f"{translator.provider} API request failed ({translation.strip('[]').lower()})")
+    return translation
+
+
+def translate_with_m2m100(text: str, source_lang: str, target_lang: str, model: str) -> str:
+    from transformers import M2M100ForConditionalGeneration, M2M100Tokenizer
+
+    model_name = {
+        "m2m100_418m": "facebook/m2m100_418M",
+        "m2m100_1.2b": "facebook/m2m100_1.2B",
+    }.get(model, model)
+
+    tokenizer = M2M100Tokenizer.from_pretrained(model_name)
+    translator = M2M100ForConditionalGeneration.from_pretrained(model_name)
+
+    tokenizer.src_lang = source_lang
+    encoded = tokenizer(text, return_tensors="pt")
+    generated = translator.generate(**encoded, forced_bos_token_id=tokenizer.get_lang_id(target_lang))
+    return tokenizer.batch_decode(generated, skip_special_tokens=True)[0]
+
+
+def preview_translation(request: dict) -> str:
+    text = request.get("text", "")
+    target_lang = request.get("targetLanguage", "es")
+    source_lang = request.get("sourceLanguage", "en")
+    settings = request.get("translation", {}) or {}
+
+    mode = settings.get("mode", "simple")
+    provider = (settings.get("cloudProvider") or "").lower()
+
+    if mode == "simple" and not provider:
+        return translate_with_m2m100(text, source_lang, target_lang, settings.get("simpleModel", "m2m100_418m"))
+    return translate_with_provider(text, target_lang, provider or "anthropic", settings.get("glossary") or [])
+
+
+def main():
+    if len(sys.argv) < 2:
+        print(json.dumps({"success": False, "error": "missing request argument"}))
+        sys.exit(1)
+
+    try:
+        request = json.loads(sys.argv[1])
+
+        # Backends print progress to stdout; keep it off the JSON channel
+        with contextlib.redirect_stdout(sys.stderr):
+            translation = preview_translation(request)
+
+        print(json.dumps({"success": True, "translation": translation}, ensure_ascii=False))
+    except Exception as e:
+        print(json.dumps({"success": False, "error": str(e)}, ensure_ascii=False))
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
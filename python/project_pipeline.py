@@ -17,15 +17,22 @@ from dataclasses import asdict
 import logging
 import re
 
-# Setup logging
-logging.basicConfig(level=logging.INFO, format='%(asctime)s - %(levelname)s - %(message)s')
+# Setup logging at the level chosen in the app's settings (KOKORO_LOG_LEVEL). "verbose"
+# shows the pipeline's own debug output; "debug" also that of the libraries it uses.
+LOG_LEVELS = {"quiet": logging.WARNING, "normal": logging.INFO, "verbose": logging.DEBUG, "debug": logging.DEBUG}
+log_level_name = os.getenv("KOKORO_LOG_LEVEL", "normal").strip().lower()
+logging.basicConfig(level=logging.INFO if log_level_name == "verbose" else LOG_LEVELS.get(log_level_name, logging.INFO),
+                    format='%(asctime)s - %(levelname)s - %(message)s')
 logger = logging.getLogger(__name__)
+logger.setLevel(LOG_LEVELS.get(log_level_name, logging.INFO))
 
 # Import the original pipeline components
 try:
     from util.download_video import download_video
     from util.fetch_transcript import fetch_transcript
     from util.transcribe_with_whisperx import transcribe_with_whisperx
+    from util.transcribe_with_faster_whisper import transcribe_with_faster_whisper
+    from util.transcribe_with_openai import transcribe_with_openai
     from util.normalize.normalize_whisperx_segments import normalize_whisperx_segments
     from util.translation_service import TranslationService
     from util.text_chunks_to_audio import text_chunks_to_audio
@@ -39,11 +46,19 @@ try:
     from checks.load_existing_segments_if_available import load_existing_segments_if_available
     from sync.create_enhanced_audio_track_with_loose_sync import create_enhanced_audio_track_with_loose_sync
     from structs.DubSegment import DubSegment
+    from util.progress import report_progress
     from config import config
 except ImportError as e:
     logger.warning(f"Could not import original pipeline components: {e}")
     logger.info("Running in standalone mode - some features may be limited")
 
+# ffmpeg encoder arguments for audio-only combine output
+AUDIO_OUTPUT_FORMATS = {
+    "mp3": ["-c:a", "libmp3lame", "-b:a", "192k"],
+    "wav": ["-c:a", "pcm_s16le"],
+    "flac": ["-c:a", "flac"],
+}
+
 def extract_video_id(url_or_id: str) -> str:
     """Extract YouTube video ID from URL or return ID if already provided"""
     # If it's already a YouTube ID (11 chars, alphanumeric, typical format)
@@ -72,9 +87,13 @@ class ProjectPipeline:
         self.audio_dir = self.project_dir / "audio"
         self.output_dir = self.project_dir / "output"
         
+        # Final outputs can be routed elsewhere (e.g. another drive) per project
+        if self.project_config.get("outputDir"):
+            self.output_dir = Path(self.project_config["outputDir"])
+        
         # Ensure directories exist
         for dir_path in [self.input_dir, self.transcripts_dir, self.audio_dir, self.output_dir]:
-            dir_path.mkdir(exist_ok=True)
+            dir_path.mkdir(parents=True, exist_ok=True)
             
         # Load environment variables
         self.load_environment()
@@ -109,6 +128,13 @@ class ProjectPipeline:
         with open(self.project_config_path, 'r', encoding='utf-8') as f:
             return json.load(f)
     
+    def project_file_reference(self, path: Path) -> str:
+        """Path as stored in fileReferences: relative inside the project, absolute outside it"""
+        try:
+            return Path(path).relative_to(self.project_dir).as_posix()
+        except ValueError:
+            return str(path)
+    
     def save_project_config(self):
         """Save updated project configuration"""
         with open(self.project_config_path, 'w', encoding='utf-8') as f:
@@ -119,7 +145,9 @@ class ProjectPipeline:
         if "completedSteps" not in self.project_config:
             self.project_config["completedSteps"] = {}
         
-        self.project_config["completedSteps"][step] = completed
+        # Local files import instead of downloading; both are recorded as the download step
+        key = "download" if step == "import" else step
+        self.project_config["completedSteps"][key] = completed
         self.project_config["lastModified"] = self.get_current_timestamp()
         self.save_project_config()
         
@@ -142,6 +170,38 @@ class ProjectPipeline:
         """Get source URL from project config"""
         return self.project_config.get("sourceUrl", "")
     
+    def get_translation_settings(self) -> Dict[str, Any]:
+        """Get translation settings, preferring the structured copy passed by the app"""
+        env_settings = os.getenv("TRANSLATION_SETTINGS")
+        if env_settings:
+            try:
+                return json.loads(env_settings)
+            except json.JSONDecodeError as e:
+                logger.warning(f"⚠️ Could not parse TRANSLATION_SETTINGS: {e}")
+        return self.project_config.get("settings", {}).get("translation", {})
+    
+    def get_translation_config(self) -> Dict[str, Any]:
+        """Build the TranslationService config from the project's translation settings"""
+        service_config = dict(config) if 'config' in globals() else {}
+        service_config["target_language"] = self.get_target_language()
+        
+        settings = self.get_translation_settings()
+        service_config["translation_glossary"] = settings.get("glossary") or []
+        if settings.get("cloudProvider"):
+            service_config["translation_provider"] = settings["cloudProvider"]
+        if settings.get("mode") == "advanced":
+            advanced = settings.get("advancedSettings") or {}
+            service_config["translation_context_size"] = advanced.get("contextWindow", service_config.get("translation_context_size", 3))
+            service_config["llm_judge_enabled"] = advanced.get("enableJudge", False)
+            models = advanced.get("models") or []
+            if models:
+                service_config["translation_models"] = models
+        return service_config
+    
+    def downloaded_media_role(self) -> str:
+        """File reference a URL download is recorded under: audioFile for audio-only downloads"""
+        return "audioFile" if os.getenv("DOWNLOAD_AUDIO_ONLY") == "1" else "videoFile"
+    
     def step_download(self) -> Dict[str, Any]:
         """Step 1: Download/Import Media"""
         logger.info("🎬 Starting download step...")
@@ -151,30 +211,30 @@ class ProjectPipeline:
         try:
             if source_type == "youtube":
                 source_url = self.get_source_url()
-                video_id = extract_video_id(source_url)
+                # Other platforms' URLs carry no YouTube ID; the app assigned one when
+                # creating the project
+                video_id = extract_video_id(source_url) if source_url else None
+                if not video_id:
+                    video_id = self.project_config.get("videoId")
                 
                 if not source_url or not video_id:
-                    raise ValueError("No valid YouTube URL found in project config")
+                    raise ValueError("No valid video URL found in project config")
                 
                 logger.info(f"🎥 Extracted video ID: {video_id}")
                 
                 # Use the actual download_video function
                 if 'download_video' in globals():
-                    # Update config to use project directories
-                    original_video_dir = config.get("video_output_dir", "videos")
-                    config["video_output_dir"] = str(self.input_dir)
-                    
-                    video_path = download_video(source_url, video_id)
-                    
-                    # Restore original config
-                    config["video_output_dir"] = original_video_dir
+                    # Download into the project; the WAV download_video extracts alongside
+                    # goes where transcription looks for it
+                    os.environ["KOKORO_AUDIO_OUTPUT_DIR"] = str(self.transcripts_dir)
+                    video_path = download_video(source_url, video_id, output_dir=str(self.input_dir))
                     
                     if not video_path:
                         raise Exception("Could not download video file")
                     
                     # Update file reference in project config
                     video_filename = os.path.basename(video_path)
-                    self.project_config["fileReferences"]["videoFile"] = {
+                    self.project_config["fileReferences"][self.downloaded_media_role()] = {
                         "path": f"input/{video_filename}",
                         "isLinked": False,
                         "size": os.path.getsize(video_path) if os.path.exists(video_path) else 0,
@@ -189,22 +249,29 @@ class ProjectPipeline:
                     }
                 else:
                     # Fallback: use yt-dlp directly
-                    video_filename = f"{video_id}.mp4"
+                    audio_only = self.downloaded_media_role() == "audioFile"
+                    video_filename = f"{video_id}.{'m4a' if audio_only else 'mp4'}"
                     video_path = self.input_dir / video_filename
                     
+                    # --continue resumes a .part file left by an interrupted run; progress
+                    # goes to stderr, where the app turns it into download:progress events
                     cmd = [
                         "yt-dlp",
-                        "-f", "best[ext=mp4]",
+                        "-f", os.getenv("DOWNLOAD_FORMAT", "").strip() or "best[ext=mp4]",
+                        "--continue", "--progress",
                         "-o", str(video_path),
-                        source_url
                     ]
+                    rate_limit = os.getenv("DOWNLOAD_RATE_LIMIT", "").strip()
+                    if rate_limit:
+                        cmd += ["--limit-rate", rate_limit]
+                    cmd.append(source_url)
                     
-                    subprocess.run(cmd, check=True, capture_output=True)
+                    subprocess.run(cmd, check=True, stdout=sys.stderr)
                     
                     if not video_path.exists():
                         raise Exception("Video download failed")
                     
-                    self.project_config["fileReferences"]["videoFile"] = {
+                    self.project_config["fileReferences"][self.downloaded_media_role()] = {
                         "path": f"input/{video_filename}",
                         "isLinked": False,
                         "size": video_path.stat().st_size,
@@ -266,6 +333,87 @@ class ProjectPipeline:
                 "message": f"❌ Download failed: {e}"
             }
     
+    def step_import(self) -> Dict[str, Any]:
+        """Step 1 for local files: check the linked or copied source and extract its audio"""
+        logger.info("📥 Starting import step...")
+        
+        source_type = self.project_config.get("sourceType")
+        
+        try:
+            if source_type not in ["video", "audio"]:
+                raise ValueError(f"Only local files are imported; {source_type} sources are downloaded")
+            
+            result = self.step_download()
+            if not result.get("success"):
+                return result
+            
+            # A trimmed project is clipped by the app after this step, and transcription
+            # extracts from the clip instead
+            if self.project_config.get("trimStart") is None and self.project_config.get("trimEnd") is None:
+                self.extract_transcription_audio(self.get_video_id())
+            
+            result["message"] = f"✅ Imported {os.path.basename(result['videoPath'])}"
+            self.update_step_completion("import", True)
+            return result
+            
+        except Exception as e:
+            logger.error(f"❌ Import step failed: {e}")
+            self.update_step_completion("import", False)
+            return {
+                "success": False,
+                "error": str(e),
+                "message": f"❌ Import failed: {e}"
+            }
+    
+    def source_media_path(self) -> Optional[Path]:
+        """Absolute path of the project's source video or audio, if any"""
+        refs = self.project_config.get("fileReferences", {})
+        # The app clips the source to the project's trim range after download
+        if refs.get("trimmedFile"):
+            return self.project_dir / refs["trimmedFile"]
+        # A video attached to an audio project is only used when combining
+        if self.project_config.get("attachedVideo"):
+            file_ref = refs.get("audioFile")
+        else:
+            file_ref = refs.get("videoFile") or refs.get("audioFile")
+        if not file_ref:
+            return None
+        path = Path(file_ref["path"])
+        return path if file_ref.get("isLinked") or path.is_absolute() else self.project_dir / path
+    
+    def extract_transcription_audio(self, video_id: str):
+        """Extract the chosen audio track of the source as 16kHz mono WAV for transcription"""
+        source_path = self.source_media_path()
+        if not source_path or not source_path.exists():
+            return
+        
+        stream = self.project_config.get("settings", {}).get("transcription", {}).get("sourceAudioStream") or 0
+        wav_path = self.transcripts_dir / f"{video_id}.wav"
+        marker_path = self.transcripts_dir / f"{video_id}.wav.stream"
+        
+        # Re-extract when a different track or trim range was chosen since the last run
+        marker = f"{stream}|{source_path}|{source_path.stat().st_mtime_ns}"
+        if wav_path.exists() and marker_path.exists() and marker_path.read_text().strip() == marker:
+            return
+        
+        logger.info(f"🎧 Extracting audio stream {stream} for transcription...")
+        subprocess.run([
+            "ffmpeg", "-y", "-i", str(source_path),
+            "-map", f"0:a:{stream}", "-vn", "-ac", "1", "-ar", "16000",
+            str(wav_path)
+        ], check=True, capture_output=True)
+        marker_path.write_text(marker)
+    
+    def transcription_settings(self) -> Dict[str, Any]:
+        """Transcription settings passed by the app, else the ones in project.json"""
+        raw = os.getenv("TRANSCRIPTION_SETTINGS", "").strip()
+        if raw:
+            try:
+                return json.loads(raw)
+            except json.JSONDecodeError:
+                logger.warning("Ignoring malformed TRANSCRIPTION_SETTINGS")
+        return self.project_config.get("settings", {}).get("transcription", {})
+    
     def step_transcribe(self) -> Dict[str, Any]:
         """Step 2: Generate Transcript"""
         logger.info("🎤 Starting transcription step...")
@@ -278,6 +426,9 @@ class ProjectPipeline:
             original_transcript_dir = config.get("transcript_output_dir", "transcripts")
             config["transcript_output_dir"] = str(self.transcripts_dir)
             
+            # WhisperX picks up {video_id}.wav from the transcripts folder
+            self.extract_transcription_audio(video_id)
+            
             force_whisperx = config.get("force_whisperx", True)
             transcript = None
             
@@ -287,9 +438,21 @@ class ProjectPipeline:
                 if transcript:
                     logger.info("✅ Found existing human transcript")
             
-            if not transcript and 'transcribe_with_whisperx' in globals():
+            # The app picks the backend and passes its settings; run by hand, fall back
+            # to WhisperX with the project's settings
+            backend = os.getenv("TRANSCRIPTION_BACKEND", "").strip() or "whisperx"
+            transcription_settings = self.transcription_settings()
+            audio_file = str(self.transcripts_dir / f"{video_id}.wav")
+            
+            if not transcript and backend == "faster-whisper":
+                logger.info("⚙️ Transcribing with faster-whisper...")
+                transcript = transcribe_with_faster_whisper(audio_file, str(self.transcripts_dir), video_id, transcription_settings)
+            elif not transcript and backend == "openai":
+                logger.info("⚙️ Transcribing with OpenAI...")
+                transcript = transcribe_with_openai(audio_file, str(self.transcripts_dir), video_id, transcription_settings)
+            elif not transcript and 'transcribe_with_whisperx' in globals():
                 logger.info("⚙️ Transcribing with WhisperX...")
-                transcript = transcribe_with_whisperx(video_id, output_dir=str(self.transcripts_dir), mode="whisperx")
+                transcript = transcribe_with_whisperx(video_id, output_dir=str(self.transcripts_dir), mode="whisperx", settings=transcription_settings)
             
             if not transcript:
                 raise Exception("Could not obtain transcript data")
@@ -315,88 +478,7 @@ class ProjectPipeline:
                 json.dump(segment_data, f, indent=2, ensure_ascii=False)
             
             # Update project config
-            self.project_config["fileReferences"]["finalAudio"] = f"audio/{final_audio_path.name}"
-            self.project_config["fileReferences"]["finalVideo"] = f"output/{final_video_path.name}"
-            
-            result = {
-                "success": True,
-                "finalVideoPath": str(final_video_path),
-                "finalAudioPath": str(final_audio_path),
-                "videoDuration": total_duration,
-                "message": f"✅ Final video created: {final_video_path.name}"
-            }
-            
-            self.update_step_completion("combine", True)
-            return result
-            
-        except Exception as e:
-            logger.error(f"❌ Final assembly step failed: {e}")
-            self.update_step_completion("combine", False)
-            return {
-                "success": False,
-                "error": str(e),
-                "message": f"❌ Final assembly failed: {e}"
-            }
-
-def setup_project_config():
-    """Setup project-specific config overrides"""
-    if 'config' not in globals():
-        return
-        
-    # These will be overridden by the project pipeline as needed
-    project_config_defaults = {
-        "force_whisperx": True,
-        "audio_effects_preset": "voice",
-        "target_language": "es"
-    }
-    
-    for key, value in project_config_defaults.items():
-        if key not in config:
-            config[key] = value
-
-def main():
-    parser = argparse.ArgumentParser(description="VoiceWeave Studio Project Pipeline")
-    parser.add_argument("project_dir", help="Project directory path")
-    parser.add_argument("step", choices=["download", "transcribe", "translate", "synthesize", "combine"], 
-                       help="Pipeline step to execute")
-    
-    args = parser.parse_args()
-    
-    try:
-        # Setup config defaults
-        setup_project_config()
-        
-        pipeline = ProjectPipeline(args.project_dir)
-        
-        # Execute the requested step
-        if args.step == "download":
-            result = pipeline.step_download()
-        elif args.step == "transcribe":
-            result = pipeline.step_transcribe()
-        elif args.step == "translate":
-            result = pipeline.step_translate()
-        elif args.step == "synthesize":
-            result = pipeline.step_synthesize()
-        elif args.step == "combine":
-            result = pipeline.step_combine()
-        
-        # Output result as JSON for Go to parse
-        print(json.dumps(result, indent=2))
-        
-        # Exit with appropriate code
-        sys.exit(0 if result["success"] else 1)
-        
-    except Exception as e:
-        error_result = {
-            "success": False,
-            "error": str(e),
-            "message": f"❌ Pipeline failed: {e}"
-        }
-        print(json.dumps(error_result, indent=2))
-        sys.exit(1)
-
-if __name__ == "__main__":
-    main()fileReferences"]["segmentsFile"] = f"transcripts/{video_id}_segments.json"
+            self.project_config["fileReferences"]["segmentsFile"] = f"transcripts/{video_id}_segments.json"
             
             # Restore original config
             config["transcript_output_dir"] = original_transcript_dir
@@ -459,16 +541,38 @@ if __name__ == "__main__":
             else:
                 segments = segment_data
             
+            # The app can ask for just a few segments to be re-translated
+            selected_indices = None
+            if os.getenv("RETRANSLATE_SEGMENTS"):
+                selected_indices = set(json.loads(os.environ["RETRANSLATE_SEGMENTS"]))
+            
             # Check if we need translation
             needs_translation = True
-            if isinstance(segments[0], dict):
+            if selected_indices is not None:
+                needs_translation = False
+                for i in selected_indices:
+                    if isinstance(segments[i], dict):
+                        segments[i]['translated_text'] = ''
+                    else:
+                        segments[i].translated_text = ''
+            elif isinstance(segments[0], dict):
                 needs_translation = any(not seg.get('translated_text', '') for seg in segments)
             else:
                 needs_translation = any(not getattr(seg, 'translated_text', '') for seg in segments)
             
-            if needs_translation and 'TranslationService' in globals():
+            if selected_indices is not None and 'TranslationService' in globals() and not isinstance(segments[0], dict):
+                translator = TranslationService(config=self.get_translation_config())
+                segments = translator.translate_selected_segments(segments, list(selected_indices))
+            elif selected_indices is not None:
+                logger.warning("TranslationService not available, using placeholder translation")
+                for i in selected_indices:
+                    if isinstance(segments[i], dict):
+                        segments[i]['translated_text'] = f"[{target_lang.upper()}] {segments[i].get('original_text', '')}"
+                    else:
+                        segments[i].translated_text = f"[{target_lang.upper()}] {getattr(segments[i], 'original_text', '')}"
+            elif needs_translation and 'TranslationService' in globals():
                 # Use the actual translation service
-                translator = TranslationService(config=config if 'config' in globals() else {})
+                translator = TranslationService(config=self.get_translation_config())
                 segments = translator.translate_segments(segments)
                 logger.info(f"✅ Translated segments using TranslationService")
             elif needs_translation:
@@ -488,7 +592,10 @@ if __name__ == "__main__":
                 text_rules = dubbing_rules.get("textRules", [])
                 if text_rules:
                     logger.info("📝 Applying text replacement rules...")
-                    for segment in segments:
+                    for i, segment in enumerate(segments):
+                        # Segments that weren't re-translated already have the rules applied
+                        if selected_indices is not None and i not in selected_indices:
+                            continue
                         if isinstance(segment, dict):
                             if segment.get('translated_text'):
                                 segment['translated_text'] = apply_text_rules(
@@ -687,11 +794,14 @@ if __name__ == "__main__":
                 segments = segment_data
             
             # Get video file path
-            video_file_ref = self.project_config["fileReferences"].get("videoFile")
+            # Audio-only downloads have no video; the audio still gives the timeline length
+            video_file_ref = self.project_config["fileReferences"].get("videoFile") or self.project_config["fileReferences"].get("audioFile")
             if not video_file_ref:
                 raise ValueError("No video file reference found")
             
-            if video_file_ref["isLinked"]:
+            if self.project_config["fileReferences"].get("trimmedFile") and not self.project_config.get("attachedVideo"):
+                video_path = str(self.source_media_path())
+            elif video_file_ref["isLinked"]:
                 video_path = video_file_ref["path"]
             else:
                 video_path = str(self.project_dir / video_file_ref["path"])
@@ -765,38 +875,61 @@ if __name__ == "__main__":
                         final_audio_path = effects_audio_path
                         logger.info(f"🎵 Audio effects applied: {preset}")
             
-            # Create final video
-            final_video_path = self.output_dir / f"{video_id}_final.mp4"
+            # The app renders the user's filename template and passes the result in
+            output_basename = os.getenv("OUTPUT_BASENAME") or f"{video_id}_final"
+            output_format = (os.getenv("OUTPUT_FORMAT") or "mp4").lower()
             
-            if 'merge_audio_with_video' in globals():
-                merge_audio_with_video(video_path, str(final_audio_path), str(final_video_path))
-                logger.info(f"🎬 Final video created: {final_video_path}")
-            else:
-                # Fallback: use ffmpeg directly
-                cmd = [
-                    "ffmpeg", "-y",
-                    "-i", video_path,
-                    "-i", str(final_audio_path),
-                    "-c:v", "copy",
-                    "-c:a", "aac",
-                    "-map", "0:v:0",
-                    "-map", "1:a:0",
-                    str(final_video_path)
-                ]
+            if output_format in AUDIO_OUTPUT_FORMATS:
+                # Audio-only output: transcode the dubbed track, no video muxing
+                final_output_path = self.output_dir / f"{output_basename}.{output_format}"
+                cmd = ["ffmpeg", "-y", "-i", str(final_audio_path)]
+                cmd += AUDIO_OUTPUT_FORMATS[output_format]
+                cmd.append(str(final_output_path))
                 subprocess.run(cmd, check=True, capture_output=True)
-                logger.info(f"🎬 Final video created: {final_video_path}")
-            
-            # Update project config
-            self.project_config["fileReferences"]["finalAudio"] = f"audio/{final_audio_path.name}"
-            self.project_config["fileReferences"]["finalVideo"] = f"output/{final_video_path.name}"
-            
-            result = {
-                "success": True,
-                "finalVideoPath": str(final_video_path),
-                "finalAudioPath": str(final_audio_path),
-                "videoDuration": total_duration,
-                "message": f"✅ Final video created: {final_video_path.name}"
-            }
+                logger.info(f"🎵 Final audio created: {final_output_path}")
+                
+                self.project_config["fileReferences"]["finalAudio"] = self.project_file_reference(final_output_path)
+                self.project_config["fileReferences"].pop("finalVideo", None)
+                
+                result = {
+                    "success": True,
+                    "finalAudioPath": str(final_output_path),
+                    "videoDuration": total_duration,
+                    "message": f"✅ Final audio created: {final_output_path.name}"
+                }
+            else:
+                # Create final video
+                final_video_path = self.output_dir / f"{output_basename}.{output_format}"
+                
+                if 'merge_audio_with_video' in globals() and output_format == "mp4":
+                    merge_audio_with_video(video_path, str(final_audio_path), str(final_video_path))
+                    logger.info(f"🎬 Final video created: {final_video_path}")
+                else:
+                    # Use ffmpeg directly; the container follows the file extension
+                    cmd = [
+                        "ffmpeg", "-y",
+                        "-i", video_path,
+                        "-i", str(final_audio_path),
+                        "-c:v", "copy",
+                        "-c:a", "aac",
+                        "-map", "0:v:0",
+                        "-map", "1:a:0",
+                        str(final_video_path)
+                    ]
+                    subprocess.run(cmd, check=True, capture_output=True)
+                    logger.info(f"🎬 Final video created: {final_video_path}")
+                
+                # Update project config
+                self.project_config["fileReferences"]["finalAudio"] = f"audio/{final_audio_path.name}"
+                self.project_config["fileReferences"]["finalVideo"] = self.project_file_reference(final_video_path)
+                
+                result = {
+                    "success": True,
+                    "finalVideoPath": str(final_video_path),
+                    "finalAudioPath": str(final_audio_path),
+                    "videoDuration": total_duration,
+                    "message": f"✅ Final video created: {final_video_path.name}"
+                }
             
             self.update_step_completion("combine", True)
             return result
@@ -829,7 +962,7 @@ def setup_project_config():
 def main():
     parser = argparse.ArgumentParser(description="VoiceWeave Studio Project Pipeline")
     parser.add_argument("project_dir", help="Project directory path")
-    parser.add_argument("step", choices=["download", "transcribe", "translate", "synthesize", "combine"], 
+    parser.add_argument("step", choices=["download", "import", "transcribe", "translate", "synthesize", "combine"], 
                        help="Pipeline step to execute")
     
     args = parser.parse_args()
@@ -840,9 +973,14 @@ def main():
         
         pipeline = ProjectPipeline(args.project_dir)
         
+        if 'report_progress' in globals():
+            report_progress(args.step, 0, f"Starting {args.step}")
+        
         # Execute the requested step
         if args.step == "download":
             result = pipeline.step_download()
+        elif args.step == "import":
+            result = pipeline.step_import()
         elif args.step == "transcribe":
             result = pipeline.step_transcribe()
         elif args.step == "translate":
@@ -852,6 +990,9 @@ def main():
         elif args.step == "combine":
             result = pipeline.step_combine()
         
+        if 'report_progress' in globals():
+            report_progress(args.step, 100, result.get("message", ""))
+        
         # Output result as JSON for Go to parse
         print(json.dumps(result, indent=2))
         
@@ -1,6 +1,17 @@
+import re
 from typing import Dict, List
 
 
+def _python_replacement(replacement: str) -> str:
+    """Convert a Go-style replacement ($1, ${name}, $$) into re.sub syntax"""
+    escaped = replacement.replace("\\", "\\\\")
+    return re.sub(
+        r"\$(\$|\{(\w+)\}|(\w+))",
+        lambda m: "$" if m.group(1) == "$" else f"\\g<{m.group(2) or m.group(3)}>",
+        escaped,
+    )
+
+
 def apply_text_rules(text: str, language: str, rules: List[Dict]) -> str:
     """Apply text replacement rules to improve pronunciation"""
     if not rules:
@@ -15,29 +26,34 @@ def apply_text_rules(text: str, language: str, rules: List[Dict]) -> str:
     
     for rule in sorted_rules:
         # Check if rule applies to this language
-        rule_lang = rule.get("language", "all")
+        rule_lang = rule.get("language") or "all"
         if rule_lang != "all" and rule_lang != language:
             continue
             
-        original = rule.get("original", "")
-        replacement = rule.get("replacement", "")
+        # Project rules use the app's originalText/replacementText keys
+        original = rule.get("originalText", rule.get("original", ""))
+        replacement = rule.get("replacementText", rule.get("replacement", ""))
         case_sensitive = rule.get("caseSensitive", False)
+        is_regex = rule.get("isRegex", False)
         
         if not original or not replacement:
             continue
         
-        # Apply replacement
-        if case_sensitive:
-            if original in modified_text:
-                modified_text = modified_text.replace(original, replacement)
-                applied_rules.append(rule.get("original"))
+        flags = 0 if case_sensitive else re.IGNORECASE
+        if is_regex:
+            try:
+                pattern = re.compile(original, flags)
+                new_text = pattern.sub(_python_replacement(replacement), modified_text)
+            except (re.error, IndexError) as e:
+                print(f"⚠️ Skipping text rule {original!r}: {e}")
+                continue
         else:
-            # Case-insensitive replacement
-            import re
-            pattern = re.compile(re.escape(original), re.IGNORECASE)
-            if pattern.search(modified_text):
-                modified_text = pattern.sub(replacement, modified_text)
-                applied_rules.append(rule.get("original"))
+            pattern = re.compile(re.escape(original), flags)
+            new_text = pattern.sub(lambda _: replacement, modified_text)
+        
+        if new_text != modified_text:
+            modified_text = new_text
+            applied_rules.append(original)
     
     if applied_rules:
         print(f"📝 Applied text rules: {', '.join(applied_rules)}")
@@ -6,6 +6,10 @@ def load_dubbing_rules() -> Dict:
     from config import config
 
     """Load dubbing rules from JSON file created by the GUI"""
+    # Rules passed directly in the run configuration win over the rules file
+    if config.get("pipeline_rules"):
+        return config["pipeline_rules"]
+
     rules_path = config.get("rules_file", "dubbing-rules.json")
     
     # Default rules structure
@@ -0,0 +1,68 @@
+#!/usr/bin/env python3
+"""
+Check that the pipeline's Python scripts compile and its dependencies import cleanly.
+Prints a single JSON object:
+{"python": "3.11.4", "scripts": {"path": null | "error message"}, "imports": {"module": {...}}}
+With --scripts-only the dependencies aren't imported.
+"""
+
+import os
+import sys
+import json
+import time
+import platform
+import contextlib
+import importlib
+
+REQUIRED_MODULES = [
+    "requests",
+    "dotenv",
+    "youtube_transcript_api",
+    "pedalboard",
+    "transformers",
+    "whisperx",
+]
+
+
+def compile_scripts():
+    """Compile every script beside this one, without writing bytecode files"""
+    root = os.path.dirname(os.path.abspath(__file__))
+    scripts = {}
+    for directory, dirs, files in os.walk(root):
+        dirs[:] = sorted(d for d in dirs if d != "__pycache__")
+        for name in sorted(files):
+            if not name.endswith(".py"):
+                continue
+            path = os.path.join(directory, name)
+            relative = os.path.relpath(path, root).replace(os.sep, "/")
+            try:
+                with open(path, "r", encoding="utf-8") as f:
+                    compile(f.read(), relative, "exec")
+                scripts[relative] = None
+            except (SyntaxError, ValueError, UnicodeDecodeError) as e:
+                scripts[relative] = f"{type(e).__name__}: {e}"
+    return scripts
+
+
+def main():
+    scripts = compile_scripts()
+    if "--scripts-only" in sys.argv[1:]:
+        print(json.dumps({"python": platform.python_version(), "scripts": scripts, "imports": {}}))
+        return
+
+    imports = {}
+    # Heavy libraries print banners to stdout; keep it off the JSON channel
+    with contextlib.redirect_stdout(sys.stderr):
+        for module in REQUIRED_MODULES:
+            started = time.monotonic()
+            try:
+                importlib.import_module(module)
+                imports[module] = {"error": None, "seconds": time.monotonic() - started}
+            except Exception as e:
+                imports[module] = {"error": f"{type(e).__name__}: {e}", "seconds": time.monotonic() - started}
+
+    print(json.dumps({"python": platform.python_version(), "scripts": scripts, "imports": imports}))
+
+
+if __name__ == "__main__":
+    main()
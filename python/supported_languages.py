@@ -0,0 +1,74 @@
+#!/usr/bin/env python3
+"""
+Report which language codes each pipeline capability supports.
+Prints a single JSON object: {"transcribe": [...], "translate": [...], "synthesize": [...]}
+"""
+
+import sys
+import json
+import contextlib
+
+# Kokoro voice names start with a language letter (e.g. "ef_dora" is Spanish)
+KOKORO_VOICE_PREFIXES = {
+    "a": "en", "b": "en", "e": "es", "f": "fr", "h": "hi",
+    "i": "it", "j": "ja", "p": "pt", "z": "zh",
+}
+
+# Whisper's language set, used when whisperx isn't importable
+WHISPER_FALLBACK_LANGUAGES = [
+    "af", "ar", "bg", "bn", "ca", "cs", "cy", "da", "de", "el", "en", "es", "et", "fa", "fi",
+    "fr", "he", "hi", "hr", "hu", "id", "it", "ja", "ko", "lt", "lv", "ms", "nl", "no", "pl",
+    "pt", "ro", "ru", "sk", "sl", "sr", "sv", "sw", "ta", "th", "tl", "tr", "uk", "ur", "vi", "zh",
+]
+
+
+def transcribe_languages():
+    try:
+        from whisperx.utils import LANGUAGES
+        return sorted(LANGUAGES.keys())
+    except Exception:
+        return sorted(WHISPER_FALLBACK_LANGUAGES)
+
+
+def translate_languages():
+    from util.translation_service import LANGUAGE_NAMES
+    return sorted(LANGUAGE_NAMES.keys())
+
+
+def synthesize_languages():
+    voices = []
+    try:
+        import requests
+        from config import config
+        response = requests.get(f"{config['kokoro_endpoint']}/v1/audio/voices", timeout=5)
+        if response.status_code == 200:
+            voices = response.json().get("voices", [])
+    except Exception:
+        pass
+
+    if voices:
+        languages = {KOKORO_VOICE_PREFIXES.get(v[:1]) for v in voices if isinstance(v, str)}
+        languages.discard(None)
+        return sorted(languages)
+
+    # Kokoro isn't running; report what the model ships voices for
+    return sorted(set(KOKORO_VOICE_PREFIXES.values()))
+
+
+def main():
+    try:
+        # Backends print setup messages to stdout; keep it off the JSON channel
+        with contextlib.redirect_stdout(sys.stderr):
+            support = {
+                "transcribe": transcribe_languages(),
+                "translate": translate_languages(),
+                "synthesize": synthesize_languages(),
+            }
+        print(json.dumps(support))
+    except Exception as e:
+        print(json.dumps({"error": str(e)}))
+        sys.exit(1)
+
+
+if __name__ == "__main__":
+    main()
@@ -2,6 +2,7 @@ import subprocess
 from typing import Dict, List
 
 from structs.DubSegment import DubSegment
+from util.pipeline_warnings import report_warning
 
 
 def calculate_loose_sync_timing(segments: List[DubSegment], audio_settings: Dict) -> List[Dict]:
@@ -59,6 +60,9 @@ def calculate_loose_sync_timing(segments: List[DubSegment], audio_settings: Dict
                 final_start = natural_start
                 synced = False
                 print(f"   ⏭️  Segment {i}: SYNC attempted ({sync_reason}) but unsafe, using flow at {final_start:.2f}s")
+                report_warning(
+                    f"Segment {i} didn't fit before its original start and plays {final_start - original_start:.2f}s late",
+                    "segment_overflow", i)
         else:
             # Natural flow timing
             final_start = natural_start
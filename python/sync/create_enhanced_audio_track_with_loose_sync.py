@@ -2,6 +2,7 @@ import os
 import subprocess
 from typing import Dict, List
 from structs.DubSegment import DubSegment
+from util.pipeline_warnings import report_warning
 
 
 def create_enhanced_audio_track_with_loose_sync(segments: List[DubSegment], output_path: str, total_duration: float, audio_settings: Dict, background_audio_path=None):
@@ -25,6 +26,7 @@ def create_enhanced_audio_track_with_loose_sync(segments: List[DubSegment], outp
             valid_segments.append((i, segment))
         else:
             print(f"⚠️ Skipping segment {i}: no audio file")
+            report_warning(f"Segment {i} has no audio and was left out of the dub", "segment_skipped", i)
     
     if not valid_segments:
         print("⚠️ No valid audio segments to process")
@@ -1,6 +1,15 @@
 from checks.check_files_exist import check_video_exists
 import os, sys, subprocess
 
+def download_format():
+    """yt-dlp -f selector chosen by the app (DOWNLOAD_FORMAT), defaulting to the best single file"""
+    return os.getenv("DOWNLOAD_FORMAT", "").strip() or "best[ext=mp4]/best[ext=webm]/best"  # Prefer video formats
+
+def rate_limit_args():
+    """yt-dlp arguments for the DOWNLOAD_RATE_LIMIT set by the app, if any"""
+    rate_limit = os.getenv("DOWNLOAD_RATE_LIMIT", "").strip()
+    return ["--limit-rate", rate_limit] if rate_limit else []
+
 def download_video(youtube_url: str, video_id: str, output_dir: str = None):
     """Download video and extract audio if it doesn't already exist"""
     project_root = os.getcwd()
@@ -17,15 +26,20 @@ def download_video(youtube_url: str, video_id: str, output_dir: str = None):
         return existing_video
     
     os.makedirs(output_dir, exist_ok=True)
-    output_path = os.path.join(output_dir, "%(id)s.%(ext)s")
+    # Named after our video ID rather than yt-dlp's %(id)s, which differs off YouTube
+    output_path = os.path.join(output_dir, f"{video_id}.%(ext)s")
     
     # 🔥 CHANGE: Download video (not audio)
+    # --continue resumes a .part file left by an interrupted run; the app reads the
+    # progress output to report download percentage
     command = [
         "yt-dlp",
-        "-f", "best[ext=mp4]/best[ext=webm]/best",  # Prefer video formats
+        "-f", download_format(),
+        "--continue", "--progress",
         "-o", output_path,
-        youtube_url
     ]
+    command += rate_limit_args()
+    command.append(youtube_url)
     
     try:
         print(f"📥 Downloading VIDEO from {youtube_url}...")  # Changed message
@@ -0,0 +1,108 @@
+"""
+Synthesis markup support.
+
+Kokoro has no SSML support, so text using the app's markup subset
+(<break time="500ms"/> and <emphasis level="...">...</emphasis>, optionally inside
+<speak>) is split into plain-text pieces and pauses. The app validates the markup
+before it gets here.
+"""
+
+import os
+import re
+import subprocess
+import tempfile
+import xml.etree.ElementTree as ET
+from typing import List, Tuple
+
+# Emphasis is approximated by slowing (or, for "reduced", speeding up) delivery
+EMPHASIS_SPEED = {"strong": 0.85, "moderate": 0.92, "reduced": 1.1}
+
+
+def markup_enabled() -> bool:
+    """Markup is only interpreted when the app says the project uses it"""
+    return os.getenv("SYNTHESIS_MARKUP") == "1"
+
+
+def has_markup(text: str) -> bool:
+    return "<" in text
+
+
+def parse_break_time(value: str) -> float:
+    match = re.fullmatch(r"(\d+(?:\.\d+)?)(ms|s)", value or "")
+    if not match:
+        return 0.0
+    seconds = float(match.group(1))
+    return seconds / 1000 if match.group(2) == "ms" else seconds
+
+
+def parse_markup(text: str) -> List[Tuple[str, object, float]]:
+    """
+    Split marked-up text into parts: ("text", str, speed_factor) or ("pause", seconds, 1.0)
+    """
+    doc = text.strip()
+    if not doc.startswith("<speak"):
+        doc = f"<speak>{doc}</speak>"
+    root = ET.fromstring(doc)
+
+    parts = []
+
+    def add_text(value, speed):
+        if value and value.strip():
+            parts.append(("text", value.strip(), speed))
+
+    add_text(root.text, 1.0)
+    for el in root:
+        if el.tag == "break":
+            parts.append(("pause", parse_break_time(el.get("time")), 1.0))
+        elif el.tag == "emphasis":
+            add_text("".join(el.itertext()), EMPHASIS_SPEED.get(el.get("level", "moderate"), 0.92))
+        add_text(el.tail, 1.0)
+
+    return parts
+
+
+def strip_markup(text: str) -> str:
+    """Plain text with all markup removed"""
+    return " ".join(value for kind, value, _ in parse_markup(text) if kind == "text")
+
+
+def make_silence(seconds: float, out_path: str):
+    subprocess.run([
+        "ffmpeg", "-y", "-f", "lavfi", "-i", "anullsrc=r=24000:cl=mono",
+        "-t", f"{seconds:.3f}", "-c:a", "libmp3lame", out_path
+    ], check=True, capture_output=True)
+
+
+def synthesize_marked_up(text: str, out_path: str, synthesize_plain, speed: float = 1.0) -> bool:
+    """
+    Synthesize marked-up text piece by piece and join the pieces into out_path.
+    synthesize_plain(text, out_path, speed) must return the written path or None.
+    """
+    parts = parse_markup(text)
+    with tempfile.TemporaryDirectory() as tmp:
+        piece_paths = []
+        for i, (kind, value, factor) in enumerate(parts):
+            piece_path = os.path.join(tmp, f"piece_{i:03d}.mp3")
+            if kind == "pause":
+                if value <= 0:
+                    continue
+                make_silence(value, piece_path)
+            elif not synthesize_plain(value, piece_path, speed * factor):
+                return False
+            piece_paths.append(piece_path)
+
+        if not piece_paths:
+            return False
+
+        list_path = os.path.join(tmp, "pieces.txt")
+        with open(list_path, "w") as f:
+            for path in piece_paths:
+                f.write(f"file '{path}'\n")
+
+        # Re-encode: Kokoro's output and the generated silence differ in stream parameters
+        subprocess.run([
+            "ffmpeg", "-y", "-f", "concat", "-safe", "0", "-i", list_path,
+            "-ar", "24000", "-ac", "1", "-c:a", "libmp3lame", out_path
+        ], check=True, capture_output=True)
+
+    return True
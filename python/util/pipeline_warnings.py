@@ -0,0 +1,16 @@
+import json
+import sys
+from typing import Optional
+
+
+def report_warning(message: str, code: str = "", segment: Optional[int] = None):
+    """Report a soft problem to the GUI.
+
+    Prints one `WARNING {json}` line to stdout. The app collects these over a
+    pipeline run and shows them after it finishes, instead of burying them in logs.
+    """
+    payload = {"message": message, "code": code}
+    if segment is not None:
+        payload["segment"] = segment
+    print(f"WARNING {json.dumps(payload, ensure_ascii=False)}", flush=True)
+    sys.stdout.flush()
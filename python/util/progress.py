@@ -0,0 +1,13 @@
+import json
+import sys
+
+
+def report_progress(step: str, percent: float, message: str = ""):
+    """Report progress to the GUI.
+
+    Prints one `PROGRESS {json}` line to stdout, which the app turns into a
+    pipeline:progress event instead of a log line. percent runs from 0 to 100.
+    """
+    payload = {"step": step, "percent": max(0.0, min(100.0, float(percent))), "message": message}
+    print(f"PROGRESS {json.dumps(payload, ensure_ascii=False)}", flush=True)
+    sys.stdout.flush()
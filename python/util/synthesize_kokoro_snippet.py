@@ -1,7 +1,73 @@
+import os
 import requests
 from typing import Optional
 
 def synthesize_kokoro_snippet(text: str, out_path: str, voice: str = "ef_dora", speed: float = 1.0, endpoint: str = "http://localhost:8880") -> Optional[str]:
+    from util.markup import markup_enabled, has_markup, synthesize_marked_up
+
+    if markup_enabled() and has_markup(text):
+        try:
+            def synthesize_plain(piece, piece_path, piece_speed):
+                return synthesize_kokoro_snippet_plain(piece, piece_path, voice, piece_speed, endpoint)
+            return out_path if synthesize_marked_up(text, out_path, synthesize_plain, speed) else None
+        except Exception as e:
+            print(f"❌ Markup synthesis error: {e}")
+            return None
+
+    return synthesize_kokoro_snippet_plain(text, out_path, voice, speed, endpoint)
+
+def custom_voice_path(voice: str) -> Optional[str]:
+    """Return the .pt file for a voice from the custom voices directory, if it is one"""
+    voices_dir = os.getenv("SYNTHESIS_CUSTOM_VOICES", "")
+    if not voices_dir or os.sep in voice or "/" in voice:
+        return None
+    path = os.path.join(voices_dir, f"{voice}.pt")
+    return path if os.path.isfile(path) else None
+
+# Local Kokoro pipelines by language letter, loaded on first use
+_local_pipelines = {}
+
+def synthesize_custom_voice(text: str, out_path: str, voice: str, voice_path: str, speed: float) -> Optional[str]:
+    """Synthesize with a custom voice pack. The Kokoro server only knows its own voices,
+    so these run through the local kokoro package and ffmpeg encodes the result."""
+    try:
+        import subprocess
+        import tempfile
+        import numpy
+        import soundfile
+        from kokoro import KPipeline
+
+        lang_code = voice[0]
+        if lang_code not in _local_pipelines:
+            _local_pipelines[lang_code] = KPipeline(lang_code=lang_code)
+        pipeline = _local_pipelines[lang_code]
+
+        print(f"🎤 Synthesizing with custom voice {voice}: '{text[:50]}...'")
+        chunks = [result.audio for result in pipeline(text, voice=voice_path, speed=speed) if result.audio is not None]
+        if not chunks:
+            print(f"❌ Custom voice {voice} produced no audio")
+            return None
+
+        with tempfile.NamedTemporaryFile(suffix=".wav", delete=False) as tmp:
+            wav_path = tmp.name
+        try:
+            soundfile.write(wav_path, numpy.concatenate([numpy.asarray(c) for c in chunks]), 24000)
+            subprocess.run(["ffmpeg", "-y", "-loglevel", "error", "-i", wav_path, out_path], check=True)
+        finally:
+            os.remove(wav_path)
+
+        print(f"✅ Saved audio: {out_path}")
+        return out_path
+
+    except Exception as e:
+        print(f"❌ Custom voice error: {e}")
+        return None
+
+def synthesize_kokoro_snippet_plain(text: str, out_path: str, voice: str, speed: float, endpoint: str) -> Optional[str]:
+    voice_path = custom_voice_path(voice)
+    if voice_path:
+        return synthesize_custom_voice(text, out_path, voice, voice_path, speed)
+
     try:
         payload = {
             "model": "kokoro",
@@ -10,6 +76,10 @@ def synthesize_kokoro_snippet(text: str, out_path: str, voice: str = "ef_dora",
             "response_format": "mp3",
             "speed": speed
         }
+        # Backends that sample take the run's seed so a result can be reproduced
+        seed = os.getenv("SYNTHESIS_SEED", "").strip()
+        if seed:
+            payload["seed"] = int(seed)
 
         print(f"🎤 Synthesizing: '{text[:50]}...'")
 
@@ -1,11 +1,33 @@
 import os
+import random
 from util.synthesize_kokoro_snippet import synthesize_kokoro_snippet
+from util.pipeline_warnings import report_warning
 
 from speakers import speaker_voices
 
+def seed_local_rngs():
+    """Seed the RNGs a local synthesis backend might sample from with SYNTHESIS_SEED"""
+    seed = os.getenv("SYNTHESIS_SEED", "").strip()
+    if not seed:
+        return
+    seed = int(seed)
+    random.seed(seed)
+    try:
+        import numpy
+        numpy.random.seed(seed)
+    except ImportError:
+        pass
+    try:
+        import torch
+        torch.manual_seed(seed)
+    except ImportError:
+        pass
+
 def text_chunks_to_audio(segments, audio_dir, audio_paths):
     from config import config
 
+    seed_local_rngs()
+
     for idx, segment in enumerate(segments):
         print(f"🔍 DEBUG: Processing segment {idx}: '{segment.original_text[:30]}...'")
         text = segment.translated_text or segment.original_text
@@ -23,7 +45,7 @@ def text_chunks_to_audio(segments, audio_dir, audio_paths):
             result_path = synthesize_kokoro_snippet(
                 text, 
                 out_path=mp3_path, 
-                voice = speaker_voices.get(segment.speaker, config["kokoro_default_voice"]),
+                voice = os.getenv("SYNTHESIS_VOICE") or speaker_voices.get(segment.speaker, config["kokoro_default_voice"]),
                 speed=synthesis_speed, 
                 endpoint=config["kokoro_endpoint"]
             )
@@ -33,6 +55,7 @@ def text_chunks_to_audio(segments, audio_dir, audio_paths):
                 print(f"Created segment with speaker '{segment.speaker}' and result_path: '{result_path}' ")
             else:
                 print(f"⚠️ Failed to synthesize segment {idx}")
+                report_warning(f"Segment {idx} could not be synthesized and will be silent", "synthesis_failed", idx)
                 segment.audio_file = None
 
         print(f"🔍 DEBUG: Set segment {idx} audio_file to: {segment.audio_file}")
@@ -0,0 +1,59 @@
+import json
+import os
+from typing import Dict, List, Optional
+
+from util.progress import report_progress
+from util.pipeline_warnings import report_warning
+from util.transcribe_with_whisperx import inference_device
+
+
+def transcribe_with_faster_whisper(audio_file: str, output_dir: str, video_id: str, settings: Optional[Dict] = None) -> Optional[List[Dict]]:
+    """Transcribe with faster-whisper directly: faster than WhisperX, but without forced
+    alignment or diarization.
+
+    Returns WhisperX-shaped segments (start, end, text, words) and saves them as
+    {video_id}.faster-whisper.json in output_dir.
+    """
+    settings = settings or {}
+    try:
+        from faster_whisper import WhisperModel
+    except ImportError:
+        print("❌ faster-whisper is not installed")
+        return None
+
+    if settings.get("enableDiarization"):
+        report_warning("faster-whisper can't tell speakers apart; diarization was skipped", "diarization_unsupported")
+
+    device = inference_device()
+    model_name = settings.get("model") or "large-v3"
+    compute_type = settings.get("computeType") or ("float16" if device == "cuda" else "int8")
+    language = settings.get("language") or None
+    beam_size = settings.get("beamSize") or 5
+
+    print(f"🎤 Running faster-whisper ({model_name}, {compute_type} on {device}) on: {os.path.basename(audio_file)}")
+    model = WhisperModel(model_name, device=device, compute_type=compute_type)
+    raw_segments, info = model.transcribe(audio_file, language=language, beam_size=beam_size,
+                                          word_timestamps=True, vad_filter=True)
+
+    # Segments are produced lazily as the audio is decoded, so progress follows along
+    segments = []
+    for segment in raw_segments:
+        segments.append({
+            "start": segment.start,
+            "end": segment.end,
+            "text": segment.text.strip(),
+            "words": [
+                {"word": word.word.strip(), "start": word.start, "end": word.end, "score": word.probability}
+                for word in (segment.words or [])
+            ],
+        })
+        if info.duration:
+            report_progress("transcribe", segment.end / info.duration * 100, f"Transcribed {segment.end:.0f}s of {info.duration:.0f}s")
+
+    os.makedirs(output_dir, exist_ok=True)
+    json_path = os.path.join(output_dir, f"{video_id}.faster-whisper.json")
+    with open(json_path, "w", encoding="utf-8") as f:
+        json.dump({"language": info.language, "segments": segments}, f, ensure_ascii=False, indent=2)
+    print(f"✅ faster-whisper transcription complete ({len(segments)} segments, language {info.language})")
+
+    return segments
@@ -0,0 +1,81 @@
+import json
+import os
+import subprocess
+from typing import Dict, List, Optional
+
+import requests
+
+from util.pipeline_warnings import report_warning
+
+TRANSCRIPTIONS_URL = "https://api.openai.com/v1/audio/transcriptions"
+
+# The API rejects uploads over 25 MB
+MAX_UPLOAD_BYTES = 25 * 1024 * 1024
+
+
+def transcribe_with_openai(audio_file: str, output_dir: str, video_id: str, settings: Optional[Dict] = None) -> Optional[List[Dict]]:
+    """Transcribe with OpenAI's speech to text API (OPENAI_API_KEY).
+
+    The audio is compressed to Opus first so about two hours fit in one upload. Returns
+    WhisperX-shaped segments and saves them as {video_id}.openai.json in output_dir.
+    """
+    settings = settings or {}
+    api_key = os.getenv("OPENAI_API_KEY")
+    if not api_key:
+        print("❌ OPENAI_API_KEY is not set")
+        return None
+
+    if settings.get("enableDiarization"):
+        report_warning("OpenAI transcription can't tell speakers apart; diarization was skipped", "diarization_unsupported")
+
+    os.makedirs(output_dir, exist_ok=True)
+    upload_path = os.path.join(output_dir, f"{video_id}.openai-upload.ogg")
+    subprocess.run([
+        "ffmpeg", "-y", "-i", audio_file,
+        "-vn", "-ac", "1", "-ar", "16000", "-c:a", "libopus", "-b:a", "24k",
+        upload_path,
+    ], check=True, capture_output=True)
+
+    try:
+        size = os.path.getsize(upload_path)
+        if size > MAX_UPLOAD_BYTES:
+            print(f"❌ Compressed audio is {size / 1024 / 1024:.0f} MB, over the API's 25 MB limit; trim the source or use a local backend")
+            return None
+
+        data = {
+            "model": settings.get("model") or "whisper-1",
+            # Only verbose_json carries segment timestamps
+            "response_format": "verbose_json",
+            "timestamp_granularities[]": "segment",
+        }
+        if settings.get("language"):
+            data["language"] = settings["language"]
+
+        print(f"☁️ Uploading {size / 1024 / 1024:.1f} MB to OpenAI for transcription...")
+        with open(upload_path, "rb") as f:
+            response = requests.post(
+                TRANSCRIPTIONS_URL,
+                headers={"Authorization": f"Bearer {api_key}"},
+                data=data,
+                files={"file": (os.path.basename(upload_path), f, "audio/ogg")},
+                timeout=600,
+            )
+        if response.status_code != 200:
+            print(f"❌ OpenAI transcription failed ({response.status_code}): {response.text[:500]}")
+            return None
+        result = response.json()
+    finally:
+        if os.path.exists(upload_path):
+            os.remove(upload_path)
+
+    segments = [
+        {"start": seg.get("start", 0.0), "end": seg.get("end", 0.0), "text": seg.get("text", "").strip()}
+        for seg in result.get("segments", [])
+    ]
+
+    json_path = os.path.join(output_dir, f"{video_id}.openai.json")
+    with open(json_path, "w", encoding="utf-8") as f:
+        json.dump({"language": result.get("language"), "segments": segments}, f, ensure_ascii=False, indent=2)
+    print(f"✅ OpenAI transcription complete ({len(segments)} segments)")
+
+    return segments
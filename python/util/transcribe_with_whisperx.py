@@ -1,15 +1,38 @@
 from typing import Optional
 from checks.check_files_exist import check_transcript_exists
+from util.pipeline_warnings import report_warning
 
 import os, subprocess
 from typing import List, Dict, Optional
 from pathlib import Path
 import json
 
-def transcribe_with_whisperx(video_id: str, output_dir: str, mode: str = "whisperx") -> Optional[List[Dict]]:
+def inference_device():
+    """Device chosen in the app (INFERENCE_DEVICE), resolved to one WhisperX can use"""
     from config import config
 
-    """Transcribe with WhisperX with proper diarization support"""
+    device = os.getenv("INFERENCE_DEVICE", "").strip().lower()
+    if device in ("", "auto"):
+        try:
+            import torch
+            return "cuda" if torch.cuda.is_available() else config.get("diarization_device", "cpu")
+        except Exception:
+            return config.get("diarization_device", "cpu")
+    if device == "mps":
+        # WhisperX's CTranslate2 backend has no MPS support
+        print("⚠️ WhisperX can't run on MPS, transcribing on the CPU instead")
+        return "cpu"
+    return device
+
+def transcribe_with_whisperx(video_id: str, output_dir: str, mode: str = "whisperx", settings: Optional[Dict] = None) -> Optional[List[Dict]]:
+    from config import config
+
+    """Transcribe with WhisperX with proper diarization support.
+
+    settings are the project's transcription settings (model, language, computeType,
+    enableDiarization); without them the config defaults are used.
+    """
+    settings = settings or {}
     print("⏳ Attempting WhisperX transcription using subprocess...")
 
     # Check for audio in the correct location (same dir as video)
@@ -70,14 +93,14 @@ def transcribe_with_whisperx(video_id: str, output_dir: str, mode: str = "whispe
         audio_file,
         "--output_format", "json",
         "--output_dir", output_dir,
-        "--compute_type", "float32",
-        "--device", config.get("diarization_device", "cpu"),
-        "--model", "large",
-        "--language", "en"
+        "--compute_type", settings.get("computeType") or "float32",
+        "--device", inference_device(),
+        "--model", settings.get("model") or "large",
+        "--language", settings.get("language") or "en"
     ]
 
     # Add diarization if enabled and token available
-    enable_diarization = config.get("enable_diarization", False)
+    enable_diarization = settings.get("enableDiarization", config.get("enable_diarization", False))
     if enable_diarization:
         if hf_token and hf_token != "your_huggingface_token_here":
             command.extend(["--diarize", "--hf_token", hf_token])
@@ -119,6 +142,7 @@ def transcribe_with_whisperx(video_id: str, output_dir: str, mode: str = "whispe
                 print(f"🎭 Speakers detected: {', '.join(sorted(speakers_found))}")
             else:
                 print("⚠️ Diarization was enabled but no speakers were detected")
+                report_warning("Diarization was enabled but no speakers were detected", "no_speakers")
         
         return segments
     except subprocess.CalledProcessError as err:
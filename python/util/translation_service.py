@@ -1,12 +1,33 @@
 """
-Simplified Translation Service using Claude API
+Simplified Translation Service using Claude, DeepL or Google Cloud Translation
 """
 
+import os
 import json
 import requests
 from typing import List, Dict, Optional
 from dataclasses import dataclass
 
+from util.pipeline_warnings import report_warning
+
+# Target languages the translation prompt knows how to name
+LANGUAGE_NAMES = {
+    "es": "Spanish", "fr": "French", "de": "German", "it": "Italian",
+    "pt": "Portuguese", "zh": "Chinese", "ja": "Japanese", "ko": "Korean",
+    "ar": "Arabic", "hi": "Hindi", "ru": "Russian", "nl": "Dutch"
+}
+
+# Cloud providers the service translates with: config key and environment variables for the API key
+PROVIDERS = {
+    "anthropic": ("claude_api_key", ("ANTHROPIC_API_KEY", "CLAUDE_API_KEY")),
+    "deepl": ("deepl_api_key", ("DEEPL_API_KEY",)),
+    "google": ("google_api_key", ("GOOGLE_TRANSLATE_API_KEY", "GOOGLE_API_KEY")),
+}
+PROVIDER_ALIASES = {"claude": "anthropic", "google_translate": "google"}
+
+# DeepL wants a regional variant for these target languages
+DEEPL_TARGET_CODES = {"en": "EN-US", "pt": "PT-BR"}
+
 @dataclass
 class DubSegment:
     start: float
@@ -25,17 +46,23 @@ class DubSegment:
     speaker: str = "SPEAKER_UNKNOWN"
 
 class TranslationService:
-    """Simplified translation service using Claude API"""
+    """Simplified translation service using a cloud translation provider (Claude by default)"""
     
     def __init__(self, config: Dict = None):
         self.config = config or self._get_default_config()
-        self.claude_api_key = self.config.get("claude_api_key") or self._get_claude_api_key()
+        provider = (self.config.get("translation_provider") or "anthropic").lower()
+        self.provider = PROVIDER_ALIASES.get(provider, provider)
+        if self.provider not in PROVIDERS:
+            raise ValueError(f"Unsupported translation provider: {provider}")
+        self.api_key = self._get_api_key()
         self.target_language = self.config.get("target_language", "es")
         self.batch_size = self.config.get("translation_batch_size", 8)  # Process 8 segments at once
         self.context_size = self.config.get("translation_context_size", 3)  # Include 3 previous segments
+        self.glossary = self.config.get("translation_glossary") or []  # Fixed source -> target terms
         
-        if not self.claude_api_key:
-            raise ValueError("Claude API key not found. Set ANTHROPIC_API_KEY environment variable or add to config.")
+        if not self.api_key:
+            config_key, env_names = PROVIDERS[self.provider]
+            raise ValueError(f"{self.provider} API key not found. Set {env_names[0]} environment variable or add {config_key} to config.")
     
     def _get_default_config(self) -> Dict:
         return {
@@ -44,19 +71,16 @@ class TranslationService:
             "translation_context_size": 3
         }
     
-    def _get_claude_api_key(self) -> Optional[str]:
-        """Get Claude API key from environment or config"""
-        import os
-        return os.getenv("ANTHROPIC_API_KEY") or os.getenv("CLAUDE_API_KEY")
+    def _get_api_key(self) -> Optional[str]:
+        """Get the provider's API key from config or environment"""
+        config_key, env_names = PROVIDERS[self.provider]
+        if self.config.get(config_key):
+            return self.config[config_key]
+        return next((os.getenv(name) for name in env_names if os.getenv(name)), None)
     
     def _get_language_name(self, lang_code: str) -> str:
         """Convert language code to full name"""
-        lang_map = {
-            "es": "Spanish", "fr": "French", "de": "German", "it": "Italian",
-            "pt": "Portuguese", "zh": "Chinese", "ja": "Japanese", "ko": "Korean",
-            "ar": "Arabic", "hi": "Hindi", "ru": "Russian", "nl": "Dutch"
-        }
-        return lang_map.get(lang_code, "Spanish")
+        return LANGUAGE_NAMES.get(lang_code, "Spanish")
     
     def _format_timestamp(self, seconds: float) -> str:
         """Format seconds as MM:SS"""
@@ -78,6 +102,17 @@ IMPORTANT GUIDELINES:
 4. Use conversational {target_lang_name}, not formal/literal translation
 5. Keep continuity with previous context"""
 
+        # Add required terminology if available
+        if self.glossary:
+            prompt += f"\n\nREQUIRED TERMINOLOGY (always translate these terms exactly as shown):"
+            for entry in self.glossary:
+                source_term = entry.get("sourceTerm", "")
+                target_term = entry.get("targetTerm", "")
+                if source_term and target_term:
+                    prompt += f"\n- \"{source_term}\" -> \"{target_term}\""
+                    if entry.get("notes"):
+                        prompt += f" ({entry['notes']})"
+
         # Add previous context if available
         if context_segments:
             prompt += f"\n\nPREVIOUS CONTEXT:"
@@ -101,7 +136,7 @@ etc."""
                 "https://api.anthropic.com/v1/messages",
                 headers={
                     "Content-Type": "application/json",
-                    "x-api-key": self.claude_api_key,
+                    "x-api-key": self.api_key,
                     "anthropic-version": "2023-06-01"
                 },
                 json={
@@ -140,6 +175,7 @@ etc."""
                     return translations
                 else:
                     print(f"⚠️ Expected {len(segments_batch)} translations, got {len(translations)}")
+                    report_warning(f"Expected {len(segments_batch)} translations in a batch, got {len(translations)}", "translation_count_mismatch")
                     # Fallback: pad or truncate
                     while len(translations) < len(segments_batch):
                         translations.append("[TRANSLATION ERROR]")
@@ -153,12 +189,67 @@ etc."""
             print(f"❌ Translation error: {e}")
             return ["[NETWORK ERROR]"] * len(segments_batch)
     
+    def _translate_batch_with_deepl(self, segments_batch: List[DubSegment]) -> List[str]:
+        """Translate a batch of segments using the DeepL API"""
+        # Free-tier keys end in ":fx" and are only accepted by the free endpoint
+        host = "api-free.deepl.com" if self.api_key.endswith(":fx") else "api.deepl.com"
+        target = DEEPL_TARGET_CODES.get(self.target_language, self.target_language.upper())
+        
+        try:
+            response = requests.post(
+                f"https://{host}/v2/translate",
+                headers={"Authorization": f"DeepL-Auth-Key {self.api_key}"},
+                json={"text": [seg.original_text for seg in segments_batch], "target_lang": target},
+                timeout=30
+            )
+            if response.status_code == 200:
+                return [t["text"] for t in response.json()["translations"]]
+            
+            print(f"❌ DeepL API error {response.status_code}: {response.text}")
+            return ["[API ERROR]"] * len(segments_batch)
+        except Exception as e:
+            print(f"❌ Translation error: {e}")
+            return ["[NETWORK ERROR]"] * len(segments_batch)
+    
+    def _translate_batch_with_google(self, segments_batch: List[DubSegment]) -> List[str]:
+        """Translate a batch of segments using the Google Cloud Translation API"""
+        try:
+            response = requests.post(
+                "https://translation.googleapis.com/language/translate/v2",
+                params={"key": self.api_key},
+                json={
+                    "q": [seg.original_text for seg in segments_batch],
+                    "target": self.target_language,
+                    "format": "text"
+                },
+                timeout=30
+            )
+            if response.status_code == 200:
+                return [t["translatedText"] for t in response.json()["data"]["translations"]]
+            
+            print(f"❌ Google Translation API error {response.status_code}: {response.text}")
+            return ["[API ERROR]"] * len(segments_batch)
+        except Exception as e:
+            print(f"❌ Translation error: {e}")
+            return ["[NETWORK ERROR]"] * len(segments_batch)
+    
+    def _translate_batch(self, segments_batch: List[DubSegment], context_segments: List[DubSegment] = None) -> List[str]:
+        """Translate a batch with the configured provider; only Claude uses the context and glossary"""
+        if self.provider == "deepl":
+            return self._translate_batch_with_deepl(segments_batch)
+        if self.provider == "google":
+            return self._translate_batch_with_google(segments_batch)
+        return self._translate_batch_with_claude(segments_batch, context_segments)
+    
     def translate_segments(self, segments: List[DubSegment]) -> List[DubSegment]:
-        """Translate all segments using Claude API in batches"""
+        """Translate all segments with the configured provider in batches"""
         if not segments:
             return segments
+        
+        if self.glossary and self.provider != "anthropic":
+            report_warning(f"The glossary is not applied when translating with {self.provider}", "glossary_not_applied")
             
-        print(f"🌐 Translating {len(segments)} segments to {self._get_language_name(self.target_language)} using Claude API...")
+        print(f"🌐 Translating {len(segments)} segments to {self._get_language_name(self.target_language)} using {self.provider}...")
         
         translated_count = 0
         
@@ -174,7 +265,7 @@ etc."""
             print(f"   Processing batch {i//self.batch_size + 1}: segments {i+1}-{batch_end}")
             
             # Translate the batch
-            translations = self._translate_batch_with_claude(current_batch, context_segments)
+            translations = self._translate_batch(current_batch, context_segments)
             
             # Apply translations to segments
             for j, translation in enumerate(translations):
@@ -185,6 +276,21 @@ etc."""
         print(f"✅ Translation completed: {translated_count}/{len(segments)} segments translated")
         return segments
     
+    def translate_selected_segments(self, segments: List[DubSegment], indices: List[int]) -> List[DubSegment]:
+        """Re-translate only the segments at the given indices, using the segments before each as context"""
+        selected = sorted(i for i in set(indices) if 0 <= i < len(segments))
+        print(f"🌐 Re-translating {len(selected)} of {len(segments)} segments to {self._get_language_name(self.target_language)}...")
+        
+        for i in selected:
+            context_start = max(0, i - self.context_size)
+            context_segments = segments[context_start:i] if i > 0 else None
+            translations = self._translate_batch([segments[i]], context_segments)
+            if translations:
+                segments[i].translated_text = translations[0]
+        
+        print(f"✅ Re-translation completed: {len(selected)} segments")
+        return segments
+    
     def translate_single_text(self, text: str, target_lang: str = None) -> str:
         """Translate a single text (fallback method for compatibility)"""
         if not target_lang:
@@ -195,14 +301,12 @@ etc."""
             start=0, end=1, original_text=text, translated_text="", target_duration=1
         )
         
-        translations = self._translate_batch_with_claude([temp_segment])
+        translations = self._translate_batch([temp_segment])
         return translations[0] if translations else text
 
 # Quick test function
 def test_translation():
     """Test the simplified translation service"""
-    import os
-    
     if not os.getenv("ANTHROPIC_API_KEY"):
         print("❌ Set ANTHROPIC_API_KEY environment variable to test")
         return
@@ -0,0 +1,51 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "sync"
+)
+
+// pythonDirMu serializes re-extraction when several steps notice the scripts are gone at once
+var pythonDirMu sync.Mutex
+
+// pythonDirPurged is set when PurgeCaches removed the scripts on purpose, so restoring them
+// isn't reported as unexpected
+var pythonDirPurged bool
+
+// pythonDirSentinel is the script whose absence means the extracted directory was cleaned up
+const pythonDirSentinel = "project_pipeline.py"
+
+// getPythonScriptsDir returns the extracted Python scripts directory, falling back to ./python
+// in development. If the OS has cleaned the extracted copy out of the temp directory during
+// the session, the scripts are extracted again and an "app:warning" event is emitted.
+func (a *App) getPythonScriptsDir() string {
+    pythonDir := os.Getenv("KOKORO_PYTHON_DIR")
+    if pythonDir == "" {
+        workDir, _ := os.Getwd()
+        return filepath.Join(workDir, "python")
+    }
+
+    pythonDirMu.Lock()
+    defer pythonDirMu.Unlock()
+
+    if fileExists(filepath.Join(pythonDir, pythonDirSentinel)) {
+        return pythonDir
+    }
+
+    // Restore them where they were, which for a headless run is its private copy
+    if err := extractPythonScriptsTo(pythonDir); err != nil {
+        fmt.Printf("Failed to re-extract Python scripts: %v\n", err)
+        return pythonDir
+    }
+
+    if pythonDirPurged {
+        pythonDirPurged = false
+        return pythonDir
+    }
+
+    a.emitWarning(fmt.Sprintf("Python scripts in %s were removed (likely by temp-folder cleanup) and have been restored", pythonDir))
+
+    return pythonDir
+}
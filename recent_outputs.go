@@ -0,0 +1,75 @@
+package main
+
+import (
+    "os"
+    "sort"
+    "time"
+)
+
+// OutputRef is a finished output file of a project
+type OutputRef struct {
+    ProjectID      string `json:"projectId"`
+    ProjectName    string `json:"projectName"`
+    TargetLanguage string `json:"targetLanguage"`
+    Kind           string `json:"kind"`
+    Path           string `json:"path"`
+    Size           int64  `json:"size"`
+    ModifiedAt     string `json:"modifiedAt"`
+}
+
+// GetRecentOutputs returns the final video and audio files across all projects, newest first.
+// A limit of 0 or less returns everything.
+func (a *App) GetRecentOutputs(limit int) ([]OutputRef, error) {
+    projects, err := a.listProjects()
+    if err != nil {
+        return nil, err
+    }
+
+    type candidate struct {
+        ref     OutputRef
+        modTime time.Time
+    }
+    var candidates []candidate
+
+    for _, p := range projects {
+        files := map[string]*string{
+            "video": p.Config.FileReferences.FinalVideo,
+            "audio": p.Config.FileReferences.FinalAudio,
+        }
+        for kind, path := range files {
+            if path == nil || *path == "" {
+                continue
+            }
+            fullPath := resolveProjectPath(p.Dir, *path)
+            info, err := os.Stat(fullPath)
+            if err != nil || info.IsDir() {
+                continue
+            }
+            candidates = append(candidates, candidate{
+                ref: OutputRef{
+                    ProjectID:      p.Config.ID,
+                    ProjectName:    p.Config.Name,
+                    TargetLanguage: p.Config.TargetLanguage,
+                    Kind:           kind,
+                    Path:           fullPath,
+                    Size:           info.Size(),
+                    ModifiedAt:     info.ModTime().Format(time.RFC3339),
+                },
+                modTime: info.ModTime(),
+            })
+        }
+    }
+
+    sort.Slice(candidates, func(i, j int) bool {
+        return candidates[i].modTime.After(candidates[j].modTime)
+    })
+    if limit > 0 && len(candidates) > limit {
+        candidates = candidates[:limit]
+    }
+
+    outputs := make([]OutputRef, 0, len(candidates))
+    for _, c := range candidates {
+        outputs = append(outputs, c.ref)
+    }
+    return outputs, nil
+}
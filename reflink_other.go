@@ -0,0 +1,8 @@
+//go:build !linux
+
+package main
+
+// reflinkFile is only implemented on Linux; elsewhere callers fall back to hard links
+func reflinkFile(src, dest string) error {
+    return errReflinkUnsupported
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "html/template"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+    "time"
+)
+
+// lastRunPath is where the summary of a project's most recent full pipeline run is kept
+func lastRunPath(projectDir string) string {
+    return filepath.Join(projectDir, "logs", "last-run.json")
+}
+
+// lastRunRecord is a finished run together with the settings it ran with, which may have
+// changed by the time a report is generated
+type lastRunRecord struct {
+    Completion     PipelineCompletion `json:"completion"`
+    TargetLanguage string             `json:"targetLanguage"`
+    Settings       ProjectSettings    `json:"settings"`
+    TextRules      int                `json:"textRules"`
+    SegmentRules   int                `json:"segmentRules"`
+}
+
+// RunReport summarizes a project's last pipeline run for QA sign-off and bug reports
+type RunReport struct {
+    GeneratedAt    string             `json:"generatedAt"`
+    AppVersion     string             `json:"appVersion"`
+    ProjectID      string             `json:"projectId"`
+    ProjectName    string             `json:"projectName"`
+    Source         string             `json:"source"`
+    TargetLanguage string             `json:"targetLanguage"`
+    Run            PipelineCompletion `json:"run"`
+    Settings       ProjectSettings    `json:"settings"`
+    TextRules      int                `json:"textRules"`
+    SegmentRules   int                `json:"segmentRules"`
+    Outputs        []RunReportFile    `json:"outputs"`
+}
+
+// RunReportFile describes one output file of the run
+type RunReportFile struct {
+    Path            string  `json:"path"`
+    Exists          bool    `json:"exists"`
+    Size            int64   `json:"size,omitempty"`
+    ModTime         string  `json:"modTime,omitempty"`
+    DurationSeconds float64 `json:"durationSeconds,omitempty"`
+    SHA256          string  `json:"sha256,omitempty"`
+}
+
+// runReportFormats are the formats GenerateRunReport writes
+var runReportFormats = map[string]bool{"json": true, "html": true}
+
+// recordLastRun saves a finished run for GenerateRunReport. Best-effort, like the project log.
+func (a *App) recordLastRun(completion PipelineCompletion) {
+    projectDir, err := a.findProjectDirectory(completion.ProjectID)
+    if err != nil {
+        return
+    }
+    project, err := readProjectConfig(projectDir)
+    if err != nil {
+        return
+    }
+
+    record := lastRunRecord{
+        Completion:     completion,
+        TargetLanguage: project.TargetLanguage,
+        Settings:       project.Settings,
+        TextRules:      len(project.TextRules),
+        SegmentRules:   len(project.SegmentRules),
+    }
+    data, err := json.MarshalIndent(record, "", "  ")
+    if err != nil {
+        return
+    }
+    path := lastRunPath(projectDir)
+    if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+        fmt.Printf("Warning: failed to record last run: %v\n", err)
+        return
+    }
+    if err := os.WriteFile(path, data, 0644); err != nil {
+        fmt.Printf("Warning: failed to record last run: %v\n", err)
+    }
+}
+
+// GenerateRunReport writes a summary of the project's last pipeline run, with its step
+// timings, warnings, settings and output files, to run-report.json or a self-contained
+// run-report.html in the project folder. Returns the report's path.
+func (a *App) GenerateRunReport(projectID, format string) (string, error) {
+    format = strings.ToLower(strings.TrimSpace(format))
+    if !runReportFormats[format] {
+        return "", fmt.Errorf("invalid report format %q (expected json or html)", format)
+    }
+
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return "", fmt.Errorf("failed to load project: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return "", fmt.Errorf("project not found: %w", err)
+    }
+
+    data, err := os.ReadFile(lastRunPath(projectDir))
+    if err != nil {
+        if os.IsNotExist(err) {
+            return "", fmt.Errorf("no pipeline run has been recorded for this project yet")
+        }
+        return "", fmt.Errorf("failed to read last run: %w", err)
+    }
+    var record lastRunRecord
+    if err := json.Unmarshal(data, &record); err != nil {
+        return "", fmt.Errorf("failed to parse last run: %w", err)
+    }
+
+    report := RunReport{
+        GeneratedAt:    time.Now().Format(time.RFC3339),
+        AppVersion:     appVersion,
+        ProjectID:      project.ID,
+        ProjectName:    project.Name,
+        Source:         reportSource(project),
+        TargetLanguage: record.TargetLanguage,
+        Run:            record.Completion,
+        Settings:       record.Settings,
+        TextRules:      record.TextRules,
+        SegmentRules:   record.SegmentRules,
+        Outputs:        []RunReportFile{},
+    }
+    if report.Run.Warnings == nil {
+        report.Run.Warnings = []PipelineWarning{}
+    }
+    if record.Completion.OutputPath != "" {
+        report.Outputs = append(report.Outputs, a.reportFile(record.Completion.OutputPath))
+    }
+
+    var content []byte
+    if format == "json" {
+        content, err = json.MarshalIndent(report, "", "  ")
+    } else {
+        content, err = renderRunReportHTML(report)
+    }
+    if err != nil {
+        return "", fmt.Errorf("failed to render report: %w", err)
+    }
+
+    path := filepath.Join(projectDir, "run-report."+format)
+    if err := os.WriteFile(path, content, 0644); err != nil {
+        return "", fmt.Errorf("failed to write report: %w", err)
+    }
+    return path, nil
+}
+
+// reportSource is the URL or file name the project was created from
+func reportSource(project *ProjectConfig) string {
+    if project.SourceUrl != nil && *project.SourceUrl != "" {
+        return *project.SourceUrl
+    }
+    if project.OriginalFilename != nil {
+        return *project.OriginalFilename
+    }
+    return ""
+}
+
+// reportFile describes an output file as it is now
+func (a *App) reportFile(path string) RunReportFile {
+    file := RunReportFile{Path: path}
+    info, err := os.Stat(path)
+    if err != nil || !info.Mode().IsRegular() {
+        return file
+    }
+    file.Exists = true
+    file.Size = info.Size()
+    file.ModTime = info.ModTime().Format(time.RFC3339)
+    if duration, ok, err := a.probeMediaDuration(path); err == nil && ok {
+        file.DurationSeconds = duration
+    }
+    if sum, err := fileSHA256(path); err == nil {
+        file.SHA256 = sum
+    }
+    return file
+}
+
+var runReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+    "seconds": func(s float64) string { return (time.Duration(s * float64(time.Second))).Round(time.Second).String() },
+    "mb":      func(n int64) string { return fmt.Sprintf("%.1f MB", float64(n)/1024/1024) },
+    "json": func(v interface{}) string {
+        data, _ := json.MarshalIndent(v, "", "  ")
+        return string(data)
+    },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Run report: {{.ProjectName}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em auto; max-width: 60em; color: #222; }
+h1 { margin-bottom: 0.2em; }
+.meta { color: #666; margin-top: 0; }
+table { border-collapse: collapse; margin: 0.5em 0 1.5em; }
+th, td { border: 1px solid #ddd; padding: 0.3em 0.8em; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+.success { color: #1a7f37; } .failed { color: #cf222e; } .cancelled { color: #9a6700; }
+pre { background: #f6f8fa; padding: 1em; overflow-x: auto; }
+</style>
+</head>
+<body>
+<h1>{{.ProjectName}}</h1>
+<p class="meta">Generated {{.GeneratedAt}} by Kokoro Studio {{.AppVersion}}</p>
+
+<h2>Run</h2>
+<table>
+<tr><th>Status</th><td class="{{.Run.Status}}">{{.Run.Status}}{{if .Run.FailedStep}} (at {{.Run.FailedStep}}){{end}}</td></tr>
+{{if .Run.Error}}<tr><th>Error</th><td>{{.Run.Error}}</td></tr>{{end}}
+<tr><th>Source</th><td>{{.Source}}</td></tr>
+<tr><th>Target language</th><td>{{.TargetLanguage}}</td></tr>
+<tr><th>Started</th><td>{{.Run.StartedAt}}</td></tr>
+<tr><th>Finished</th><td>{{.Run.FinishedAt}}</td></tr>
+<tr><th>Duration</th><td>{{seconds .Run.DurationSeconds}}</td></tr>
+</table>
+
+<h2>Steps</h2>
+<table>
+<tr><th>Step</th><th>Duration</th></tr>
+{{range .Steps}}<tr><td>{{.Name}}</td><td>{{seconds .Seconds}}</td></tr>
+{{end}}</table>
+
+<h2>Warnings</h2>
+{{if .Run.Warnings}}<table>
+<tr><th>Step</th><th>Segment</th><th>Message</th></tr>
+{{range .Run.Warnings}}<tr><td>{{.Step}}</td><td>{{if .Segment}}{{.Segment}}{{end}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>{{else}}<p>None</p>{{end}}
+
+<h2>Outputs</h2>
+{{if .Outputs}}<table>
+<tr><th>File</th><th>Size</th><th>Duration</th><th>SHA-256</th></tr>
+{{range .Outputs}}<tr><td>{{.Path}}</td>{{if .Exists}}<td>{{mb .Size}}</td><td>{{if .DurationSeconds}}{{seconds .DurationSeconds}}{{end}}</td><td><code>{{.SHA256}}</code></td>{{else}}<td colspan="3">missing</td>{{end}}</tr>
+{{end}}</table>{{else}}<p>None</p>{{end}}
+
+<h2>Settings</h2>
+<p>{{.TextRules}} text rule(s), {{.SegmentRules}} segment rule(s)</p>
+<pre>{{json .Settings}}</pre>
+</body>
+</html>
+`))
+
+// renderRunReportHTML renders the report as one HTML file with no external resources
+func renderRunReportHTML(report RunReport) ([]byte, error) {
+    type stepTiming struct {
+        Name    string
+        Seconds float64
+    }
+    steps := []stepTiming{}
+    knownSteps := append([]string{"import"}, pipelineSteps...)
+    for _, step := range knownSteps {
+        if seconds, ok := report.Run.StepDurations[step]; ok {
+            steps = append(steps, stepTiming{step, seconds})
+        }
+    }
+    // Steps the pipeline no longer has still show, after the known ones
+    var others []string
+    for step := range report.Run.StepDurations {
+        if !containsString(knownSteps, step) {
+            others = append(others, step)
+        }
+    }
+    sort.Strings(others)
+    for _, step := range others {
+        steps = append(steps, stepTiming{step, report.Run.StepDurations[step]})
+    }
+
+    var buf bytes.Buffer
+    err := runReportTemplate.Execute(&buf, struct {
+        RunReport
+        Steps []stepTiming
+    }{report, steps})
+    return buf.Bytes(), err
+}
@@ -0,0 +1,60 @@
+package main
+
+import "sort"
+
+// minGapSeconds keeps rounding noise between back-to-back segments from counting as a gap
+const minGapSeconds = 0.01
+
+// Gap is a stretch of the timeline with no segment. Before and After are the indexes of
+// the segments either side of it, -1 at the start or end of the media.
+type Gap struct {
+    Start    float64 `json:"start"`
+    End      float64 `json:"end"`
+    Duration float64 `json:"duration"`
+    After    int     `json:"after"`
+    Before   int     `json:"before"`
+}
+
+// GetSegmentGaps returns the silent stretches between a project's segments, in timeline
+// order, including any before the first segment and, when the media's length can be
+// probed, after the last. Overlapping segments leave no gap.
+func (a *App) GetSegmentGaps(projectID string) ([]Gap, error) {
+    _, _, segments, err := a.loadProjectSegments(projectID)
+    if err != nil {
+        return nil, err
+    }
+    mediaSeconds, mediaKnown := a.projectMediaSeconds(projectID)
+    return segmentGaps(segments, mediaSeconds, mediaKnown), nil
+}
+
+func segmentGaps(segments []TranscriptSegment, mediaSeconds float64, mediaKnown bool) []Gap {
+    // Edits can leave segments out of order; keep the file's indexes for the UI
+    order := make([]int, len(segments))
+    for i := range order {
+        order[i] = i
+    }
+    sort.SliceStable(order, func(i, j int) bool {
+        return segments[order[i]].Start < segments[order[j]].Start
+    })
+
+    gaps := []Gap{}
+    add := func(start, end float64, after, before int) {
+        if end-start >= minGapSeconds {
+            gaps = append(gaps, Gap{Start: start, End: end, Duration: end - start, After: after, Before: before})
+        }
+    }
+
+    covered, last := 0.0, -1
+    for _, i := range order {
+        segment := segments[i]
+        add(covered, segment.Start, last, i)
+        // A segment inside an earlier, longer one doesn't end the coverage
+        if segment.End > covered {
+            covered, last = segment.End, i
+        }
+    }
+    if mediaKnown {
+        add(covered, mediaSeconds, last, -1)
+    }
+    return gaps
+}
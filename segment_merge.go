@@ -0,0 +1,102 @@
+package main
+
+import (
+    "fmt"
+    "strings"
+)
+
+// MergeShortSegments joins adjacent segments of the same speaker that are less than
+// maxGapMs apart when either is shorter than minDurationMs, since very short segments
+// synthesize into choppy speech. Returns how many merges were made. Merged translations
+// are joined too, so only synthesis and combining need to run again when the project was
+// already translated.
+func (a *App) MergeShortSegments(projectID string, maxGapMs, minDurationMs int) (int, error) {
+    if maxGapMs < 0 {
+        return 0, fmt.Errorf("max gap can't be negative, got %dms", maxGapMs)
+    }
+    if minDurationMs <= 0 {
+        return 0, fmt.Errorf("min duration must be positive, got %dms", minDurationMs)
+    }
+
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return 0, fmt.Errorf("failed to load project: %w", err)
+    }
+    _, segmentsPath, segments, err := a.loadProjectSegments(projectID)
+    if err != nil {
+        return 0, err
+    }
+
+    merged, merges := mergeShortSegments(segments, float64(maxGapMs)/1000, float64(minDurationMs)/1000)
+    if merges == 0 {
+        return 0, nil
+    }
+    if err := validateSegmentTimings(merged); err != nil {
+        return 0, fmt.Errorf("merging would leave inconsistent timings: %w", err)
+    }
+
+    changed := "transcribe"
+    if project.CompletedSteps.Translate {
+        changed = "translate"
+    }
+    err = a.recordTranscriptEdit(projectID, segmentsPath, changed, func() error {
+        return saveProjectSegments(segmentsPath, merged)
+    })
+    if err != nil {
+        return 0, err
+    }
+    if err := a.invalidateStepsAfter(projectID, changed); err != nil {
+        return merges, err
+    }
+
+    a.emitEvent("project:updated", projectID)
+    return merges, nil
+}
+
+// mergeShortSegments does the merging for MergeShortSegments. A merged segment can keep
+// absorbing the next one while it is still short, so runs of fragments collapse into one.
+func mergeShortSegments(segments []TranscriptSegment, maxGap, minDuration float64) ([]TranscriptSegment, int) {
+    if len(segments) == 0 {
+        return segments, 0
+    }
+
+    merged := []TranscriptSegment{segments[0]}
+    merges := 0
+    for _, next := range segments[1:] {
+        current := &merged[len(merged)-1]
+        short := current.End-current.Start < minDuration || next.End-next.Start < minDuration
+        if current.Speaker == next.Speaker && next.Start-current.End < maxGap && short {
+            *current = joinSegments(*current, next)
+            merges++
+            continue
+        }
+        merged = append(merged, next)
+    }
+    return merged, merges
+}
+
+// joinSegments combines two consecutive segments into one spanning both. Synthesis
+// results are dropped since the audio no longer matches the text.
+func joinSegments(first, second TranscriptSegment) TranscriptSegment {
+    joined := first
+    joined.End = max(first.End, second.End)
+    joined.TargetDuration = joined.End - joined.Start
+    joined.OriginalText = joinSegmentText(first.OriginalText, second.OriginalText)
+    joined.TranslatedText = joinSegmentText(first.TranslatedText, second.TranslatedText)
+    joined.Words = append(append([]map[string]interface{}{}, first.Words...), second.Words...)
+    joined.BufferAfter = second.BufferAfter
+    joined.Priority = max(first.Priority, second.Priority)
+    joined.AudioFile = nil
+    joined.ActualStart = nil
+    joined.ActualEnd = nil
+    joined.AdjustedSpeed = 1.0
+    return joined
+}
+
+func joinSegmentText(first, second string) string {
+    first, second = strings.TrimSpace(first), strings.TrimSpace(second)
+    if first == "" || second == "" {
+        return first + second
+    }
+    return first + " " + second
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+    "unicode/utf8"
+)
+
+// Boundaries text is split at, from most to least preferred. Each match ends where the
+// split goes, after the punctuation and the whitespace following it.
+var splitBoundaries = []*regexp.Regexp{
+    regexp.MustCompile(`[.!?…。！？]+["'”’)\]]*\s+`),
+    regexp.MustCompile(`[,;:—–、，；：]\s*`),
+    regexp.MustCompile(`\s+`),
+}
+
+// SplitLongSegments splits segments longer than maxDurationMs at sentence boundaries,
+// falling back to other punctuation and then to word boundaries, and shares each
+// segment's time between the pieces by their share of the text. Returns how many splits
+// were made, so a segment cut in three counts as two.
+func (a *App) SplitLongSegments(projectID string, maxDurationMs int) (int, error) {
+    if maxDurationMs <= 0 {
+        return 0, fmt.Errorf("max duration must be positive, got %dms", maxDurationMs)
+    }
+
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return 0, fmt.Errorf("failed to load project: %w", err)
+    }
+    _, segmentsPath, segments, err := a.loadProjectSegments(projectID)
+    if err != nil {
+        return 0, err
+    }
+
+    maxDuration := float64(maxDurationMs) / 1000
+    split := make([]TranscriptSegment, 0, len(segments))
+    splits := 0
+    for _, segment := range segments {
+        pieces := splitLongSegment(segment, maxDuration)
+        splits += len(pieces) - 1
+        split = append(split, pieces...)
+    }
+    if splits == 0 {
+        return 0, nil
+    }
+    if err := validateSegmentTimings(split); err != nil {
+        return 0, fmt.Errorf("splitting would leave inconsistent timings: %w", err)
+    }
+
+    // Translations are split along with the original, so a translated project only needs
+    // synthesis and combining again
+    changed := "transcribe"
+    if project.CompletedSteps.Translate {
+        changed = "translate"
+    }
+    err = a.recordTranscriptEdit(projectID, segmentsPath, changed, func() error {
+        return saveProjectSegments(segmentsPath, split)
+    })
+    if err != nil {
+        return 0, err
+    }
+    if err := a.invalidateStepsAfter(projectID, changed); err != nil {
+        return splits, err
+    }
+
+    a.emitEvent("project:updated", projectID)
+    return splits, nil
+}
+
+// splitLongSegment cuts one segment into pieces of at most maxDuration where the text
+// allows it. A segment that is short enough, or has no boundary to cut at, comes back whole.
+func splitLongSegment(segment TranscriptSegment, maxDuration float64) []TranscriptSegment {
+    duration := segment.End - segment.Start
+    text := strings.TrimSpace(segment.OriginalText)
+    length := utf8.RuneCountInString(text)
+    if duration <= maxDuration || length == 0 {
+        return []TranscriptSegment{segment}
+    }
+
+    maxChars := max(int(float64(length)*maxDuration/duration), 1)
+    originals := packTextPieces(text, maxChars, 0)
+    if len(originals) < 2 {
+        return []TranscriptSegment{segment}
+    }
+
+    // Where each piece starts, as a share of the whole text
+    shares := make([]float64, len(originals)+1)
+    offset := 0
+    for i, piece := range originals {
+        shares[i] = float64(offset) / float64(length)
+        offset += utf8.RuneCountInString(piece)
+    }
+    shares[len(originals)] = 1
+
+    translations := splitTextAtShares(strings.TrimSpace(segment.TranslatedText), shares)
+    starts := pieceStartTimes(segment, originals, shares)
+
+    pieces := make([]TranscriptSegment, len(originals))
+    for i := range originals {
+        piece := segment
+        piece.Start = starts[i]
+        piece.End = segment.End
+        if i+1 < len(originals) {
+            piece.End = starts[i+1]
+        }
+        piece.TargetDuration = piece.End - piece.Start
+        piece.OriginalText = strings.TrimSpace(originals[i])
+        piece.TranslatedText = strings.TrimSpace(translations[i])
+        piece.Words = wordsBetween(segment.Words, piece.Start, piece.End, i == 0, i == len(originals)-1)
+        piece.AudioFile = nil
+        piece.ActualStart = nil
+        piece.ActualEnd = nil
+        piece.AdjustedSpeed = 1.0
+        if i > 0 {
+            piece.BufferBefore = 0
+        }
+        if i < len(originals)-1 {
+            piece.BufferAfter = 0
+        }
+        pieces[i] = piece
+    }
+    return pieces
+}
+
+// packTextPieces splits text at the boundaries of one level and packs consecutive units
+// into pieces of at most maxChars. Units that are still too long are split at the next level.
+func packTextPieces(text string, maxChars, level int) []string {
+    if level >= len(splitBoundaries) {
+        return []string{text}
+    }
+
+    var pieces []string
+    current := ""
+    for _, unit := range splitAtBoundaries(text, splitBoundaries[level]) {
+        if utf8.RuneCountInString(unit) > maxChars {
+            if current != "" {
+                pieces = append(pieces, current)
+                current = ""
+            }
+            pieces = append(pieces, packTextPieces(unit, maxChars, level+1)...)
+            continue
+        }
+        if current != "" && utf8.RuneCountInString(current+unit) > maxChars {
+            pieces = append(pieces, current)
+            current = ""
+        }
+        current += unit
+    }
+    if current != "" {
+        pieces = append(pieces, current)
+    }
+    return pieces
+}
+
+// splitAtBoundaries cuts text after every match of boundary, keeping all characters
+func splitAtBoundaries(text string, boundary *regexp.Regexp) []string {
+    var units []string
+    start := 0
+    for _, match := range boundary.FindAllStringIndex(text, -1) {
+        if match[1] > start && match[1] < len(text) {
+            units = append(units, text[start:match[1]])
+            start = match[1]
+        }
+    }
+    return append(units, text[start:])
+}
+
+// splitTextAtShares cuts text into len(shares)-1 pieces, each cut at the most preferred
+// boundary near the same share of the text as the original's cut. Used for translations,
+// whose sentences needn't line up with the original's.
+func splitTextAtShares(text string, shares []float64) []string {
+    count := len(shares) - 1
+    pieces := make([]string, count)
+    if text == "" {
+        return pieces
+    }
+
+    // Cuts are searched for within half a piece of their target
+    window := len(text) / (2 * count)
+    cuts := []int{0}
+    for _, share := range shares[1:count] {
+        target := int(share * float64(len(text)))
+        cut := nearestBoundary(text, target, window, cuts[len(cuts)-1])
+        cuts = append(cuts, cut)
+    }
+    cuts = append(cuts, len(text))
+
+    for i := 0; i < count; i++ {
+        pieces[i] = text[cuts[i]:cuts[i+1]]
+    }
+    return pieces
+}
+
+// nearestBoundary finds the boundary closest to target, trying each boundary level in turn
+// and only accepting one within window of target and after the previous cut. Falls back to
+// target itself, moved to the nearest character start.
+func nearestBoundary(text string, target, window, previous int) int {
+    for _, boundary := range splitBoundaries {
+        best := -1
+        for _, match := range boundary.FindAllStringIndex(text, -1) {
+            cut := match[1]
+            if cut <= previous || cut >= len(text) || abs(cut-target) > window {
+                continue
+            }
+            if best < 0 || abs(cut-target) < abs(best-target) {
+                best = cut
+            }
+        }
+        if best >= 0 {
+            return best
+        }
+    }
+
+    cut := max(target, previous)
+    for cut < len(text) && !utf8.RuneStart(text[cut]) {
+        cut++
+    }
+    return cut
+}
+
+// pieceStartTimes times each piece by its share of the text, or by the start of its first
+// word when the segment has word timings that line up with its text
+func pieceStartTimes(segment TranscriptSegment, pieces []string, shares []float64) []float64 {
+    duration := segment.End - segment.Start
+    starts := make([]float64, len(pieces))
+    for i := range pieces {
+        starts[i] = segment.Start + duration*shares[i]
+    }
+
+    wordCount := 0
+    for _, piece := range pieces {
+        wordCount += len(strings.Fields(piece))
+    }
+    if wordCount != len(segment.Words) {
+        return starts
+    }
+
+    index := 0
+    for i, piece := range pieces {
+        if i > 0 {
+            if start, ok := segment.Words[index]["start"].(float64); ok && start > starts[i-1] && start < segment.End {
+                starts[i] = start
+            }
+        }
+        index += len(strings.Fields(piece))
+    }
+    return starts
+}
+
+// wordsBetween returns the words starting in [start, end). Words without timings are kept
+// with the first piece.
+func wordsBetween(words []map[string]interface{}, start, end float64, first, last bool) []map[string]interface{} {
+    var selected []map[string]interface{}
+    for _, word := range words {
+        wordStart, ok := word["start"].(float64)
+        switch {
+        case !ok:
+            if first {
+                selected = append(selected, word)
+            }
+        case (wordStart >= start || first) && (wordStart < end || last):
+            selected = append(selected, word)
+        }
+    }
+    return selected
+}
+
+func abs(n int) int {
+    if n < 0 {
+        return -n
+    }
+    return n
+}
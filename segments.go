@@ -0,0 +1,280 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+)
+
+// TranscriptSegment mirrors the Python DubSegment as stored in the segments file
+type TranscriptSegment struct {
+    Start          float64                  `json:"start"`
+    End            float64                  `json:"end"`
+    OriginalText   string                   `json:"original_text"`
+    TranslatedText string                   `json:"translated_text"`
+    TargetDuration float64                  `json:"target_duration"`
+    Words          []map[string]interface{} `json:"words"`
+    AudioFile      *string                  `json:"audio_file"`
+    AdjustedSpeed  float64                  `json:"adjusted_speed"`
+    ActualStart    *float64                 `json:"actual_start"`
+    ActualEnd      *float64                 `json:"actual_end"`
+    BufferBefore   float64                  `json:"buffer_before"`
+    BufferAfter    float64                  `json:"buffer_after"`
+    Priority       int                      `json:"priority"`
+    Speaker        string                   `json:"speaker"`
+}
+
+// SegmentAudio is the synthesized audio of a single segment
+type SegmentAudio struct {
+    Data     []byte `json:"data"`
+    MimeType string `json:"mimeType"`
+    Path     string `json:"path"`
+}
+
+var audioMimeTypes = map[string]string{
+    ".mp3":  "audio/mpeg",
+    ".wav":  "audio/wav",
+    ".flac": "audio/flac",
+    ".ogg":  "audio/ogg",
+    ".m4a":  "audio/mp4",
+    ".aac":  "audio/aac",
+}
+
+// GetSegmentAudio returns the synthesized audio of one segment, identified by its index.
+// Wails can only bind (value, error) returns, so the bytes and MIME type come back together.
+func (a *App) GetSegmentAudio(projectID, segmentID string) (*SegmentAudio, error) {
+    index, err := strconv.Atoi(strings.TrimSpace(segmentID))
+    if err != nil || index < 0 {
+        return nil, fmt.Errorf("invalid segment ID: %s", segmentID)
+    }
+
+    projectDir, _, segments, err := a.loadProjectSegments(projectID)
+    if err != nil {
+        return nil, err
+    }
+    if index >= len(segments) {
+        return nil, fmt.Errorf("segment %d not found (project has %d segments)", index, len(segments))
+    }
+
+    audioPath := segmentAudioPath(projectDir, index, segments[index])
+    data, err := os.ReadFile(audioPath)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil, fmt.Errorf("segment %d has not been synthesized yet", index)
+        }
+        return nil, fmt.Errorf("failed to read segment audio: %w", err)
+    }
+
+    mimeType, ok := audioMimeTypes[strings.ToLower(filepath.Ext(audioPath))]
+    if !ok {
+        mimeType = "application/octet-stream"
+    }
+
+    return &SegmentAudio{
+        Data:     data,
+        MimeType: mimeType,
+        Path:     audioPath,
+    }, nil
+}
+
+// GetProjectSegmentsCount counts a project's segments without loading them all, returning
+// 0 if transcription hasn't run yet
+func (a *App) GetProjectSegmentsCount(projectID string) (int, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return 0, fmt.Errorf("failed to load project: %w", err)
+    }
+
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return 0, fmt.Errorf("project not found: %w", err)
+    }
+
+    f, err := os.Open(projectSegmentsPath(projectDir, project))
+    if err != nil {
+        if os.IsNotExist(err) {
+            return 0, nil
+        }
+        return 0, fmt.Errorf("failed to open segments file: %w", err)
+    }
+    defer f.Close()
+
+    decoder := json.NewDecoder(f)
+    if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+        return 0, fmt.Errorf("segments file is not a JSON array")
+    }
+
+    // Skip over each element without decoding it into a segment
+    count := 0
+    for decoder.More() {
+        var skip json.RawMessage
+        if err := decoder.Decode(&skip); err != nil {
+            return 0, fmt.Errorf("failed to read segments file: %w", err)
+        }
+        count++
+    }
+
+    return count, nil
+}
+
+// loadProjectSegments reads a project's segments file, returning the project directory and
+// the path of the segments file alongside the segments
+func (a *App) loadProjectSegments(projectID string) (string, string, []TranscriptSegment, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return "", "", nil, fmt.Errorf("failed to load project: %w", err)
+    }
+
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return "", "", nil, fmt.Errorf("project not found: %w", err)
+    }
+
+    segmentsPath := projectSegmentsPath(projectDir, project)
+    data, err := os.ReadFile(segmentsPath)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return "", "", nil, fmt.Errorf("segments file not found, run transcription first")
+        }
+        return "", "", nil, fmt.Errorf("failed to read segments file: %w", err)
+    }
+
+    var segments []TranscriptSegment
+    if err := json.Unmarshal(data, &segments); err != nil {
+        return "", "", nil, fmt.Errorf("failed to parse segments file: %w", err)
+    }
+
+    return projectDir, segmentsPath, segments, nil
+}
+
+// saveProjectSegments replaces the segments file. It writes then renames so a crash
+// mid-save never leaves a truncated transcript behind.
+func saveProjectSegments(segmentsPath string, segments []TranscriptSegment) error {
+    data, err := json.MarshalIndent(segments, "", "  ")
+    if err != nil {
+        return fmt.Errorf("failed to marshal segments: %w", err)
+    }
+    return writeSegmentsFile(segmentsPath, data)
+}
+
+func writeSegmentsFile(segmentsPath string, data []byte) error {
+    tmpPath := segmentsPath + ".tmp"
+    if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+        return fmt.Errorf("failed to write segments file: %w", err)
+    }
+    if err := os.Rename(tmpPath, segmentsPath); err != nil {
+        os.Remove(tmpPath)
+        return fmt.Errorf("failed to save segments file: %w", err)
+    }
+    return nil
+}
+
+// validateSegmentTimings checks every segment ends after it starts and segments are in
+// order, which synthesis and combining rely on
+func validateSegmentTimings(segments []TranscriptSegment) error {
+    for i, segment := range segments {
+        if segment.End <= segment.Start {
+            return fmt.Errorf("segment %d ends at %.3fs, not after its start at %.3fs", i, segment.End, segment.Start)
+        }
+        if i > 0 && segment.Start < segments[i-1].Start {
+            return fmt.Errorf("segment %d starts at %.3fs, before segment %d at %.3fs", i, segment.Start, i-1, segments[i-1].Start)
+        }
+    }
+    return nil
+}
+
+// projectSegmentsPath returns where the project's segments file lives
+func projectSegmentsPath(projectDir string, project *ProjectConfig) string {
+    if project.FileReferences.SegmentsFile != nil && *project.FileReferences.SegmentsFile != "" {
+        return filepath.Join(projectDir, *project.FileReferences.SegmentsFile)
+    }
+
+    videoID := "unknown"
+    if project.VideoId != nil {
+        videoID = *project.VideoId
+    }
+    return filepath.Join(projectDir, "transcripts", videoID+"_segments.json")
+}
+
+// segmentAudioPath locates a segment's synthesized audio: the file recorded on the
+// segment if any, otherwise the chunk_NNN.mp3 naming used by the synthesize step
+func segmentAudioPath(projectDir string, index int, segment TranscriptSegment) string {
+    if segment.AudioFile != nil && *segment.AudioFile != "" {
+        return filepath.Join(projectDir, "audio", filepath.Base(*segment.AudioFile))
+    }
+    return filepath.Join(projectDir, "audio", fmt.Sprintf("chunk_%03d.mp3", index))
+}
+
+// RetranslateSegments re-runs translation for just the given segments (by index) and marks
+// synthesis and combining as needing another run
+func (a *App) RetranslateSegments(projectID string, segmentIDs []string) error {
+    if len(segmentIDs) == 0 {
+        return fmt.Errorf("no segments selected")
+    }
+
+    _, segmentsPath, segments, err := a.loadProjectSegments(projectID)
+    if err != nil {
+        return err
+    }
+
+    indices := make([]int, 0, len(segmentIDs))
+    for _, id := range segmentIDs {
+        index, err := strconv.Atoi(strings.TrimSpace(id))
+        if err != nil || index < 0 {
+            return fmt.Errorf("invalid segment ID: %s", id)
+        }
+        if index >= len(segments) {
+            return fmt.Errorf("segment %d not found (project has %d segments)", index, len(segments))
+        }
+        indices = append(indices, index)
+    }
+
+    indicesJSON, err := json.Marshal(indices)
+    if err != nil {
+        return err
+    }
+
+    err = a.recordTranscriptEdit(projectID, segmentsPath, "translate", func() error {
+        result, err := a.runPipelineStep(projectID, "translate", true, []string{"RETRANSLATE_SEGMENTS=" + string(indicesJSON)})
+        if err != nil {
+            return err
+        }
+        if success, _ := result["success"].(bool); !success {
+            return fmt.Errorf("re-translation failed: %v", result["error"])
+        }
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    return a.invalidateStepsAfter(projectID, "translate")
+}
+
+// invalidateStepsAfter marks every step after step incomplete, since its inputs changed
+func (a *App) invalidateStepsAfter(projectID, step string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+
+    after := false
+    for _, s := range projectPipelineSteps(project) {
+        if after {
+            setStepCompleted(&project.CompletedSteps, s, false)
+            delete(project.StepCache, s)
+        }
+        if sameStep(s, step) {
+            after = true
+        }
+    }
+
+    return a.saveProjectConfig(projectDir, project)
+}
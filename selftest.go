@@ -0,0 +1,222 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "runtime"
+    "sort"
+    "strings"
+    "time"
+)
+
+// SelfTestReport is the outcome of RunSelfTest, meant to be attached to bug reports
+type SelfTestReport struct {
+    Passed     bool            `json:"passed"`
+    AppVersion string          `json:"appVersion"`
+    Platform   string          `json:"platform"`
+    Started    string          `json:"started"`
+    Checks     []SelfTestCheck `json:"checks"`
+}
+
+// SelfTestCheck is one check of a self-test
+type SelfTestCheck struct {
+    Name       string `json:"name"`
+    Passed     bool   `json:"passed"`
+    Message    string `json:"message,omitempty"`
+    DurationMs int64  `json:"durationMs"`
+}
+
+// RunSelfTest exercises the app's critical paths (Python scripts, interpreter and
+// dependencies, external tools, project storage) and reports each with its timing
+func (a *App) RunSelfTest() (*SelfTestReport, error) {
+    report := &SelfTestReport{
+        Passed:     true,
+        AppVersion: appVersion,
+        Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+        Started:    time.Now().Format(time.RFC3339),
+        Checks:     []SelfTestCheck{},
+    }
+
+    run := func(name string, check func() (string, error)) {
+        started := time.Now()
+        message, err := check()
+        result := SelfTestCheck{
+            Name:       name,
+            Passed:     err == nil,
+            Message:    message,
+            DurationMs: time.Since(started).Milliseconds(),
+        }
+        if err != nil {
+            result.Message = err.Error()
+            report.Passed = false
+        }
+        report.Checks = append(report.Checks, result)
+    }
+
+    var pythonDir string
+    run("Extract Python scripts", func() (string, error) {
+        pythonDir = a.getPythonScriptsDir()
+        if !fileExists(filepath.Join(pythonDir, pythonDirSentinel)) {
+            return "", fmt.Errorf("%s is missing from %s", pythonDirSentinel, pythonDir)
+        }
+        return pythonDir, nil
+    })
+
+    pythonOK := false
+    run("Resolve Python", func() (string, error) {
+        path, err := exec.LookPath(a.getPythonCommand())
+        if err != nil {
+            return "", fmt.Errorf("Python interpreter not found: %w", err)
+        }
+        pythonOK = true
+        return path, nil
+    })
+
+    if pythonOK {
+        result, err := a.selfTestPython(pythonDir, false)
+        if err != nil {
+            run("Import Python dependencies", func() (string, error) { return "", err })
+        } else {
+            run("Compile Python scripts", func() (string, error) {
+                if broken := result.brokenScripts(); len(broken) > 0 {
+                    return "", fmt.Errorf("%s", strings.Join(broken, "\n"))
+                }
+                return fmt.Sprintf("%d scripts", len(result.Scripts)), nil
+            })
+
+            modules := make([]string, 0, len(result.Imports))
+            for module := range result.Imports {
+                modules = append(modules, module)
+            }
+            sort.Strings(modules)
+            for _, module := range modules {
+                imported := result.Imports[module]
+                report.Checks = append(report.Checks, SelfTestCheck{
+                    Name:       "Import " + module,
+                    Passed:     imported.Error == nil,
+                    Message:    stringValue(imported.Error),
+                    DurationMs: int64(imported.Seconds * 1000),
+                })
+                if imported.Error != nil {
+                    report.Passed = false
+                }
+            }
+        }
+    }
+
+    for _, req := range toolRequirements {
+        if req.name == "python" {
+            continue
+        }
+        req := req
+        run("Probe "+req.name, func() (string, error) {
+            result := a.checkToolVersion(req.name, req)
+            if result.Status == "fail" {
+                return "", fmt.Errorf("%s", result.Message)
+            }
+            if result.Message != "" {
+                return result.Detected + ": " + result.Message, nil
+            }
+            return result.Detected, nil
+        })
+    }
+
+    run("Write and read a project", selfTestProjectStorage(a))
+
+    return report, nil
+}
+
+type selfTestImport struct {
+    Error   *string `json:"error"`
+    Seconds float64 `json:"seconds"`
+}
+
+// selfTestPythonResult is what self_test.py reports: a compile error (or nil) for each script
+// and the outcome of importing each dependency
+type selfTestPythonResult struct {
+    Scripts map[string]*string        `json:"scripts"`
+    Imports map[string]selfTestImport `json:"imports"`
+}
+
+// selfTestPython runs self_test.py, which compiles every pipeline script and imports each
+// pipeline dependency in turn. With scriptsOnly the dependencies aren't imported.
+func (a *App) selfTestPython(pythonDir string, scriptsOnly bool) (*selfTestPythonResult, error) {
+    args := []string{filepath.Join(pythonDir, "self_test.py")}
+    if scriptsOnly {
+        args = append(args, "--scripts-only")
+    }
+    cmd := a.trackedCommand(a.getPythonCommand(), args...)
+    defer a.untrackCommand(cmd)
+    cmd.Dir = pythonDir
+    cmd.Env = append(os.Environ(), fmt.Sprintf("PYTHONPATH=%s", pythonDir), "PYTHONDONTWRITEBYTECODE=1")
+
+    var stderr bytes.Buffer
+    cmd.Stderr = &stderr
+    output, runErr := a.commandOutput(cmd)
+
+    var result selfTestPythonResult
+    if err := parseTrailingJSON(output, &result); err != nil {
+        if runErr != nil {
+            return nil, fmt.Errorf("self-test script failed: %v\nOutput: %s", runErr, stderr.String())
+        }
+        return nil, fmt.Errorf("failed to parse self-test output: %w", err)
+    }
+    return &result, nil
+}
+
+// brokenScripts lists the scripts that failed to compile with their errors, sorted by path
+func (r *selfTestPythonResult) brokenScripts() []string {
+    var broken []string
+    for script, err := range r.Scripts {
+        if err != nil {
+            broken = append(broken, fmt.Sprintf("%s: %s", script, *err))
+        }
+    }
+    sort.Strings(broken)
+    return broken
+}
+
+// selfTestProjectStorage round-trips a throwaway project through the temp directory
+func selfTestProjectStorage(a *App) func() (string, error) {
+    return func() (string, error) {
+        dir, err := os.MkdirTemp("", "kokoro-studio-selftest-")
+        if err != nil {
+            return "", fmt.Errorf("failed to create temp directory: %w", err)
+        }
+        defer os.RemoveAll(dir)
+
+        project := &ProjectConfig{
+            ID:             "selftest",
+            Name:           "Self-test",
+            Created:        time.Now().Format(time.RFC3339),
+            Version:        1,
+            SourceType:     "audio",
+            TargetLanguage: "es",
+            Settings:       defaultProjectSettings(),
+            TextRules:      []TextRule{},
+            SegmentRules:   []SegmentRule{},
+        }
+        if err := a.saveProjectConfig(dir, project); err != nil {
+            return "", fmt.Errorf("failed to write project: %w", err)
+        }
+
+        loaded, err := readProjectConfig(dir)
+        if err != nil {
+            return "", err
+        }
+        if loaded.ID != project.ID || loaded.Name != project.Name {
+            return "", fmt.Errorf("project read back differently than it was written")
+        }
+        return dir, nil
+    }
+}
+
+func stringValue(s *string) string {
+    if s == nil {
+        return ""
+    }
+    return *s
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+    "os/exec"
+    "strings"
+    "testing"
+)
+
+func TestPythonScriptsCompile(t *testing.T) {
+    app, _ := newTestApp(t)
+    if _, err := exec.LookPath(app.getPythonCommand()); err != nil {
+        t.Skip("Python interpreter not available")
+    }
+
+    result, err := app.selfTestPython(app.getPythonScriptsDir(), true)
+    if err != nil {
+        t.Fatalf("selfTestPython: %v", err)
+    }
+    if _, ok := result.Scripts["project_pipeline.py"]; !ok {
+        t.Fatalf("project_pipeline.py was not compiled; got %d scripts", len(result.Scripts))
+    }
+    if broken := result.brokenScripts(); len(broken) > 0 {
+        t.Errorf("scripts failed to compile:\n%s", strings.Join(broken, "\n"))
+    }
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+)
+
+// presetNamePattern keeps preset names usable as file names on every platform
+var presetNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 _-]{0,63}$`)
+
+// validatePresetName checks a preset name can be its file's name as-is
+func validatePresetName(name string) error {
+    if !presetNamePattern.MatchString(name) || strings.HasSuffix(name, " ") {
+        return fmt.Errorf("invalid preset name %q: use up to 64 letters, digits, spaces, '-' or '_', starting with a letter or digit", name)
+    }
+    if windowsReservedNames[strings.ToUpper(name)] {
+        return fmt.Errorf("invalid preset name %q: reserved by the operating system", name)
+    }
+    return nil
+}
+
+// presetsDir keeps one <name>.json per preset beside settings.json
+func (a *App) presetsDir() (string, error) {
+    settingsPath, err := a.getSettingsPath()
+    if err != nil {
+        return "", err
+    }
+    return filepath.Join(filepath.Dir(settingsPath), "presets"), nil
+}
+
+// SaveSettingsPreset saves settings as a named preset, replacing any preset of that name
+func (a *App) SaveSettingsPreset(name string, settings ProjectSettings) error {
+    name = strings.TrimSpace(name)
+    if err := validatePresetName(name); err != nil {
+        return err
+    }
+    if err := a.validateReusableSettings(settings); err != nil {
+        return err
+    }
+
+    // The audio stream index only means something for one particular source file
+    settings.Transcription.SourceAudioStream = nil
+
+    dir, err := a.presetsDir()
+    if err != nil {
+        return fmt.Errorf("failed to get presets directory: %w", err)
+    }
+    if err := os.MkdirAll(dir, 0755); err != nil {
+        return fmt.Errorf("failed to create presets directory: %w", err)
+    }
+
+    data, err := json.MarshalIndent(settings, "", "  ")
+    if err != nil {
+        return fmt.Errorf("failed to marshal preset: %w", err)
+    }
+    if err := os.WriteFile(filepath.Join(dir, name+".json"), data, 0644); err != nil {
+        return fmt.Errorf("failed to write preset: %w", err)
+    }
+    return nil
+}
+
+// GetSettingsPresets returns every saved preset by name. Files that aren't valid presets
+// are skipped.
+func (a *App) GetSettingsPresets() (map[string]ProjectSettings, error) {
+    presets := make(map[string]ProjectSettings)
+
+    dir, err := a.presetsDir()
+    if err != nil {
+        return nil, fmt.Errorf("failed to get presets directory: %w", err)
+    }
+    entries, err := os.ReadDir(dir)
+    if os.IsNotExist(err) {
+        return presets, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to read presets directory: %w", err)
+    }
+
+    for _, entry := range entries {
+        name := strings.TrimSuffix(entry.Name(), ".json")
+        if entry.IsDir() || name == entry.Name() || validatePresetName(name) != nil {
+            continue
+        }
+        settings, err := a.loadSettingsPreset(dir, name)
+        if err != nil {
+            continue
+        }
+        presets[name] = settings
+    }
+    return presets, nil
+}
+
+func (a *App) loadSettingsPreset(dir, name string) (ProjectSettings, error) {
+    var settings ProjectSettings
+    data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+    if err != nil {
+        return settings, err
+    }
+    err = json.Unmarshal(data, &settings)
+    return settings, err
+}
+
+// ApplyPreset replaces a project's settings with a saved preset. The project keeps its own
+// source audio stream, since presets don't carry one.
+func (a *App) ApplyPreset(projectID, name string) error {
+    if err := validatePresetName(name); err != nil {
+        return err
+    }
+    dir, err := a.presetsDir()
+    if err != nil {
+        return fmt.Errorf("failed to get presets directory: %w", err)
+    }
+    settings, err := a.loadSettingsPreset(dir, name)
+    if os.IsNotExist(err) {
+        return fmt.Errorf("preset not found: %s", name)
+    }
+    if err != nil {
+        return fmt.Errorf("failed to load preset %s: %w", name, err)
+    }
+
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    settings.Transcription.SourceAudioStream = project.Settings.Transcription.SourceAudioStream
+    project.Settings = settings
+    return a.UpdateProject(project)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+    "errors"
+    "fmt"
+    "os"
+    "strings"
+    "time"
+)
+
+// ErrSourceChanged is returned when a linked source file no longer matches the size or
+// modification time recorded when it was linked
+var ErrSourceChanged = errors.New("source file changed since it was linked")
+
+// SourceChange describes a linked source file that differs from what was recorded
+type SourceChange struct {
+    Role             string `json:"role"`
+    Path             string `json:"path"`
+    Missing          bool   `json:"missing"`
+    RecordedSize     int64  `json:"recordedSize"`
+    CurrentSize      int64  `json:"currentSize"`
+    RecordedModified string `json:"recordedModified"`
+    CurrentModified  string `json:"currentModified"`
+}
+
+// CheckSourceChanged compares the project's linked source files against the size and
+// modification time recorded when they were linked
+func (a *App) CheckSourceChanged(projectID string) ([]SourceChange, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load project: %w", err)
+    }
+    return linkedSourceChanges(project), nil
+}
+
+func linkedSourceChanges(project *ProjectConfig) []SourceChange {
+    changes := []SourceChange{}
+    for _, role := range []string{"video", "audio"} {
+        ref, _ := fileReferenceForRole(project, role)
+        if ref == nil || *ref == nil || !(*ref).IsLinked {
+            continue
+        }
+        if change := sourceChange(role, *ref); change != nil {
+            changes = append(changes, *change)
+        }
+    }
+    return changes
+}
+
+// sourceChange returns how a linked file differs from its reference, or nil if it doesn't.
+// Fields the reference never recorded aren't compared.
+func sourceChange(role string, ref *FileReference) *SourceChange {
+    change := &SourceChange{Role: role, Path: ref.Path}
+    if ref.Size != nil {
+        change.RecordedSize = *ref.Size
+    }
+    if ref.LastModified != nil {
+        change.RecordedModified = *ref.LastModified
+    }
+
+    info, err := os.Stat(ref.Path)
+    if err != nil {
+        change.Missing = true
+        return change
+    }
+    change.CurrentSize = info.Size()
+    change.CurrentModified = info.ModTime().Format(time.RFC3339)
+
+    sizeChanged := ref.Size != nil && *ref.Size != info.Size()
+    modifiedChanged := false
+    if ref.LastModified != nil {
+        // Recorded times only have second precision
+        if recorded, err := time.Parse(time.RFC3339, *ref.LastModified); err == nil {
+            modifiedChanged = !recorded.Equal(info.ModTime().Truncate(time.Second))
+        }
+    }
+    if !sizeChanged && !modifiedChanged {
+        return nil
+    }
+    return change
+}
+
+// sourceChangedError builds an ErrSourceChanged error naming the changed files
+func sourceChangedError(changes []SourceChange) error {
+    details := make([]string, 0, len(changes))
+    for _, change := range changes {
+        if change.Missing {
+            details = append(details, fmt.Sprintf("%s file %s is missing", change.Role, change.Path))
+            continue
+        }
+        details = append(details, fmt.Sprintf("%s file %s (size %d → %d, modified %s → %s)", change.Role, change.Path,
+            change.RecordedSize, change.CurrentSize, change.RecordedModified, change.CurrentModified))
+    }
+    return fmt.Errorf("%w: %s; reset the project's completed steps to continue", ErrSourceChanged, strings.Join(details, "; "))
+}
+
+// checkSourceBeforeRun stops a pipeline step when a linked source changed after earlier
+// steps already used it. With nothing completed yet there is nothing to invalidate, so
+// the new size/time are simply recorded.
+func (a *App) checkSourceBeforeRun(projectID string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+
+    changes := linkedSourceChanges(project)
+    if len(changes) == 0 {
+        return nil
+    }
+    for _, change := range changes {
+        if change.Missing {
+            return sourceChangedError(changes)
+        }
+    }
+
+    if projectState(project.CompletedSteps) == projectStateNew {
+        return a.acknowledgeSourceChanges(project, changes)
+    }
+
+    a.emitEvent("project:source-changed", projectID, changes)
+    return sourceChangedError(changes)
+}
+
+// ResetAfterSourceChange accepts the current linked source files: their size and time are
+// recorded again and every completed step is reset, since its output came from the old file
+func (a *App) ResetAfterSourceChange(projectID string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+
+    changes := linkedSourceChanges(project)
+    for _, change := range changes {
+        if change.Missing {
+            return fmt.Errorf("%s file %s is missing; relink it first", change.Role, change.Path)
+        }
+    }
+
+    project.CompletedSteps = CompletedSteps{}
+    return a.acknowledgeSourceChanges(project, changes)
+}
+
+// acknowledgeSourceChanges records the current size and time of the changed sources
+func (a *App) acknowledgeSourceChanges(project *ProjectConfig, changes []SourceChange) error {
+    for _, change := range changes {
+        ref, err := fileReferenceForRole(project, change.Role)
+        if err != nil || *ref == nil {
+            continue
+        }
+        size, modified := change.CurrentSize, change.CurrentModified
+        (*ref).Size = &size
+        (*ref).LastModified = &modified
+    }
+    return a.UpdateProject(project)
+}
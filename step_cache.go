@@ -0,0 +1,253 @@
+package main
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// StepCacheEntry records the inputs a pipeline step last ran successfully with
+type StepCacheEntry struct {
+    InputHash   string                 `json:"inputHash"`
+    Result      map[string]interface{} `json:"result"`
+    CompletedAt string                 `json:"completedAt"`
+}
+
+// pipelineSteps are the steps of a URL source. Local files have nothing to download, so
+// they import the file instead, see projectPipelineSteps.
+var pipelineSteps = []string{"download", "transcribe", "translate", "synthesize", "combine"}
+
+var localPipelineSteps = []string{"import", "transcribe", "translate", "synthesize", "combine"}
+
+// projectPipelineSteps returns the steps a project runs, which depend on its source
+func projectPipelineSteps(project *ProjectConfig) []string {
+    if isLocalSource(project) {
+        return localPipelineSteps
+    }
+    return pipelineSteps
+}
+
+func isLocalSource(project *ProjectConfig) bool {
+    return project.SourceType == "video" || project.SourceType == "audio"
+}
+
+// isSourceStep reports whether step is the first step, which gets the source ready
+func isSourceStep(step string) bool {
+    return step == "download" || step == "import"
+}
+
+// sameStep compares step names, treating download and import as the same step since a
+// project only ever has one of them
+func sameStep(a, b string) bool {
+    return a == b || isSourceStep(a) && isSourceStep(b)
+}
+
+// stepInputHash hashes everything a step's output depends on. Each step also folds in
+// the hash of the step before it, so a change upstream invalidates everything downstream.
+func (a *App) stepInputHash(projectID, step string) (string, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return "", fmt.Errorf("failed to load project: %w", err)
+    }
+
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return "", fmt.Errorf("project not found: %w", err)
+    }
+
+    previous := ""
+    for _, s := range projectPipelineSteps(project) {
+        inputs := map[string]interface{}{
+            "step":     s,
+            "previous": previous,
+        }
+
+        switch s {
+        case "download", "import":
+            inputs["sourceType"] = project.SourceType
+            inputs["trimStart"] = project.TrimStart
+            inputs["trimEnd"] = project.TrimEnd
+            if isLocalSource(project) {
+                // An attached video isn't transcribed, so it only matters from combine on
+                if !project.AttachedVideo {
+                    inputs["videoFile"] = fileReferenceFingerprint(projectDir, project.FileReferences.VideoFile)
+                }
+                inputs["audioFile"] = fileReferenceFingerprint(projectDir, project.FileReferences.AudioFile)
+            } else {
+                // The download writes the file references itself, so they can't be inputs
+                inputs["sourceUrl"] = project.SourceUrl
+                inputs["downloadFormat"] = downloadFormatOrDefault(project.DownloadFormat)
+                if settings, err := a.GetAppSettings(); err == nil {
+                    inputs["downloadRateLimit"] = settings.DownloadRateLimit
+                }
+            }
+        case "transcribe":
+            inputs["transcription"] = project.Settings.Transcription
+        case "translate":
+            inputs["targetLanguage"] = project.TargetLanguage
+            inputs["translation"] = project.Settings.Translation
+            inputs["textRules"] = project.TextRules
+        case "synthesize":
+            inputs["segmentRules"] = project.SegmentRules
+            inputs["usesMarkup"] = project.UsesMarkup
+            inputs["synthesis"] = project.Settings.Synthesis
+            // A custom voice pack can be replaced under the same name
+            if voice := project.Settings.Synthesis.Voice; voice != "" {
+                inputs["customVoice"] = a.customVoiceFingerprint(voice)
+            }
+        case "combine":
+            inputs["audio"] = project.Settings.Audio
+            inputs["output"] = project.Settings.Output
+            inputs["outputDir"] = project.OutputDir
+            inputs["locale"] = project.Locale
+            if project.AttachedVideo {
+                inputs["attachedVideo"] = fileReferenceFingerprint(projectDir, project.FileReferences.VideoFile)
+            }
+        default:
+            return "", fmt.Errorf("invalid pipeline step: %s", step)
+        }
+
+        data, err := json.Marshal(inputs)
+        if err != nil {
+            return "", fmt.Errorf("failed to marshal %s inputs: %w", s, err)
+        }
+
+        sum := sha256.Sum256(data)
+        previous = hex.EncodeToString(sum[:])
+
+        if s == step {
+            return previous, nil
+        }
+    }
+
+    return "", fmt.Errorf("invalid pipeline step: %s", step)
+}
+
+// cachedStepResult returns the stored result for a step if its inputs are unchanged
+// and its outputs are still on disk
+func (a *App) cachedStepResult(projectID, step, inputHash string) (map[string]interface{}, bool) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return nil, false
+    }
+
+    entry, ok := project.StepCache[step]
+    if !ok || entry.InputHash != inputHash || !isStepCompleted(project.CompletedSteps, step) {
+        return nil, false
+    }
+
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil || !stepOutputsExist(projectDir, project, step) {
+        return nil, false
+    }
+
+    result := make(map[string]interface{}, len(entry.Result)+1)
+    for k, v := range entry.Result {
+        result[k] = v
+    }
+    result["cached"] = true
+
+    return result, true
+}
+
+// storeStepResult saves the input hash and result of a successful step run
+func (a *App) storeStepResult(projectID, step, inputHash string, result map[string]interface{}) error {
+    // Reload, since the Python step has just rewritten project.json
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return err
+    }
+
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return err
+    }
+
+    if project.StepCache == nil {
+        project.StepCache = make(map[string]StepCacheEntry)
+    }
+    project.StepCache[step] = StepCacheEntry{
+        InputHash:   inputHash,
+        Result:      result,
+        CompletedAt: time.Now().Format(time.RFC3339),
+    }
+
+    return a.saveProjectConfig(projectDir, project)
+}
+
+func isStepCompleted(steps CompletedSteps, step string) bool {
+    switch step {
+    case "download", "import":
+        return steps.Download
+    case "transcribe":
+        return steps.Transcribe
+    case "translate":
+        return steps.Translate
+    case "synthesize":
+        return steps.Synthesize
+    case "combine":
+        return steps.Combine
+    }
+    return false
+}
+
+// stepOutputsExist checks that the files a step produces are still present
+func stepOutputsExist(projectDir string, project *ProjectConfig, step string) bool {
+    refs := project.FileReferences
+
+    switch step {
+    case "download", "import":
+        return fileReferenceExists(projectDir, refs.VideoFile) || fileReferenceExists(projectDir, refs.AudioFile)
+    case "transcribe", "translate":
+        return refs.SegmentsFile != nil && fileExists(filepath.Join(projectDir, *refs.SegmentsFile))
+    case "synthesize":
+        return len(listDataFiles(filepath.Join(projectDir, "audio"))) > 0
+    case "combine":
+        if isAudioOutputFormat(project.Settings.Output.Format) {
+            return refs.FinalAudio != nil && fileExists(resolveProjectPath(projectDir, *refs.FinalAudio))
+        }
+        return refs.FinalVideo != nil && fileExists(resolveProjectPath(projectDir, *refs.FinalVideo))
+    }
+    return false
+}
+
+// resolveFileReferencePath returns the absolute path of a file reference
+func resolveFileReferencePath(projectDir string, ref *FileReference) string {
+    if ref.IsLinked || filepath.IsAbs(ref.Path) {
+        return ref.Path
+    }
+    return filepath.Join(projectDir, ref.Path)
+}
+
+func fileReferenceExists(projectDir string, ref *FileReference) bool {
+    return ref != nil && fileExists(resolveFileReferencePath(projectDir, ref))
+}
+
+// fileReferenceFingerprint identifies a referenced file by path, size and modification time
+func fileReferenceFingerprint(projectDir string, ref *FileReference) string {
+    if ref == nil {
+        return ""
+    }
+
+    return pathFingerprint(resolveFileReferencePath(projectDir, ref))
+}
+
+// pathFingerprint identifies a file by path, size and modification time, or a missing
+// file by its path alone
+func pathFingerprint(path string) string {
+    info, err := os.Stat(path)
+    if err != nil {
+        return path
+    }
+
+    return fmt.Sprintf("%s|%d|%d", path, info.Size(), info.ModTime().UnixNano())
+}
+
+func fileExists(path string) bool {
+    info, err := os.Stat(path)
+    return err == nil && !info.IsDir()
+}
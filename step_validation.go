@@ -0,0 +1,176 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "strconv"
+    "strings"
+)
+
+// translationErrorMarkers are the placeholders the translation service writes for segments it failed on
+var translationErrorMarkers = []string{"[TRANSLATION ERROR]", "[API ERROR]", "[NETWORK ERROR]"}
+
+// validateStepOutput checks that a step which reported success actually produced usable
+// output, so the pipeline doesn't march forward on empty or garbage intermediate data
+func (a *App) validateStepOutput(projectID, step string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+    refs := project.FileReferences
+
+    switch step {
+    case "download", "import":
+        ref := sourceFileReference(project)
+        if ref == nil {
+            return fmt.Errorf("%s produced no media file", step)
+        }
+        return a.validateMediaFile(resolveFileReferencePath(projectDir, ref))
+
+    case "transcribe", "translate":
+        _, _, segments, err := a.loadProjectSegments(projectID)
+        if err != nil {
+            return err
+        }
+        if len(segments) == 0 {
+            return fmt.Errorf("segments file contains no segments")
+        }
+        for i, segment := range segments {
+            if segment.End < segment.Start {
+                return fmt.Errorf("segment %d ends before it starts (%.2fs-%.2fs)", i, segment.Start, segment.End)
+            }
+        }
+        if step == "transcribe" {
+            return requireSegmentText(segments, func(s TranscriptSegment) string { return s.OriginalText }, "transcribed")
+        }
+        if err := requireSegmentText(segments, func(s TranscriptSegment) string { return s.TranslatedText }, "translated"); err != nil {
+            return err
+        }
+        failed := 0
+        for _, segment := range segments {
+            for _, marker := range translationErrorMarkers {
+                if strings.Contains(segment.TranslatedText, marker) {
+                    failed++
+                    break
+                }
+            }
+        }
+        if failed > 0 {
+            return fmt.Errorf("%d of %d segments failed to translate", failed, len(segments))
+        }
+        return nil
+
+    case "synthesize":
+        _, _, segments, err := a.loadProjectSegments(projectID)
+        if err != nil {
+            return err
+        }
+        found := 0
+        for i, segment := range segments {
+            info, err := os.Stat(segmentAudioPath(projectDir, i, segment))
+            if err != nil {
+                continue
+            }
+            if info.Size() == 0 {
+                return fmt.Errorf("segment %d audio is empty", i)
+            }
+            found++
+        }
+        if found == 0 {
+            return fmt.Errorf("synthesis produced no segment audio")
+        }
+        return nil
+
+    case "combine":
+        output := refs.FinalVideo
+        if isAudioOutputFormat(project.Settings.Output.Format) {
+            output = refs.FinalAudio
+        }
+        if output == nil {
+            return fmt.Errorf("combine produced no output file")
+        }
+        return a.validateMediaFile(resolveProjectPath(projectDir, *output))
+    }
+
+    return nil
+}
+
+// requireSegmentText fails when no segment has any text in the given field
+func requireSegmentText(segments []TranscriptSegment, text func(TranscriptSegment) string, what string) error {
+    for _, segment := range segments {
+        if strings.TrimSpace(text(segment)) != "" {
+            return nil
+        }
+    }
+    return fmt.Errorf("no segment has %s text", what)
+}
+
+// validateMediaFile checks that a media file exists, is non-empty and has a duration
+func (a *App) validateMediaFile(path string) error {
+    info, err := os.Stat(path)
+    if err != nil {
+        return fmt.Errorf("output file missing: %s", filepath.Base(path))
+    }
+    if info.Size() == 0 {
+        return fmt.Errorf("output file is empty: %s", filepath.Base(path))
+    }
+
+    duration, ok, err := a.probeMediaDuration(path)
+    if err != nil {
+        return fmt.Errorf("%s is not a readable media file: %w", filepath.Base(path), err)
+    }
+    if ok && duration <= 0 {
+        return fmt.Errorf("%s has zero duration", filepath.Base(path))
+    }
+    return nil
+}
+
+// probeMediaDuration returns a media file's duration in seconds. ok is false when ffprobe
+// isn't installed, in which case the duration is unknown rather than zero.
+func (a *App) probeMediaDuration(path string) (float64, bool, error) {
+    ffprobe, err := exec.LookPath("ffprobe")
+    if err != nil {
+        return 0, false, nil
+    }
+
+    cmd := a.trackedCommand(ffprobe, "-v", "error", "-show_entries", "format=duration", "-of", "default=noprint_wrappers=1:nokey=1", path)
+    defer a.untrackCommand(cmd)
+
+    output, err := a.commandOutput(cmd)
+    if err != nil {
+        return 0, true, err
+    }
+
+    value := strings.TrimSpace(string(output))
+    if value == "" || value == "N/A" {
+        return 0, true, nil
+    }
+    duration, err := strconv.ParseFloat(value, 64)
+    if err != nil {
+        return 0, true, fmt.Errorf("unexpected ffprobe duration %q", value)
+    }
+    return duration, true, nil
+}
+
+// markStepIncomplete clears a step's completion flag after its output failed validation
+func (a *App) markStepIncomplete(projectID, step string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return err
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return err
+    }
+
+    setStepCompleted(&project.CompletedSteps, step, false)
+    delete(project.StepCache, step)
+
+    return a.saveProjectConfig(projectDir, project)
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+    "bufio"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strings"
+)
+
+// SubtitleOptions controls ExportSubtitles
+type SubtitleOptions struct {
+    // Format is "srt" or "vtt"; when empty it follows the destination's extension
+    Format string `json:"format"`
+    // Original exports the source-language text instead of the translation
+    Original bool `json:"original"`
+    // IncludeSpeakerLabels marks diarized speakers: VTT voice tags, or a "Speaker:" prefix in SRT
+    IncludeSpeakerLabels bool `json:"includeSpeakerLabels"`
+    // Position is "bottom" (the default) or "top"; only VTT can express it
+    Position string `json:"position"`
+}
+
+var subtitlePositions = map[string]string{
+    "":       "",
+    "bottom": "",
+    "top":    " line:0 align:center",
+}
+
+// ExportSubtitles writes the project's segments as an SRT or WebVTT file at destPath.
+// Translated text follows the dub's actual timing when synthesis recorded it.
+func (a *App) ExportSubtitles(projectID, destPath string, options SubtitleOptions) error {
+    _, _, segments, err := a.loadProjectSegments(projectID)
+    if err != nil {
+        return err
+    }
+
+    format := strings.ToLower(strings.TrimSpace(options.Format))
+    if format == "" {
+        format = strings.ToLower(strings.TrimPrefix(filepath.Ext(destPath), "."))
+    }
+    cueSettings, ok := subtitlePositions[strings.ToLower(strings.TrimSpace(options.Position))]
+    if !ok {
+        return fmt.Errorf("unsupported subtitle position: %s (expected \"top\" or \"bottom\")", options.Position)
+    }
+
+    var data string
+    switch format {
+    case "srt":
+        data = renderSRT(segments, options)
+    case "vtt":
+        data = renderVTT(segments, options, cueSettings)
+        if err := validateWebVTT(data); err != nil {
+            return fmt.Errorf("generated subtitles are not valid WebVTT: %w", err)
+        }
+    default:
+        return fmt.Errorf("unsupported subtitle format: %s (expected srt or vtt)", format)
+    }
+
+    if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+        return fmt.Errorf("failed to create destination directory: %w", err)
+    }
+    tmpPath := destPath + ".tmp"
+    if err := os.WriteFile(tmpPath, []byte(data), 0644); err != nil {
+        return fmt.Errorf("failed to write subtitles: %w", err)
+    }
+    if err := os.Rename(tmpPath, destPath); err != nil {
+        os.Remove(tmpPath)
+        return fmt.Errorf("failed to write subtitles: %w", err)
+    }
+    return nil
+}
+
+// subtitleCue is one segment ready to render
+type subtitleCue struct {
+    start, end float64
+    speaker    string
+    lines      []string
+}
+
+// subtitleCues picks each segment's text and timing, skipping segments with no text
+func subtitleCues(segments []TranscriptSegment, options SubtitleOptions) []subtitleCue {
+    cues := make([]subtitleCue, 0, len(segments))
+    for _, segment := range segments {
+        text := segment.TranslatedText
+        start, end := segment.Start, segment.End
+        if options.Original || text == "" {
+            text = segment.OriginalText
+        } else if segment.ActualStart != nil && segment.ActualEnd != nil && *segment.ActualEnd > *segment.ActualStart {
+            start, end = *segment.ActualStart, *segment.ActualEnd
+        }
+
+        var lines []string
+        for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+            if line = strings.TrimSpace(line); line != "" {
+                lines = append(lines, line)
+            }
+        }
+        if len(lines) == 0 || end <= start {
+            continue
+        }
+
+        cue := subtitleCue{start: start, end: end, lines: lines}
+        if options.IncludeSpeakerLabels {
+            cue.speaker = strings.TrimSpace(segment.Speaker)
+        }
+        cues = append(cues, cue)
+    }
+
+    // Cues must be in start order; actual dub timings can shift a segment past its neighbour
+    sort.SliceStable(cues, func(i, j int) bool { return cues[i].start < cues[j].start })
+    return cues
+}
+
+func renderSRT(segments []TranscriptSegment, options SubtitleOptions) string {
+    var b strings.Builder
+    for i, cue := range subtitleCues(segments, options) {
+        fmt.Fprintf(&b, "%d\n%s --> %s\n", i+1, formatSubtitleTime(cue.start, ","), formatSubtitleTime(cue.end, ","))
+        if cue.speaker != "" {
+            cue.lines[0] = cue.speaker + ": " + cue.lines[0]
+        }
+        // Lenient SRT parsers take any "-->" for a timing line
+        b.WriteString(strings.ReplaceAll(strings.Join(cue.lines, "\n"), "-->", "→"))
+        b.WriteString("\n\n")
+    }
+    return b.String()
+}
+
+func renderVTT(segments []TranscriptSegment, options SubtitleOptions, cueSettings string) string {
+    var b strings.Builder
+    b.WriteString("WEBVTT\n\n")
+    for _, cue := range subtitleCues(segments, options) {
+        fmt.Fprintf(&b, "%s --> %s%s\n", formatSubtitleTime(cue.start, "."), formatSubtitleTime(cue.end, "."), cueSettings)
+        for i, line := range cue.lines {
+            line = escapeVTTText(line)
+            // Voice tags apply to a single line, so every line of a cue gets one
+            if cue.speaker != "" {
+                line = fmt.Sprintf("<v %s>%s", escapeVTTAnnotation(cue.speaker), line)
+            }
+            if i > 0 {
+                b.WriteString("\n")
+            }
+            b.WriteString(line)
+        }
+        b.WriteString("\n\n")
+    }
+    return b.String()
+}
+
+// formatSubtitleTime renders seconds as HH:MM:SS,mmm (SRT) or HH:MM:SS.mmm (VTT)
+func formatSubtitleTime(seconds float64, fractionSeparator string) string {
+    ms := int64(seconds*1000 + 0.5)
+    if ms < 0 {
+        ms = 0
+    }
+    return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, fractionSeparator, ms%1000)
+}
+
+// escapeVTTText escapes the characters WebVTT cue text reserves for markup; "-->" can't
+// appear in cue text at all
+func escapeVTTText(s string) string {
+    s = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+    return strings.ReplaceAll(s, "--&gt;", "→")
+}
+
+// escapeVTTAnnotation makes a speaker name safe inside a <v ...> tag
+func escapeVTTAnnotation(s string) string {
+    return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\n", " ").Replace(s)
+}
+
+var vttTimingLine = regexp.MustCompile(`^(\d{2,}:)?(\d{2}):(\d{2})\.(\d{3}) --> (\d{2,}:)?(\d{2}):(\d{2})\.(\d{3})((?:[ \t]+\S+)*)$`)
+
+// validateWebVTT checks the structure the WebVTT spec requires: the header, well-formed
+// and ordered cue timings, and no "-->" inside cue text
+func validateWebVTT(data string) error {
+    scanner := bufio.NewScanner(strings.NewReader(data))
+    if !scanner.Scan() || (scanner.Text() != "WEBVTT" && !strings.HasPrefix(scanner.Text(), "WEBVTT ")) {
+        return fmt.Errorf("missing WEBVTT header")
+    }
+
+    lineNo := 1
+    previousStart := -1.0
+    inCue := false
+    for scanner.Scan() {
+        lineNo++
+        line := scanner.Text()
+        switch {
+        case line == "":
+            inCue = false
+        case inCue:
+            if strings.Contains(line, "-->") {
+                return fmt.Errorf("line %d: cue text contains \"-->\"", lineNo)
+            }
+        default:
+            m := vttTimingLine.FindStringSubmatch(line)
+            if m == nil {
+                return fmt.Errorf("line %d: expected a cue timing line, got %q", lineNo, line)
+            }
+            start := vttSeconds(m[1], m[2], m[3], m[4])
+            end := vttSeconds(m[5], m[6], m[7], m[8])
+            if end <= start {
+                return fmt.Errorf("line %d: cue ends before it starts", lineNo)
+            }
+            if start < previousStart {
+                return fmt.Errorf("line %d: cues are out of order", lineNo)
+            }
+            previousStart = start
+            inCue = true
+        }
+    }
+    return scanner.Err()
+}
+
+func vttSeconds(hours, minutes, seconds, millis string) float64 {
+    var h, m, s, ms int
+    if hours != "" {
+        fmt.Sscanf(strings.TrimSuffix(hours, ":"), "%d", &h)
+    }
+    fmt.Sscanf(minutes, "%d", &m)
+    fmt.Sscanf(seconds, "%d", &s)
+    fmt.Sscanf(millis, "%d", &ms)
+    return float64(h*3600+m*60+s) + float64(ms)/1000
+}
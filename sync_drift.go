@@ -0,0 +1,130 @@
+package main
+
+import (
+    "fmt"
+    "math"
+)
+
+const (
+    // syncDriftTolerance is how far (in seconds) the dub may drift before it is flagged
+    syncDriftTolerance = 0.5
+    // driftRegionSeconds is the width of the windows segment drift is summarized over
+    driftRegionSeconds = 60.0
+)
+
+// DriftReport compares a project's dubbed output against its source timing
+type DriftReport struct {
+    SourceDuration   float64       `json:"sourceDuration"`
+    OutputDuration   float64       `json:"outputDuration"`
+    DriftSeconds     float64       `json:"driftSeconds"`
+    Tolerance        float64       `json:"tolerance"`
+    ExceedsTolerance bool          `json:"exceedsTolerance"`
+    Regions          []RegionDrift `json:"regions"`
+}
+
+// RegionDrift summarizes how far synthesized segments landed from their source timing
+// within one window of the source
+type RegionDrift struct {
+    Start            float64 `json:"start"`
+    End              float64 `json:"end"`
+    Segments         int     `json:"segments"`
+    MeanDriftSeconds float64 `json:"meanDriftSeconds"`
+    MaxDriftSeconds  float64 `json:"maxDriftSeconds"`
+    ExceedsTolerance bool    `json:"exceedsTolerance"`
+}
+
+// CheckSyncDrift measures the final output against the source media with ffprobe and, when
+// the segments carry their actual placement, reports drift per region of the timeline
+func (a *App) CheckSyncDrift(projectID string) (DriftReport, error) {
+    report := DriftReport{Tolerance: syncDriftTolerance, Regions: []RegionDrift{}}
+
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return report, fmt.Errorf("failed to load project: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return report, fmt.Errorf("project not found: %w", err)
+    }
+
+    source := project.FileReferences.VideoFile
+    if source == nil {
+        source = project.FileReferences.AudioFile
+    }
+    if source == nil {
+        return report, fmt.Errorf("project has no source media yet")
+    }
+
+    output := project.FileReferences.FinalVideo
+    if isAudioOutputFormat(project.Settings.Output.Format) || output == nil {
+        output = project.FileReferences.FinalAudio
+    }
+    if output == nil {
+        return report, fmt.Errorf("project has no dubbed output yet, run the combine step first")
+    }
+
+    sourceDuration, ok, err := a.probeMediaDuration(resolveFileReferencePath(projectDir, source))
+    if !ok {
+        return report, fmt.Errorf("ffprobe is required to measure durations")
+    }
+    if err != nil {
+        return report, fmt.Errorf("failed to read source duration: %w", err)
+    }
+    outputDuration, _, err := a.probeMediaDuration(resolveProjectPath(projectDir, *output))
+    if err != nil {
+        return report, fmt.Errorf("failed to read output duration: %w", err)
+    }
+
+    report.SourceDuration = sourceDuration
+    report.OutputDuration = outputDuration
+    report.DriftSeconds = outputDuration - sourceDuration
+    report.ExceedsTolerance = math.Abs(report.DriftSeconds) > syncDriftTolerance
+
+    // Segment placement is only known once synthesis has recorded it
+    if _, _, segments, err := a.loadProjectSegments(projectID); err == nil {
+        report.Regions = segmentDriftRegions(segments)
+        for _, region := range report.Regions {
+            if region.ExceedsTolerance {
+                report.ExceedsTolerance = true
+            }
+        }
+    }
+
+    return report, nil
+}
+
+// segmentDriftRegions groups segments into fixed windows by source start time and
+// summarizes how far each landed from where it was spoken
+func segmentDriftRegions(segments []TranscriptSegment) []RegionDrift {
+    regions := []RegionDrift{}
+    byIndex := make(map[int]int)
+    totals := make(map[int]float64)
+
+    for _, segment := range segments {
+        if segment.ActualStart == nil {
+            continue
+        }
+        drift := math.Abs(*segment.ActualStart - segment.Start)
+
+        window := int(segment.Start / driftRegionSeconds)
+        i, ok := byIndex[window]
+        if !ok {
+            i = len(regions)
+            byIndex[window] = i
+            regions = append(regions, RegionDrift{
+                Start: float64(window) * driftRegionSeconds,
+                End:   float64(window+1) * driftRegionSeconds,
+            })
+        }
+
+        regions[i].Segments++
+        totals[i] += drift
+        regions[i].MaxDriftSeconds = math.Max(regions[i].MaxDriftSeconds, drift)
+    }
+
+    for i := range regions {
+        regions[i].MeanDriftSeconds = totals[i] / float64(regions[i].Segments)
+        regions[i].ExceedsTolerance = regions[i].MaxDriftSeconds > syncDriftTolerance
+    }
+    return regions
+}
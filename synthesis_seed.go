@@ -0,0 +1,27 @@
+package main
+
+import (
+    "fmt"
+    "math"
+    "math/rand/v2"
+)
+
+// maxSynthesisSeed keeps seeds in the range every Python RNG accepts
+const maxSynthesisSeed = math.MaxInt32
+
+// validateSynthesisSeed rejects seeds outside 0..maxSynthesisSeed
+func validateSynthesisSeed(seed *int) error {
+    if seed != nil && (*seed < 0 || *seed > maxSynthesisSeed) {
+        return fmt.Errorf("synthesis seed must be between 0 and %d", maxSynthesisSeed)
+    }
+    return nil
+}
+
+// synthesisSeed returns the project's fixed seed, or a fresh one when it has none. Either
+// way the seed ends up in the step result, so the run can be reproduced by setting it.
+func synthesisSeed(settings SynthesisSettings) int {
+    if settings.Seed != nil {
+        return *settings.Seed
+    }
+    return rand.IntN(maxSynthesisSeed + 1)
+}
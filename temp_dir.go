@@ -0,0 +1,40 @@
+package main
+
+import (
+    "fmt"
+    "os"
+)
+
+// applyTempDirSetting points the process temp directory at the user's TempDir setting, so
+// the extracted Python scripts, Go scratch files and the Python steps' own temp files (which
+// inherit the environment) all land there. An unusable setting falls back to the system
+// default with a warning. It runs once at startup; changing the setting takes effect on the
+// next launch.
+func (a *App) applyTempDirSetting() {
+    settings, err := a.GetAppSettings()
+    if err != nil || settings.TempDir == nil || *settings.TempDir == "" {
+        return
+    }
+
+    dir := *settings.TempDir
+    if err := checkDirWritable(dir); err != nil {
+        a.emitWarning(fmt.Sprintf("Temp directory %s is not usable (%v); using %s instead", dir, err, os.TempDir()))
+        return
+    }
+
+    // os.TempDir reads TMPDIR on Unix and TMP/TEMP on Windows
+    for _, env := range []string{"TMPDIR", "TMP", "TEMP"} {
+        os.Setenv(env, dir)
+    }
+}
+
+// validateTempDirSetting rejects a TempDir setting that can't be written to
+func validateTempDirSetting(dir *string) error {
+    if dir == nil || *dir == "" {
+        return nil
+    }
+    if err := checkDirWritable(*dir); err != nil {
+        return fmt.Errorf("invalid temp directory: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,427 @@
+#!/usr/bin/env python3
+"""
+Runs each step path of project_pipeline.py once with external tools and models faked, and
+checks the commands, files and project.json updates it produces.
+Usage: project_pipeline_paths.py <python scripts dir>. Exits non-zero if any check fails.
+"""
+
+import io
+import os
+import sys
+import json
+import shutil
+import tempfile
+import contextlib
+import subprocess
+import importlib.abc
+import importlib.machinery
+from unittest import mock
+
+# Third-party packages the scripts import; the ones that aren't installed are mocked
+MOCKED_PACKAGES = {
+    "dotenv", "faster_whisper", "huggingface_hub", "kokoro", "numpy", "pedalboard", "requests",
+    "soundfile", "torch", "torchaudio", "transformers", "whisperx", "youtube_transcript_api",
+}
+
+
+class _MockLoader(importlib.abc.Loader):
+    def create_module(self, spec):
+        module = mock.MagicMock(name=spec.name)
+        module.__path__ = []
+        module.__spec__ = spec
+        module.__name__ = spec.name
+        return module
+
+    def exec_module(self, module):
+        pass
+
+
+class _MockFinder(importlib.abc.MetaPathFinder):
+    """Last on sys.meta_path, so it only sees packages no real finder could load"""
+
+    def find_spec(self, name, path=None, target=None):
+        if name.split(".")[0] not in MOCKED_PACKAGES:
+            return None
+        return importlib.machinery.ModuleSpec(name, _MockLoader(), is_package=True)
+
+
+sys.meta_path.append(_MockFinder())
+sys.path.insert(0, os.path.abspath(sys.argv[1]))
+
+# Everything, including files the scripts drop in the working directory, lives here
+WORK_DIR = tempfile.mkdtemp(prefix="pipeline-paths-")
+os.chdir(WORK_DIR)
+
+import project_pipeline as pipeline  # noqa: E402
+
+COMMANDS = []
+
+
+class _Completed:
+    def __init__(self, stdout=""):
+        self.stdout = stdout
+        self.stderr = ""
+        self.returncode = 0
+
+
+def fake_run(cmd, *args, **kwargs):
+    """Records the command and creates the file a real tool would have written"""
+    cmd = [str(c) for c in cmd]
+    COMMANDS.append(cmd)
+    tool = os.path.basename(cmd[0])
+    if tool == "ffprobe":
+        return _Completed("10.0\n")
+    if tool == "yt-dlp":
+        write_file(cmd[cmd.index("-o") + 1].replace("%(ext)s", "mp4"))
+    elif tool == "ffmpeg":
+        write_file(cmd[-1])
+    return _Completed()
+
+
+subprocess.run = fake_run
+
+
+def write_file(path, data=b"media"):
+    with open(path, "wb") as f:
+        f.write(data)
+
+
+def commands(tool):
+    return [c for c in COMMANDS if os.path.basename(c[0]) == tool]
+
+
+def arg(cmd, flag):
+    return cmd[cmd.index(flag) + 1]
+
+
+@contextlib.contextmanager
+def env(**values):
+    saved = {key: os.environ.get(key) for key in values}
+    os.environ.update(values)
+    try:
+        yield
+    finally:
+        for key, value in saved.items():
+            if value is None:
+                os.environ.pop(key, None)
+            else:
+                os.environ[key] = value
+
+
+def make_project(**overrides):
+    """A local video project linked to a source file outside it"""
+    project_dir = tempfile.mkdtemp(prefix="project-", dir=WORK_DIR)
+    source = os.path.join(WORK_DIR, f"{os.path.basename(project_dir)}.mp4")
+    write_file(source)
+    project = {
+        "id": "p1",
+        "name": "Test",
+        "videoId": "vid1",
+        "sourceType": "video",
+        "targetLanguage": "es",
+        "fileReferences": {"videoFile": {"path": source, "isLinked": True}},
+        "completedSteps": {},
+        "settings": {"transcription": {}, "translation": {}},
+    }
+    project.update(overrides)
+    save_project(project_dir, project)
+    return project_dir
+
+
+def load_project(project_dir):
+    with open(os.path.join(project_dir, "project.json"), encoding="utf-8") as f:
+        return json.load(f)
+
+
+def save_project(project_dir, project):
+    with open(os.path.join(project_dir, "project.json"), "w", encoding="utf-8") as f:
+        json.dump(project, f)
+
+
+SEGMENTS = [
+    {"start": 0, "end": 1, "original_text": "Hello", "translated_text": "Hola", "target_duration": 1},
+    {"start": 1, "end": 2, "original_text": "World", "translated_text": "Mundo", "target_duration": 1},
+]
+
+
+def segments_path(project_dir):
+    return os.path.join(project_dir, "transcripts", "vid1_segments.json")
+
+
+def write_segments(project_dir, segments):
+    os.makedirs(os.path.dirname(segments_path(project_dir)), exist_ok=True)
+    with open(segments_path(project_dir), "w", encoding="utf-8") as f:
+        json.dump(segments, f)
+
+
+CHECKS = []
+
+
+def check(name):
+    def register(fn):
+        CHECKS.append((name, fn))
+        return fn
+    return register
+
+
+@check("import step through main()")
+def _():
+    project_dir = make_project()
+    out = io.StringIO()
+    sys.argv = ["project_pipeline.py", project_dir, "import"]
+    with contextlib.redirect_stdout(out):
+        try:
+            pipeline.main()
+        except SystemExit as e:
+            assert e.code == 0, (e.code, out.getvalue())
+    output = out.getvalue()
+    result = json.loads(output[output.rindex("\n{") + 1:] if "\n{" in output else output)
+    assert result["success"], result
+    completed = load_project(project_dir)["completedSteps"]
+    assert completed.get("download") is True and "import" not in completed, completed
+    assert os.path.exists(os.path.join(project_dir, "transcripts", "vid1.wav"))
+
+
+@check("import rejects URL projects")
+def _():
+    project_dir = make_project(sourceType="youtube", sourceUrl="https://youtu.be/dQw4w9WgXcQ")
+    result = pipeline.ProjectPipeline(project_dir).step_import()
+    assert not result["success"] and "downloaded" in result["error"], result
+
+
+@check("download passes the format and rate limit")
+def _():
+    project_dir = make_project(sourceType="youtube", sourceUrl="https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+                               videoId="dQw4w9WgXcQ", fileReferences={})
+    COMMANDS.clear()
+    with env(DOWNLOAD_FORMAT="bestaudio", DOWNLOAD_RATE_LIMIT="2M"), contextlib.redirect_stdout(io.StringIO()):
+        result = pipeline.ProjectPipeline(project_dir).step_download()
+    assert result["success"], result
+    cmd = commands("yt-dlp")[0]
+    assert arg(cmd, "-f") == "bestaudio" and arg(cmd, "--limit-rate") == "2M", cmd
+    ref = load_project(project_dir)["fileReferences"]["videoFile"]
+    assert os.path.exists(os.path.join(project_dir, ref["path"])), (ref, cmd)
+
+
+@check("yt-dlp fallback passes the format and rate limit")
+def _():
+    project_dir = make_project(sourceType="youtube", sourceUrl="https://youtu.be/dQw4w9WgXcQ",
+                               videoId="dQw4w9WgXcQ", fileReferences={})
+    COMMANDS.clear()
+    download_video = pipeline.download_video
+    del pipeline.download_video
+    try:
+        with env(DOWNLOAD_FORMAT="worst", DOWNLOAD_RATE_LIMIT="500K"):
+            result = pipeline.ProjectPipeline(project_dir).step_download()
+    finally:
+        pipeline.download_video = download_video
+    assert result["success"], result
+    cmd = commands("yt-dlp")[0]
+    assert arg(cmd, "-f") == "worst" and arg(cmd, "--limit-rate") == "500K", cmd
+    ref = load_project(project_dir)["fileReferences"]["videoFile"]
+    assert os.path.exists(os.path.join(project_dir, ref["path"])), ref
+
+
+@check("transcription audio uses the chosen stream")
+def _():
+    project_dir = make_project(settings={"transcription": {"sourceAudioStream": 1}, "translation": {}})
+    COMMANDS.clear()
+    pipeline.ProjectPipeline(project_dir).extract_transcription_audio("vid1")
+    assert arg(commands("ffmpeg")[-1], "-map") == "0:a:1", COMMANDS
+
+    COMMANDS.clear()
+    pipeline.ProjectPipeline(project_dir).extract_transcription_audio("vid1")
+    assert not commands("ffmpeg"), "the same stream should reuse the extracted audio"
+
+    project = load_project(project_dir)
+    project["settings"]["transcription"]["sourceAudioStream"] = 2
+    save_project(project_dir, project)
+    pipeline.ProjectPipeline(project_dir).extract_transcription_audio("vid1")
+    assert arg(commands("ffmpeg")[-1], "-map") == "0:a:2", COMMANDS
+
+
+@check("trimmed projects use the clip")
+def _():
+    project_dir = make_project(trimStart=1.0, trimEnd=5.0)
+    COMMANDS.clear()
+    result = pipeline.ProjectPipeline(project_dir).step_import()
+    assert result["success"], result
+    assert not commands("ffmpeg"), "import must not extract audio from the untrimmed source"
+
+    write_file(os.path.join(project_dir, "input", "clip.mp4"))
+    project = load_project(project_dir)
+    project["fileReferences"]["trimmedFile"] = "input/clip.mp4"
+    save_project(project_dir, project)
+    project_pipeline = pipeline.ProjectPipeline(project_dir)
+    assert project_pipeline.source_media_path().as_posix().endswith("input/clip.mp4")
+    project_pipeline.extract_transcription_audio("vid1")
+    assert arg(commands("ffmpeg")[-1], "-i").endswith("clip.mp4"), COMMANDS
+
+
+@check("transcription routes to the chosen backend")
+def _():
+    calls = {}
+
+    def backend(name):
+        def transcribe(*args, **kwargs):
+            calls[name] = (args, kwargs)
+            return [{"start": 0, "end": 1, "text": "hi"}]
+        return transcribe
+
+    normalized = [{"start": 0, "end": 1, "original_text": "hi", "translated_text": "", "target_duration": 1}]
+    with mock.patch.multiple(pipeline, create=True,
+                             transcribe_with_faster_whisper=backend("faster-whisper"),
+                             transcribe_with_openai=backend("openai"),
+                             transcribe_with_whisperx=backend("whisperx"),
+                             normalize_whisperx_segments=lambda transcript: normalized):
+        for chosen in ["faster-whisper", "openai", "whisperx", ""]:
+            project_dir = make_project()
+            calls.clear()
+            with env(TRANSCRIPTION_BACKEND=chosen, TRANSCRIPTION_SETTINGS=json.dumps({"model": "small"})):
+                result = pipeline.ProjectPipeline(project_dir).step_transcribe()
+            assert result["success"], (chosen, result)
+
+            expected = chosen or "whisperx"
+            assert list(calls) == [expected], (chosen, list(calls))
+            args, kwargs = calls[expected]
+            settings = kwargs["settings"] if expected == "whisperx" else args[3]
+            assert settings["model"] == "small", (chosen, settings)
+            refs = load_project(project_dir)["fileReferences"]
+            assert refs["segmentsFile"] == "transcripts/vid1_segments.json", refs
+
+
+@check("retranslation only touches the selected segments")
+def _():
+    selected = []
+
+    class FakeTranslationService:
+        def __init__(self, config=None):
+            pass
+
+        def translate_selected_segments(self, segments, indices):
+            selected.append(sorted(indices))
+            for i in indices:
+                segments[i].translated_text = "Nuevo"
+            return segments
+
+        def translate_segments(self, segments):
+            raise AssertionError("a full translation must not run")
+
+    project_dir = make_project()
+    write_segments(project_dir, SEGMENTS)
+    with mock.patch.object(pipeline, "TranslationService", FakeTranslationService, create=True), \
+            env(RETRANSLATE_SEGMENTS="[1]"):
+        result = pipeline.ProjectPipeline(project_dir).step_translate()
+    assert result["success"], result
+    assert selected == [[1]], selected
+    with open(segments_path(project_dir), encoding="utf-8") as f:
+        texts = [s["translated_text"] for s in json.load(f)]
+    assert texts == ["Hola", "Nuevo"], texts
+
+
+@check("translation uses the selected cloud provider")
+def _():
+    from util import translation_service
+
+    responses = {
+        "api.anthropic.com": {"content": [{"text": "1. Hola\n2. Mundo"}]},
+        "api-free.deepl.com": {"translations": [{"text": "Hola"}, {"text": "Mundo"}]},
+        "translation.googleapis.com": {"data": {"translations": [{"translatedText": "Hola"}, {"translatedText": "Mundo"}]}},
+    }
+    requested = []
+
+    def post(url, **kwargs):
+        host = url.split("/")[2]
+        requested.append((host, kwargs))
+        return mock.Mock(status_code=200, json=lambda: responses[host])
+
+    keys = {"ANTHROPIC_API_KEY": "a", "DEEPL_API_KEY": "d:fx", "GOOGLE_TRANSLATE_API_KEY": "g"}
+    with mock.patch.object(translation_service.requests, "post", post), env(**keys):
+        for provider, host in [("", "api.anthropic.com"), ("deepl", "api-free.deepl.com"),
+                               ("google", "translation.googleapis.com")]:
+            project_dir = make_project(settings={"transcription": {}, "translation": {"cloudProvider": provider}})
+            write_segments(project_dir, [dict(s, translated_text="") for s in SEGMENTS])
+            requested.clear()
+            with contextlib.redirect_stdout(io.StringIO()):
+                result = pipeline.ProjectPipeline(project_dir).step_translate()
+            assert result["success"], (provider, result)
+            assert [h for h, _ in requested] == [host], (provider, requested)
+            with open(segments_path(project_dir), encoding="utf-8") as f:
+                texts = [s["translated_text"] for s in json.load(f)]
+            assert texts == ["Hola", "Mundo"], (provider, texts)
+
+    # DeepL needs a regional variant for Portuguese
+    with mock.patch.object(translation_service.requests, "post", post), env(**keys):
+        requested.clear()
+        service = translation_service.TranslationService(config={"target_language": "pt", "translation_provider": "deepl"})
+        service.translate_single_text("Hello")
+    assert requested[0][1]["json"]["target_lang"] == "PT-BR", requested
+
+
+@contextlib.contextmanager
+def fake_mixing():
+    def mix(segments, path, duration, settings):
+        write_file(path)
+        return True
+
+    def merge(video_path, audio_path, output_path):
+        write_file(output_path)
+
+    with mock.patch.multiple(pipeline, create=True,
+                             create_enhanced_audio_track_with_loose_sync=mix,
+                             apply_audio_effects=lambda *args: False,
+                             merge_audio_with_video=merge):
+        yield
+
+
+@check("combine writes the chosen output format")
+def _():
+    with fake_mixing():
+        for output_format in ["mp4", "mkv", "mp3", "flac"]:
+            project_dir = make_project()
+            write_segments(project_dir, SEGMENTS)
+            with env(OUTPUT_FORMAT=output_format, OUTPUT_BASENAME="Talk ES"):
+                result = pipeline.ProjectPipeline(project_dir).step_combine()
+            assert result["success"], (output_format, result)
+
+            expected = os.path.join(project_dir, "output", f"Talk ES.{output_format}")
+            refs = load_project(project_dir)["fileReferences"]
+            if output_format in ("mp3", "flac"):
+                assert result["finalAudioPath"] == expected and "finalVideoPath" not in result, result
+                assert "finalVideo" not in refs, refs
+            else:
+                assert result["finalVideoPath"] == expected, result
+            assert os.path.exists(expected), expected
+
+
+@check("combine writes to the project output directory")
+def _():
+    output_dir = tempfile.mkdtemp(prefix="output-", dir=WORK_DIR)
+    project_dir = make_project(outputDir=output_dir)
+    write_segments(project_dir, SEGMENTS)
+    with fake_mixing():
+        result = pipeline.ProjectPipeline(project_dir).step_combine()
+    assert result["success"], result
+    assert os.path.dirname(result["finalVideoPath"]) == output_dir, result
+    assert load_project(project_dir)["fileReferences"]["finalVideo"] == result["finalVideoPath"]
+
+
+def main():
+    failed = 0
+    for name, fn in CHECKS:
+        try:
+            # The pipeline logs to stderr; only show it for failures
+            log = io.StringIO()
+            with contextlib.redirect_stderr(log):
+                fn()
+            print(f"PASS {name}")
+        except Exception as e:
+            failed += 1
+            print(f"FAIL {name}: {type(e).__name__}: {e}\n{log.getvalue()}")
+    os.chdir(os.path.dirname(WORK_DIR))
+    shutil.rmtree(WORK_DIR, ignore_errors=True)
+    sys.exit(1 if failed else 0)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,111 @@
+package main
+
+import (
+    "fmt"
+    "regexp"
+    "sort"
+)
+
+// textRulePriorities orders rules the way rules/apply_text_rules.py does; unknown
+// priorities count as medium
+var textRulePriorities = map[string]int{"high": 0, "medium": 1, "low": 2}
+
+// compileTextRule turns a rule into the pattern the pipeline replaces. Literal rules match
+// their original text exactly; regex rules compile it as a Go regular expression. Either is
+// case-insensitive unless the rule says otherwise, as rules/apply_text_rules.py does.
+func compileTextRule(rule TextRule) (*regexp.Regexp, error) {
+    if rule.OriginalText == "" {
+        return nil, fmt.Errorf("rule has no original text")
+    }
+
+    pattern := rule.OriginalText
+    if !rule.IsRegex {
+        pattern = regexp.QuoteMeta(pattern)
+    }
+    if !rule.CaseSensitive {
+        pattern = "(?i)" + pattern
+    }
+    re, err := regexp.Compile(pattern)
+    if err != nil {
+        return nil, fmt.Errorf("invalid rule pattern %q: %w", rule.OriginalText, err)
+    }
+    if rule.IsRegex && re.MatchString("") {
+        return nil, fmt.Errorf("rule pattern %q matches empty text, so it would insert the replacement everywhere", rule.OriginalText)
+    }
+    return re, nil
+}
+
+// applyTextRule replaces every match of the rule in text. Only regex rules expand group
+// references in the replacement; a literal rule's "$1" stays "$1".
+func applyTextRule(rule TextRule, text string) (string, error) {
+    re, err := compileTextRule(rule)
+    if err != nil {
+        return "", err
+    }
+    if rule.IsRegex {
+        return re.ReplaceAllString(text, rule.ReplacementText), nil
+    }
+    return re.ReplaceAllLiteralString(text, rule.ReplacementText), nil
+}
+
+// ApplyTextRules applies a project's text rules for language to text, highest priority
+// first, the same way the translate step does
+func (a *App) ApplyTextRules(projectID, language, text string) (string, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return "", fmt.Errorf("failed to load project: %w", err)
+    }
+    return applyTextRules(project.TextRules, language, text)
+}
+
+func applyTextRules(rules []TextRule, language, text string) (string, error) {
+    ordered := append([]TextRule{}, rules...)
+    sort.SliceStable(ordered, func(i, j int) bool {
+        return textRulePriority(ordered[i]) < textRulePriority(ordered[j])
+    })
+
+    for _, rule := range ordered {
+        if rule.Language != "" && rule.Language != "all" && rule.Language != language {
+            continue
+        }
+        // The pipeline skips rules without a replacement rather than deleting the match
+        if rule.OriginalText == "" || rule.ReplacementText == "" {
+            continue
+        }
+        var err error
+        if text, err = applyTextRule(rule, text); err != nil {
+            return "", err
+        }
+    }
+    return text, nil
+}
+
+func textRulePriority(rule TextRule) int {
+    if priority, ok := textRulePriorities[rule.Priority]; ok {
+        return priority
+    }
+    return textRulePriorities["medium"]
+}
+
+// validateTextRules checks every regex rule compiles, naming the rule that doesn't
+func validateTextRules(rules []TextRule) error {
+    for i, rule := range rules {
+        if !rule.IsRegex {
+            continue
+        }
+        if _, err := compileTextRule(rule); err != nil {
+            return fmt.Errorf("text rule %d: %w", i+1, err)
+        }
+    }
+    return nil
+}
+
+// TestTextRule applies just this rule to sampleText, so a rule can be tried before it is
+// saved. The rule's language is ignored.
+func (a *App) TestTextRule(rule TextRule, sampleText string) (string, error) {
+    // The pipeline skips rules without a replacement rather than deleting the match
+    if rule.ReplacementText == "" {
+        return "", fmt.Errorf("rule has no replacement text, so the pipeline would skip it")
+    }
+    return applyTextRule(rule, sampleText)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "math"
+    "os/exec"
+    "strconv"
+)
+
+// ExtractFrame grabs a single JPEG frame of a video at the given time, for scrubbable previews
+func (a *App) ExtractFrame(path string, timestampSec float64) ([]byte, error) {
+    if !fileExists(path) {
+        return nil, fmt.Errorf("media file not found: %s", path)
+    }
+    if math.IsNaN(timestampSec) || math.IsInf(timestampSec, 0) || timestampSec < 0 {
+        return nil, fmt.Errorf("invalid timestamp: %v", timestampSec)
+    }
+
+    ffmpeg, err := exec.LookPath("ffmpeg")
+    if err != nil {
+        return nil, fmt.Errorf("ffmpeg is required to extract frames")
+    }
+
+    duration, probed, err := a.probeMediaDuration(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read media duration: %w", err)
+    }
+    if probed && duration > 0 && timestampSec >= duration {
+        return nil, fmt.Errorf("timestamp %.3fs is beyond the end of the media (%.3fs)", timestampSec, duration)
+    }
+
+    // Seeking before the input is fast and, with modern ffmpeg, still frame-accurate
+    cmd := a.trackedCommand(ffmpeg,
+        "-v", "error",
+        "-ss", strconv.FormatFloat(timestampSec, 'f', 3, 64),
+        "-i", path,
+        "-frames:v", "1",
+        "-f", "image2",
+        "-c:v", "mjpeg",
+        "-q:v", "3",
+        "pipe:1",
+    )
+    defer a.untrackCommand(cmd)
+
+    var stderr bytes.Buffer
+    cmd.Stderr = &stderr
+    frame, err := a.commandOutput(cmd)
+    if err != nil {
+        return nil, fmt.Errorf("failed to extract frame: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+    }
+    if len(frame) == 0 {
+        return nil, fmt.Errorf("no video frame at %.3fs", timestampSec)
+    }
+
+    return frame, nil
+}
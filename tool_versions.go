@@ -0,0 +1,122 @@
+package main
+
+import (
+    "fmt"
+    "os/exec"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// CompatResult is the outcome of checking one external tool's version
+type CompatResult struct {
+    Tool     string `json:"tool"`
+    Status   string `json:"status"` // "pass", "warn" or "fail"
+    Detected string `json:"detected,omitempty"`
+    Required string `json:"required"`
+    Message  string `json:"message,omitempty"`
+}
+
+// toolRequirement is the minimum version of an external tool the pipeline works with
+type toolRequirement struct {
+    name    string
+    args    []string
+    pattern *regexp.Regexp
+    minimum string
+}
+
+// yt-dlp versions are dates; older releases fail on current YouTube pages
+var toolRequirements = []toolRequirement{
+    {name: "yt-dlp", args: []string{"--version"}, pattern: regexp.MustCompile(`(\d{4}\.\d{2}\.\d{2})`), minimum: "2024.03.10"},
+    {name: "ffmpeg", args: []string{"-version"}, pattern: regexp.MustCompile(`ffmpeg version n?(\d+\.\d+(?:\.\d+)?)`), minimum: "4.4"},
+    {name: "ffprobe", args: []string{"-version"}, pattern: regexp.MustCompile(`ffprobe version n?(\d+\.\d+(?:\.\d+)?)`), minimum: "4.4"},
+    {name: "python", args: []string{"--version"}, pattern: regexp.MustCompile(`Python (\d+\.\d+(?:\.\d+)?)`), minimum: "3.9"},
+}
+
+// CheckToolCompatibility checks that the external tools the pipeline shells out to are
+// installed and recent enough, so outdated tools are caught during onboarding
+func (a *App) CheckToolCompatibility() ([]CompatResult, error) {
+    results := make([]CompatResult, 0, len(toolRequirements))
+    for _, req := range toolRequirements {
+        command := req.name
+        if req.name == "python" {
+            command = a.getPythonCommand()
+        }
+        results = append(results, a.checkToolVersion(command, req))
+    }
+    return results, nil
+}
+
+func (a *App) checkToolVersion(command string, req toolRequirement) CompatResult {
+    result := CompatResult{
+        Tool:     req.name,
+        Required: req.minimum,
+    }
+
+    path, err := exec.LookPath(command)
+    if err != nil {
+        result.Status = "fail"
+        result.Message = fmt.Sprintf("%s was not found; install it and make sure it is on PATH", req.name)
+        return result
+    }
+
+    cmd := a.trackedCommand(path, req.args...)
+    defer a.untrackCommand(cmd)
+
+    // Some tools (older Pythons) print their version to stderr
+    output, err := a.commandCombinedOutput(cmd)
+    if err != nil {
+        result.Status = "fail"
+        result.Message = fmt.Sprintf("failed to run %s: %v", req.name, err)
+        return result
+    }
+
+    match := req.pattern.FindStringSubmatch(string(output))
+    if match == nil {
+        // Custom/nightly builds often report something unparseable (e.g. a git hash)
+        result.Status = "warn"
+        result.Detected = firstLine(string(output))
+        result.Message = "could not determine the version; it may be a development build"
+        return result
+    }
+
+    result.Detected = match[1]
+    if compareVersions(result.Detected, req.minimum) < 0 {
+        result.Status = "fail"
+        result.Message = fmt.Sprintf("%s %s is older than the required %s; please update it", req.name, result.Detected, req.minimum)
+        return result
+    }
+
+    result.Status = "pass"
+    return result
+}
+
+// compareVersions compares dotted numeric versions, returning -1, 0 or 1
+func compareVersions(a, b string) int {
+    as := strings.Split(a, ".")
+    bs := strings.Split(b, ".")
+    for i := 0; i < len(as) || i < len(bs); i++ {
+        var x, y int
+        if i < len(as) {
+            x, _ = strconv.Atoi(as[i])
+        }
+        if i < len(bs) {
+            y, _ = strconv.Atoi(bs[i])
+        }
+        if x != y {
+            if x < y {
+                return -1
+            }
+            return 1
+        }
+    }
+    return 0
+}
+
+func firstLine(s string) string {
+    s = strings.TrimSpace(s)
+    if i := strings.IndexByte(s, '\n'); i >= 0 {
+        return strings.TrimSpace(s[:i])
+    }
+    return s
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// TranscriptDraft is an autosaved, uncommitted copy of a project's transcript edits
+type TranscriptDraft struct {
+    Segments []TranscriptSegment `json:"segments"`
+    SavedAt  string              `json:"savedAt"`
+    // NewerThanSegments is set when the draft was saved after the segments file last changed,
+    // i.e. it holds edits that would otherwise be lost
+    NewerThanSegments bool `json:"newerThanSegments"`
+}
+
+// transcriptDraftPath returns where a project's transcript draft is kept, next to its segments file
+func (a *App) transcriptDraftPath(projectID string) (string, string, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return "", "", fmt.Errorf("failed to load project: %w", err)
+    }
+
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return "", "", fmt.Errorf("project not found: %w", err)
+    }
+
+    segmentsPath := projectSegmentsPath(projectDir, project)
+    return segmentsPath + ".draft", segmentsPath, nil
+}
+
+// SaveTranscriptDraft autosaves in-progress transcript edits without touching the segments file
+func (a *App) SaveTranscriptDraft(projectID string, segments []TranscriptSegment) error {
+    draftPath, _, err := a.transcriptDraftPath(projectID)
+    if err != nil {
+        return err
+    }
+
+    data, err := json.MarshalIndent(segments, "", "  ")
+    if err != nil {
+        return fmt.Errorf("failed to marshal transcript draft: %w", err)
+    }
+
+    if err := os.MkdirAll(filepath.Dir(draftPath), 0755); err != nil {
+        return fmt.Errorf("failed to create transcripts directory: %w", err)
+    }
+
+    // Write then rename so a crash mid-save never leaves a truncated draft behind
+    tmpPath := draftPath + ".tmp"
+    if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+        return fmt.Errorf("failed to write transcript draft: %w", err)
+    }
+    if err := os.Rename(tmpPath, draftPath); err != nil {
+        os.Remove(tmpPath)
+        return fmt.Errorf("failed to save transcript draft: %w", err)
+    }
+
+    return nil
+}
+
+// GetTranscriptDraft returns the project's autosaved transcript draft, or nil if there is none
+func (a *App) GetTranscriptDraft(projectID string) (*TranscriptDraft, error) {
+    draftPath, segmentsPath, err := a.transcriptDraftPath(projectID)
+    if err != nil {
+        return nil, err
+    }
+
+    info, err := os.Stat(draftPath)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil, nil
+        }
+        return nil, fmt.Errorf("failed to read transcript draft: %w", err)
+    }
+
+    data, err := os.ReadFile(draftPath)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read transcript draft: %w", err)
+    }
+
+    var segments []TranscriptSegment
+    if err := json.Unmarshal(data, &segments); err != nil {
+        return nil, fmt.Errorf("failed to parse transcript draft: %w", err)
+    }
+
+    newer := true
+    if segmentsInfo, err := os.Stat(segmentsPath); err == nil {
+        newer = info.ModTime().After(segmentsInfo.ModTime())
+    }
+
+    return &TranscriptDraft{
+        Segments:          segments,
+        SavedAt:           info.ModTime().Format(time.RFC3339),
+        NewerThanSegments: newer,
+    }, nil
+}
+
+// DiscardTranscriptDraft deletes the project's autosaved transcript draft, if any
+func (a *App) DiscardTranscriptDraft(projectID string) error {
+    draftPath, _, err := a.transcriptDraftPath(projectID)
+    if err != nil {
+        return err
+    }
+
+    if err := os.Remove(draftPath); err != nil && !os.IsNotExist(err) {
+        return fmt.Errorf("failed to discard transcript draft: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+    "fmt"
+    "os"
+)
+
+// maxTranscriptUndo caps how many edits of a project can be undone. Each snapshot is a
+// whole segments file, so this bounds memory to a few dozen copies of it.
+const maxTranscriptUndo = 20
+
+// transcriptSnapshot is the segments file as it was before or after an edit
+type transcriptSnapshot struct {
+    segmentsPath string
+    data         []byte
+    // step is the last step whose output the edit left valid; restoring the snapshot
+    // invalidates the steps after it again
+    step string
+}
+
+// transcriptHistory is one project's undo and redo stacks, most recent last. The history
+// lasts for the session: it isn't saved with the project.
+type transcriptHistory struct {
+    undo []transcriptSnapshot
+    redo []transcriptSnapshot
+}
+
+// recordTranscriptEdit runs apply, which rewrites the segments file, and makes it undoable.
+// step is as for transcriptSnapshot.
+func (a *App) recordTranscriptEdit(projectID, segmentsPath, step string, apply func() error) error {
+    before, err := os.ReadFile(segmentsPath)
+    if err != nil {
+        return fmt.Errorf("failed to read segments file: %w", err)
+    }
+    if err := apply(); err != nil {
+        return err
+    }
+
+    a.transcriptUndoMu.Lock()
+    defer a.transcriptUndoMu.Unlock()
+    history := a.transcriptHistoryFor(projectID)
+    history.undo = pushSnapshot(history.undo, transcriptSnapshot{segmentsPath: segmentsPath, data: before, step: step})
+    history.redo = nil
+    return nil
+}
+
+// UndoTranscriptEdit restores the segments file to before the last merge, split or
+// re-translation. The edit can be redone with RedoTranscriptEdit.
+func (a *App) UndoTranscriptEdit(projectID string) error {
+    return a.stepTranscriptHistory(projectID, true)
+}
+
+// RedoTranscriptEdit re-applies the last edit undone by UndoTranscriptEdit
+func (a *App) RedoTranscriptEdit(projectID string) error {
+    return a.stepTranscriptHistory(projectID, false)
+}
+
+func (a *App) stepTranscriptHistory(projectID string, undo bool) error {
+    a.transcriptUndoMu.Lock()
+    history := a.transcriptHistoryFor(projectID)
+    from, to := &history.undo, &history.redo
+    if !undo {
+        from, to = to, from
+    }
+    if len(*from) == 0 {
+        a.transcriptUndoMu.Unlock()
+        if undo {
+            return fmt.Errorf("nothing to undo")
+        }
+        return fmt.Errorf("nothing to redo")
+    }
+    snapshot := (*from)[len(*from)-1]
+
+    current, err := os.ReadFile(snapshot.segmentsPath)
+    if err != nil {
+        a.transcriptUndoMu.Unlock()
+        return fmt.Errorf("failed to read segments file: %w", err)
+    }
+    if err := writeSegmentsFile(snapshot.segmentsPath, snapshot.data); err != nil {
+        a.transcriptUndoMu.Unlock()
+        return err
+    }
+    *from = (*from)[:len(*from)-1]
+    *to = pushSnapshot(*to, transcriptSnapshot{segmentsPath: snapshot.segmentsPath, data: current, step: snapshot.step})
+    a.transcriptUndoMu.Unlock()
+
+    if err := a.invalidateStepsAfter(projectID, snapshot.step); err != nil {
+        return err
+    }
+    a.emitEvent("project:updated", projectID)
+    return nil
+}
+
+// transcriptHistoryFor returns the project's history, creating it. Callers hold transcriptUndoMu.
+func (a *App) transcriptHistoryFor(projectID string) *transcriptHistory {
+    if a.transcriptUndo == nil {
+        a.transcriptUndo = make(map[string]*transcriptHistory)
+    }
+    history, ok := a.transcriptUndo[projectID]
+    if !ok {
+        history = &transcriptHistory{}
+        a.transcriptUndo[projectID] = history
+    }
+    return history
+}
+
+// pushSnapshot appends a snapshot, dropping the oldest beyond maxTranscriptUndo
+func pushSnapshot(stack []transcriptSnapshot, snapshot transcriptSnapshot) []transcriptSnapshot {
+    stack = append(stack, snapshot)
+    if len(stack) > maxTranscriptUndo {
+        stack = append([]transcriptSnapshot{}, stack[len(stack)-maxTranscriptUndo:]...)
+    }
+    return stack
+}
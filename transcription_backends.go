@@ -0,0 +1,204 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "strings"
+    "sync"
+)
+
+// BackendInfo describes a transcription backend and whether it can be used right now
+type BackendInfo struct {
+    ID   string `json:"id"`
+    Name string `json:"name"`
+    // Kind is "local" for backends running on this machine and "cloud" for remote APIs
+    Kind string `json:"kind"`
+    // Installed reports whether the backend's Python package is importable
+    Installed bool `json:"installed"`
+    // APIKeyEnv and APIKeyConfigured are only set for cloud backends
+    APIKeyEnv        string `json:"apiKeyEnv,omitempty"`
+    APIKeyConfigured bool   `json:"apiKeyConfigured,omitempty"`
+    Available        bool   `json:"available"`
+    // Message says what's missing when the backend isn't available
+    Message             string   `json:"message,omitempty"`
+    Models              []string `json:"models"`
+    DefaultModel        string   `json:"defaultModel"`
+    SupportsDiarization bool     `json:"supportsDiarization"`
+}
+
+// transcriptionBackend is one value of TranscriptionSettings.Source
+type transcriptionBackend struct {
+    id      string
+    name    string
+    kind    string
+    // pkg is the Python distribution that has to be installed
+    pkg          string
+    keyEnvs      []string
+    models       []string
+    defaultModel string
+    diarization  bool
+    // computeTypes are the accepted TranscriptionSettings.ComputeType values, if any
+    computeTypes []string
+    beamSize     bool
+}
+
+const defaultTranscriptionBackend = "whisperx"
+
+// localWhisperModels are the model names the local backends accept, see whisperModels
+var localWhisperModels = []string{"tiny", "base", "small", "medium", "large-v2", "large-v3", "large"}
+
+var transcriptionBackends = []transcriptionBackend{
+    {
+        id:           "whisperx",
+        name:         "WhisperX (Local AI)",
+        kind:         "local",
+        pkg:          "whisperx",
+        models:       localWhisperModels,
+        defaultModel: defaultWhisperModel,
+        diarization:  true,
+        computeTypes: []string{"float32", "float16", "int8"},
+    },
+    {
+        id:           "faster-whisper",
+        name:         "faster-whisper (Local AI, no alignment)",
+        kind:         "local",
+        pkg:          "faster-whisper",
+        models:       localWhisperModels,
+        defaultModel: "large-v3",
+        computeTypes: []string{"float32", "float16", "int8", "int8_float16"},
+        beamSize:     true,
+    },
+    {
+        id:           "openai",
+        name:         "OpenAI Speech to Text",
+        kind:         "cloud",
+        pkg:          "requests",
+        keyEnvs:      []string{"OPENAI_API_KEY"},
+        // The newer transcription models don't return segment timestamps, which dubbing needs
+        models:       []string{"whisper-1"},
+        defaultModel: "whisper-1",
+    },
+}
+
+// legacyTranscriptionSources were offered by older versions of the UI but always ran WhisperX
+var legacyTranscriptionSources = map[string]bool{"youtube": true, "upload": true}
+
+const maxBeamSize = 10
+
+// transcriptionBackendFor looks up the backend a TranscriptionSettings.Source selects
+func transcriptionBackendFor(source string) (transcriptionBackend, bool) {
+    id := strings.ToLower(strings.TrimSpace(source))
+    if id == "" || legacyTranscriptionSources[id] {
+        id = defaultTranscriptionBackend
+    }
+    for _, backend := range transcriptionBackends {
+        if backend.id == id {
+            return backend, true
+        }
+    }
+    return transcriptionBackend{}, false
+}
+
+// GetTranscriptionBackends lists the transcription backends and whether each is installed
+// and configured, so the UI can offer only the usable ones
+func (a *App) GetTranscriptionBackends() ([]BackendInfo, error) {
+    backends := make([]BackendInfo, len(transcriptionBackends))
+
+    // Each installed check starts Python, so run them side by side
+    var wg sync.WaitGroup
+    for i, backend := range transcriptionBackends {
+        wg.Add(1)
+        go func(i int, backend transcriptionBackend) {
+            defer wg.Done()
+            backends[i] = a.backendInfo(backend)
+        }(i, backend)
+    }
+    wg.Wait()
+
+    return backends, nil
+}
+
+func (a *App) backendInfo(backend transcriptionBackend) BackendInfo {
+    info := BackendInfo{
+        ID:                  backend.id,
+        Name:                backend.name,
+        Kind:                backend.kind,
+        Installed:           a.pythonPackageVersion(backend.pkg) != "",
+        Models:              append([]string{}, backend.models...),
+        DefaultModel:        backend.defaultModel,
+        SupportsDiarization: backend.diarization,
+    }
+    if len(backend.keyEnvs) > 0 {
+        info.APIKeyEnv = backend.keyEnvs[0]
+        info.APIKeyConfigured = apiKeyConfigured(backend.keyEnvs)
+    }
+
+    switch {
+    case !info.Installed:
+        info.Message = fmt.Sprintf("Python package %s is not installed", backend.pkg)
+    case len(backend.keyEnvs) > 0 && !info.APIKeyConfigured:
+        info.Message = fmt.Sprintf("%s is not set", info.APIKeyEnv)
+    default:
+        info.Available = true
+    }
+    return info
+}
+
+func apiKeyConfigured(envs []string) bool {
+    for _, env := range envs {
+        if os.Getenv(env) != "" {
+            return true
+        }
+    }
+    return false
+}
+
+// validateTranscriptionSettings checks the backend exists and the backend-specific settings
+// are ones it accepts. Whether it is installed is only checked when transcribing.
+func validateTranscriptionSettings(settings TranscriptionSettings) error {
+    backend, ok := transcriptionBackendFor(settings.Source)
+    if !ok {
+        return fmt.Errorf("unknown transcription backend: %s", settings.Source)
+    }
+
+    if settings.Model != nil && *settings.Model != "" && !containsString(backend.models, *settings.Model) {
+        return fmt.Errorf("%s has no model %q (expected one of %s)", backend.name, *settings.Model, strings.Join(backend.models, ", "))
+    }
+    if settings.ComputeType != "" && !containsString(backend.computeTypes, settings.ComputeType) {
+        if len(backend.computeTypes) == 0 {
+            return fmt.Errorf("%s doesn't take a compute type", backend.name)
+        }
+        return fmt.Errorf("invalid compute type %q for %s (expected one of %s)", settings.ComputeType, backend.name, strings.Join(backend.computeTypes, ", "))
+    }
+    if settings.BeamSize != 0 {
+        if !backend.beamSize {
+            return fmt.Errorf("%s doesn't take a beam size", backend.name)
+        }
+        if settings.BeamSize < 1 || settings.BeamSize > maxBeamSize {
+            return fmt.Errorf("beam size must be between 1 and %d, got %d", maxBeamSize, settings.BeamSize)
+        }
+    }
+    return nil
+}
+
+// requireTranscriptionBackend fails the transcribe step early, with a useful message, when
+// the selected backend can't run
+func (a *App) requireTranscriptionBackend(settings TranscriptionSettings) (transcriptionBackend, error) {
+    if err := validateTranscriptionSettings(settings); err != nil {
+        return transcriptionBackend{}, err
+    }
+    backend, _ := transcriptionBackendFor(settings.Source)
+    if info := a.backendInfo(backend); !info.Available {
+        return transcriptionBackend{}, fmt.Errorf("transcription backend %s is not available: %s", backend.name, info.Message)
+    }
+    return backend, nil
+}
+
+func containsString(values []string, value string) bool {
+    for _, v := range values {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
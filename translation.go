@@ -0,0 +1,290 @@
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+    "time"
+)
+
+// translationPreviewRequest is the payload passed to preview_translation.py
+type translationPreviewRequest struct {
+    Text           string              `json:"text"`
+    SourceLanguage string              `json:"sourceLanguage"`
+    TargetLanguage string              `json:"targetLanguage"`
+    Translation    TranslationSettings `json:"translation"`
+}
+
+// PreviewTranslation translates a single piece of text using the project's translation settings
+func (a *App) PreviewTranslation(projectID, text string) (string, error) {
+    if strings.TrimSpace(text) == "" {
+        return "", fmt.Errorf("text to translate is empty")
+    }
+    
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return "", fmt.Errorf("failed to load project: %w", err)
+    }
+    
+    request := translationPreviewRequest{
+        Text:           text,
+        SourceLanguage: project.Settings.Transcription.Language,
+        TargetLanguage: project.TargetLanguage,
+        Translation:    project.Settings.Translation,
+    }
+    
+    requestJSON, err := json.Marshal(request)
+    if err != nil {
+        return "", err
+    }
+    
+    pythonDir := a.getPythonScriptsDir()
+    cmd := a.trackedCommand(a.getPythonCommand(), filepath.Join(pythonDir, "preview_translation.py"), string(requestJSON))
+    defer a.untrackCommand(cmd)
+    cmd.Dir = pythonDir
+    cmd.Env = append(os.Environ(), fmt.Sprintf("PYTHONPATH=%s", pythonDir))
+    
+    var stderr bytes.Buffer
+    cmd.Stderr = &stderr
+    output, runErr := a.commandOutput(cmd)
+    
+    var result struct {
+        Success     bool   `json:"success"`
+        Translation string `json:"translation"`
+        Error       string `json:"error"`
+    }
+    if err := json.Unmarshal(output, &result); err != nil {
+        if runErr != nil {
+            return "", fmt.Errorf("translation preview failed: %v\nOutput: %s", runErr, stderr.String())
+        }
+        return "", fmt.Errorf("failed to parse translation output: %w\nOutput: %s", err, string(output))
+    }
+    
+    if !result.Success {
+        return "", fmt.Errorf("translation preview failed: %s", result.Error)
+    }
+    
+    return result.Translation, nil
+}
+
+const (
+    minContextWindow = 0
+    maxContextWindow = 20
+)
+
+// ValidateTranslationSettings checks that the translation settings are complete and within bounds
+func (a *App) ValidateTranslationSettings(settings TranslationSettings) error {
+    switch settings.Mode {
+    case "simple":
+        if strings.TrimSpace(settings.SimpleModel) == "" && settings.CloudProvider == nil {
+            return fmt.Errorf("simple translation mode requires a model")
+        }
+    case "advanced":
+        advanced := settings.AdvancedSettings
+        if advanced == nil {
+            return fmt.Errorf("advanced translation mode requires advanced settings")
+        }
+        if len(advanced.Models) == 0 {
+            return fmt.Errorf("advanced translation mode requires at least one model")
+        }
+        for i, model := range advanced.Models {
+            if strings.TrimSpace(model) == "" {
+                return fmt.Errorf("advanced translation model %d is empty", i+1)
+            }
+        }
+        if advanced.ContextWindow < minContextWindow || advanced.ContextWindow > maxContextWindow {
+            return fmt.Errorf("context window must be between %d and %d segments, got %d",
+                minContextWindow, maxContextWindow, advanced.ContextWindow)
+        }
+    default:
+        return fmt.Errorf("invalid translation mode: %s", settings.Mode)
+    }
+    
+    return nil
+}
+
+// TranslationProvider describes a cloud translation backend and what it can do
+type TranslationProvider struct {
+    ID               string   `json:"id"`
+    Name             string   `json:"name"`
+    Languages        []string `json:"languages"`
+    APIKeyEnv        string   `json:"apiKeyEnv"`
+    APIKeyConfigured bool     `json:"apiKeyConfigured"`
+}
+
+// translationProviders lists the cloud providers the translate step can run. Languages are
+// ISO 639-1 target codes. Only add a provider once the Python side implements it.
+var translationProviders = []struct {
+    id        string
+    name      string
+    keyEnvs   []string
+    languages []string
+}{
+    {
+        id:        "anthropic",
+        name:      "Anthropic Claude",
+        keyEnvs:   []string{"ANTHROPIC_API_KEY", "CLAUDE_API_KEY"},
+        languages: []string{"ar", "de", "es", "fr", "hi", "it", "ja", "ko", "nl", "pt", "ru", "zh"},
+    },
+    {
+        id:        "deepl",
+        name:      "DeepL",
+        keyEnvs:   []string{"DEEPL_API_KEY"},
+        languages: []string{"ar", "bg", "cs", "da", "de", "el", "en", "es", "et", "fi", "fr", "hu", "id", "it",
+            "ja", "ko", "lt", "lv", "nb", "nl", "pl", "pt", "ro", "ru", "sk", "sl", "sv", "tr", "uk", "zh"},
+    },
+    {
+        id:        "google",
+        name:      "Google Cloud Translation",
+        keyEnvs:   []string{"GOOGLE_TRANSLATE_API_KEY", "GOOGLE_API_KEY"},
+        languages: []string{"af", "ar", "bg", "bn", "ca", "cs", "da", "de", "el", "en", "es", "et", "fa", "fi",
+            "fr", "he", "hi", "hr", "hu", "id", "it", "ja", "ko", "lt", "lv", "ms", "nl", "no", "pl", "pt", "ro",
+            "ru", "sk", "sl", "sr", "sv", "sw", "ta", "th", "tl", "tr", "uk", "ur", "vi", "zh"},
+    },
+}
+
+// GetTranslationProviders returns the supported cloud translation providers and whether each has an API key configured
+func (a *App) GetTranslationProviders() ([]TranslationProvider, error) {
+    providers := make([]TranslationProvider, 0, len(translationProviders))
+    for _, p := range translationProviders {
+        configured := false
+        for _, env := range p.keyEnvs {
+            if os.Getenv(env) != "" {
+                configured = true
+                break
+            }
+        }
+        
+        providers = append(providers, TranslationProvider{
+            ID:               p.id,
+            Name:             p.name,
+            Languages:        append([]string{}, p.languages...),
+            APIKeyEnv:        p.keyEnvs[0],
+            APIKeyConfigured: configured,
+        })
+    }
+    
+    return providers, nil
+}
+
+// validateTranslationProvider checks that the selected cloud provider exists and supports the target language
+func validateTranslationProvider(settings TranslationSettings, targetLang string) error {
+    if settings.CloudProvider == nil || *settings.CloudProvider == "" {
+        return nil
+    }
+    
+    providerID := strings.ToLower(*settings.CloudProvider)
+    lang := strings.ToLower(targetLang)
+    for _, p := range translationProviders {
+        if p.id != providerID {
+            continue
+        }
+        for _, supported := range p.languages {
+            if supported == lang {
+                return nil
+            }
+        }
+        return fmt.Errorf("%s does not support target language: %s", p.name, targetLang)
+    }
+    
+    return fmt.Errorf("unknown translation provider: %s", *settings.CloudProvider)
+}
+
+// GetGlossary returns the project's translation glossary
+func (a *App) GetGlossary(projectID string) ([]GlossaryEntry, error) {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load project: %w", err)
+    }
+    
+    if project.Settings.Translation.Glossary == nil {
+        return []GlossaryEntry{}, nil
+    }
+    return project.Settings.Translation.Glossary, nil
+}
+
+// AddGlossaryEntry adds a required source -> target term to the project's glossary
+func (a *App) AddGlossaryEntry(projectID string, entry GlossaryEntry) (*GlossaryEntry, error) {
+    if err := validateGlossaryEntry(entry); err != nil {
+        return nil, err
+    }
+    
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load project: %w", err)
+    }
+    
+    for _, existing := range project.Settings.Translation.Glossary {
+        if strings.EqualFold(existing.SourceTerm, entry.SourceTerm) {
+            return nil, fmt.Errorf("glossary already contains term: %s", existing.SourceTerm)
+        }
+    }
+    
+    entryID, err := generateProjectID()
+    if err != nil {
+        return nil, fmt.Errorf("failed to generate glossary entry ID: %w", err)
+    }
+    entry.ID = entryID
+    entry.CreatedAt = time.Now().Format(time.RFC3339)
+    
+    project.Settings.Translation.Glossary = append(project.Settings.Translation.Glossary, entry)
+    if err := a.UpdateProject(project); err != nil {
+        return nil, err
+    }
+    
+    return &entry, nil
+}
+
+// UpdateGlossaryEntry replaces an existing glossary entry, matched by ID
+func (a *App) UpdateGlossaryEntry(projectID string, entry GlossaryEntry) error {
+    if err := validateGlossaryEntry(entry); err != nil {
+        return err
+    }
+    
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    
+    glossary := project.Settings.Translation.Glossary
+    for i, existing := range glossary {
+        if existing.ID == entry.ID {
+            entry.CreatedAt = existing.CreatedAt
+            glossary[i] = entry
+            return a.UpdateProject(project)
+        }
+    }
+    
+    return fmt.Errorf("glossary entry not found: %s", entry.ID)
+}
+
+// DeleteGlossaryEntry removes a glossary entry by ID
+func (a *App) DeleteGlossaryEntry(projectID, entryID string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    
+    glossary := project.Settings.Translation.Glossary
+    for i, existing := range glossary {
+        if existing.ID == entryID {
+            project.Settings.Translation.Glossary = append(glossary[:i], glossary[i+1:]...)
+            return a.UpdateProject(project)
+        }
+    }
+    
+    return fmt.Errorf("glossary entry not found: %s", entryID)
+}
+
+func validateGlossaryEntry(entry GlossaryEntry) error {
+    if strings.TrimSpace(entry.SourceTerm) == "" {
+        return fmt.Errorf("glossary source term is empty")
+    }
+    if strings.TrimSpace(entry.TargetTerm) == "" {
+        return fmt.Errorf("glossary target term is empty")
+    }
+    return nil
+}
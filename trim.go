@@ -0,0 +1,185 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "strconv"
+    "strings"
+)
+
+// trimmed reports whether the project dubs only part of its source
+func (p *ProjectConfig) trimmed() bool {
+    return p.TrimStart != nil || p.TrimEnd != nil
+}
+
+// sourceFileReference is the media the download step produced or linked
+func sourceFileReference(project *ProjectConfig) *FileReference {
+    if project.FileReferences.VideoFile != nil && !project.AttachedVideo {
+        return project.FileReferences.VideoFile
+    }
+    return project.FileReferences.AudioFile
+}
+
+// trimInputsChanged reports whether the trim range or the source it applies to differs from
+// previous, the saved config
+func trimInputsChanged(projectDir string, previous, project *ProjectConfig) bool {
+    return !sameFloat(previous.TrimStart, project.TrimStart) ||
+        !sameFloat(previous.TrimEnd, project.TrimEnd) ||
+        fileReferenceFingerprint(projectDir, sourceFileReference(previous)) != fileReferenceFingerprint(projectDir, sourceFileReference(project))
+}
+
+func sameFloat(a, b *float64) bool {
+    if a == nil || b == nil {
+        return a == b
+    }
+    return *a == *b
+}
+
+// validateTrim checks that 0 <= TrimStart < TrimEnd <= duration. The duration is only
+// known once the source exists and ffprobe is installed; until then it isn't checked.
+func (a *App) validateTrim(projectDir string, project *ProjectConfig) error {
+    if !project.trimmed() {
+        return nil
+    }
+
+    start := 0.0
+    if project.TrimStart != nil {
+        start = *project.TrimStart
+        if start < 0 {
+            return fmt.Errorf("trim start must not be negative (got %.3fs)", start)
+        }
+    }
+    if project.TrimEnd != nil && *project.TrimEnd <= start {
+        return fmt.Errorf("trim end (%.3fs) must be after trim start (%.3fs)", *project.TrimEnd, start)
+    }
+
+    source := sourceFileReference(project)
+    if !fileReferenceExists(projectDir, source) {
+        return nil
+    }
+    duration, ok, err := a.probeMediaDuration(resolveFileReferencePath(projectDir, source))
+    if err != nil || !ok || duration <= 0 {
+        return nil
+    }
+    if start >= duration {
+        return fmt.Errorf("trim start (%.3fs) is past the end of the source (%.3fs)", start, duration)
+    }
+    if project.TrimEnd != nil && *project.TrimEnd > duration {
+        return fmt.Errorf("trim end (%.3fs) is past the end of the source (%.3fs)", *project.TrimEnd, duration)
+    }
+    return nil
+}
+
+// trimSource clips the downloaded source to the project's trim range so transcription and
+// every later step only see that part. Without a trim range any earlier clip is removed.
+func (a *App) trimSource(projectID string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+
+    if previous := project.FileReferences.TrimmedFile; previous != nil {
+        os.Remove(filepath.Join(projectDir, *previous))
+        project.FileReferences.TrimmedFile = nil
+    }
+    if !project.trimmed() {
+        return a.UpdateProject(project)
+    }
+    if err := a.validateTrim(projectDir, project); err != nil {
+        return err
+    }
+
+    source := sourceFileReference(project)
+    if source == nil {
+        return fmt.Errorf("no source media to trim")
+    }
+    sourcePath := resolveFileReferencePath(projectDir, source)
+
+    ffmpeg, err := exec.LookPath("ffmpeg")
+    if err != nil {
+        return fmt.Errorf("ffmpeg is required to trim the source: %w", err)
+    }
+
+    ext := filepath.Ext(sourcePath)
+    name := strings.TrimSuffix(filepath.Base(sourcePath), ext) + "_trimmed" + ext
+    relPath := filepath.Join("input", name)
+    outPath := filepath.Join(projectDir, relPath)
+    if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+        return fmt.Errorf("failed to create input directory: %w", err)
+    }
+    tmpPath := filepath.Join(filepath.Dir(outPath), ".tmp_"+name)
+    defer os.Remove(tmpPath)
+
+    // Re-encode rather than stream-copy: a copy can only cut on keyframes, which would
+    // shift every transcript timestamp. Keep all audio tracks so SourceAudioStream still applies.
+    args := []string{"-y", "-v", "error"}
+    if project.TrimStart != nil {
+        args = append(args, "-ss", strconv.FormatFloat(*project.TrimStart, 'f', 3, 64))
+    }
+    args = append(args, "-i", sourcePath)
+    if project.TrimEnd != nil {
+        start := 0.0
+        if project.TrimStart != nil {
+            start = *project.TrimStart
+        }
+        args = append(args, "-t", strconv.FormatFloat(*project.TrimEnd-start, 'f', 3, 64))
+    }
+    args = append(args, "-map", "0:v?", "-map", "0:a?", "-f", ffmpegFormatForExt(ext), tmpPath)
+
+    cmd := a.trackedCommand(ffmpeg, args...)
+    defer a.untrackCommand(cmd)
+    if output, err := a.commandCombinedOutput(cmd); err != nil {
+        return fmt.Errorf("failed to trim source: %v\n%s", err, strings.TrimSpace(string(output)))
+    }
+    if err := a.validateMediaFile(tmpPath); err != nil {
+        return fmt.Errorf("trimmed source is invalid: %w", err)
+    }
+    if err := os.Rename(tmpPath, outPath); err != nil {
+        return fmt.Errorf("failed to save trimmed source: %w", err)
+    }
+
+    project.FileReferences.TrimmedFile = &relPath
+    return a.UpdateProject(project)
+}
+
+// ffmpegFormatForExt names the muxer for a file extension, since the temporary file's
+// name doesn't let ffmpeg guess it
+func ffmpegFormatForExt(ext string) string {
+    switch strings.ToLower(strings.TrimPrefix(ext, ".")) {
+    case "mkv":
+        return "matroska"
+    case "m4a", "m4v", "mov":
+        return "mp4"
+    case "":
+        return "mp4"
+    default:
+        return strings.ToLower(strings.TrimPrefix(ext, "."))
+    }
+}
+
+// requireTrimmedSource stops later steps from running on the full source when the clip
+// they should use is missing
+func (a *App) requireTrimmedSource(projectID string) error {
+    project, err := a.LoadProject(projectID)
+    if err != nil {
+        return fmt.Errorf("failed to load project: %w", err)
+    }
+    if !project.trimmed() {
+        return nil
+    }
+    projectDir, err := a.findProjectDirectory(projectID)
+    if err != nil {
+        return fmt.Errorf("project not found: %w", err)
+    }
+    trimmed := project.FileReferences.TrimmedFile
+    if trimmed == nil || !fileExists(filepath.Join(projectDir, *trimmed)) {
+        return fmt.Errorf("the trimmed source hasn't been created; run the download step again")
+    }
+    return nil
+}
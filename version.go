@@ -0,0 +1,4 @@
+package main
+
+// appVersion is set at build time with -ldflags "-X main.appVersion=..."
+var appVersion = "dev"
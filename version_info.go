@@ -0,0 +1,130 @@
+package main
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io/fs"
+    "os"
+    "runtime"
+    "runtime/debug"
+    "strings"
+    "sync"
+)
+
+// VersionInfo identifies exactly what a user is running, for bug reports and the About dialog
+type VersionInfo struct {
+    AppVersion  string `json:"appVersion"`
+    Commit      string `json:"commit,omitempty"`
+    GoVersion   string `json:"goVersion"`
+    Platform    string `json:"platform"`
+    ScriptsHash string `json:"scriptsHash"`
+    // Tools maps each external tool to its detected version; missing tools are left out
+    Tools map[string]string `json:"tools"`
+}
+
+// GetVersionInfo reports the app build, a hash of the bundled Python scripts and the versions
+// of the external tools and Python packages the pipeline uses
+func (a *App) GetVersionInfo() (VersionInfo, error) {
+    info := VersionInfo{
+        AppVersion: appVersion,
+        GoVersion:  runtime.Version(),
+        Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+        Tools:      make(map[string]string),
+    }
+
+    if build, ok := debug.ReadBuildInfo(); ok {
+        for _, setting := range build.Settings {
+            if setting.Key == "vcs.revision" {
+                info.Commit = setting.Value
+            }
+        }
+    }
+
+    hash, err := a.embeddedScriptsHash()
+    if err != nil {
+        return info, fmt.Errorf("failed to hash python scripts: %w", err)
+    }
+    info.ScriptsHash = hash
+
+    // Tools are probed concurrently since each one is a separate process start
+    var mu sync.Mutex
+    var wg sync.WaitGroup
+    record := func(name, version string) {
+        if version == "" {
+            return
+        }
+        mu.Lock()
+        info.Tools[name] = version
+        mu.Unlock()
+    }
+    for _, req := range toolRequirements {
+        command := req.name
+        if req.name == "python" {
+            command = a.getPythonCommand()
+        }
+        wg.Add(1)
+        go func(command string, req toolRequirement) {
+            defer wg.Done()
+            record(req.name, a.checkToolVersion(command, req).Detected)
+        }(command, req)
+    }
+    wg.Add(1)
+    go func() {
+        defer wg.Done()
+        record("whisperx", a.pythonPackageVersion("whisperx"))
+    }()
+    wg.Wait()
+
+    return info, nil
+}
+
+// embeddedScriptsHash hashes the Python scripts built into the binary, which is what gets
+// extracted at startup, so two installs with the same hash run identical pipeline code
+func (a *App) embeddedScriptsHash() (string, error) {
+    a.scriptsHashMu.Lock()
+    defer a.scriptsHashMu.Unlock()
+    if a.scriptsHash != "" {
+        return a.scriptsHash, nil
+    }
+
+    h := sha256.New()
+    // WalkDir visits entries in lexical order, so the hash is stable
+    err := fs.WalkDir(pythonScripts, "python", func(path string, d fs.DirEntry, err error) error {
+        if err != nil {
+            return err
+        }
+        if d.IsDir() || strings.HasSuffix(path, ".pyc") {
+            return nil
+        }
+        data, err := pythonScripts.ReadFile(path)
+        if err != nil {
+            return err
+        }
+        fmt.Fprintf(h, "%s\x00%d\x00", path, len(data))
+        h.Write(data)
+        return nil
+    })
+    if err != nil {
+        return "", err
+    }
+
+    a.scriptsHash = hex.EncodeToString(h.Sum(nil))[:16]
+    return a.scriptsHash, nil
+}
+
+// pythonPackageVersion returns an installed package's version, or "" if it isn't installed
+func (a *App) pythonPackageVersion(pkg string) string {
+    pythonDir := a.getPythonScriptsDir()
+    cmd := a.trackedCommand(a.getPythonCommand(), "-c",
+        "import importlib.metadata, sys; print(importlib.metadata.version(sys.argv[1]))", pkg)
+    defer a.untrackCommand(cmd)
+    cmd.Dir = pythonDir
+    cmd.Env = append(os.Environ(), fmt.Sprintf("PYTHONPATH=%s", pythonDir))
+
+    output, err := a.commandOutput(cmd)
+    if err != nil {
+        return ""
+    }
+    return firstLine(strings.TrimSpace(string(output)))
+}
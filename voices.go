@@ -0,0 +1,113 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+)
+
+// VoiceInfo describes one synthesis voice
+type VoiceInfo struct {
+    ID       string `json:"id"`
+    Language string `json:"language"`
+    Gender   string `json:"gender,omitempty"`
+    // Custom voices come from the CustomVoicesPath directory and are synthesized locally
+    Custom   bool   `json:"custom,omitempty"`
+}
+
+// voiceWeightPattern strips the "(0.6)" weight from a component of a blended voice
+var voiceWeightPattern = regexp.MustCompile(`\([^)]*\)$`)
+
+// ListAvailableVoices returns the voices the synthesis backend offers, with their language
+func (a *App) ListAvailableVoices() ([]VoiceInfo, error) {
+    pythonDir := a.getPythonScriptsDir()
+    cmd := a.trackedCommand(a.getPythonCommand(), filepath.Join(pythonDir, "list_voices.py"))
+    defer a.untrackCommand(cmd)
+    cmd.Dir = pythonDir
+    cmd.Env = append(os.Environ(), fmt.Sprintf("PYTHONPATH=%s", pythonDir))
+
+    var stderr bytes.Buffer
+    cmd.Stderr = &stderr
+    output, runErr := a.commandOutput(cmd)
+
+    var result struct {
+        Voices []VoiceInfo `json:"voices"`
+        Error  string      `json:"error"`
+    }
+    if err := parseTrailingJSON(output, &result); err != nil {
+        if runErr != nil {
+            return nil, fmt.Errorf("failed to list voices: %v\nOutput: %s", runErr, stderr.String())
+        }
+        return nil, fmt.Errorf("failed to parse voice list: %w", err)
+    }
+    if result.Error != "" {
+        return nil, fmt.Errorf("failed to list voices: %s", result.Error)
+    }
+
+    // A custom pack named like a built-in voice takes its place
+    voices := result.Voices
+    for _, custom := range a.customVoiceInfos() {
+        replaced := false
+        for i := range voices {
+            if voices[i].ID == custom.ID {
+                voices[i] = custom
+                replaced = true
+            }
+        }
+        if !replaced {
+            voices = append(voices, custom)
+        }
+    }
+    return voices, nil
+}
+
+// ValidateVoiceLanguage checks a voice can speak the given language. Blended voices such as
+// "em_alex(0.6)+im_nicola(0.8)" are pronounced by their first component, so that one decides.
+func (a *App) ValidateVoiceLanguage(voice, langCode string) error {
+    components := strings.Split(strings.TrimSpace(voice), "+")
+    if voice == "" || components[0] == "" {
+        return fmt.Errorf("no voice selected")
+    }
+
+    voices, err := a.ListAvailableVoices()
+    if err != nil {
+        return err
+    }
+    byID := make(map[string]VoiceInfo, len(voices))
+    for _, v := range voices {
+        byID[v.ID] = v
+    }
+
+    var primary VoiceInfo
+    for i, component := range components {
+        id := strings.TrimSpace(voiceWeightPattern.ReplaceAllString(strings.TrimSpace(component), ""))
+        info, ok := byID[id]
+        if !ok {
+            return fmt.Errorf("unknown voice: %s", id)
+        }
+        if info.Custom && len(components) > 1 {
+            return fmt.Errorf("custom voice %s can't be blended with other voices", id)
+        }
+        if i == 0 {
+            primary = info
+        }
+    }
+
+    if primary.Language == langCode {
+        return nil
+    }
+
+    var compatible []string
+    for _, v := range voices {
+        if v.Language == langCode {
+            compatible = append(compatible, v.ID)
+        }
+    }
+    if len(compatible) == 0 {
+        return fmt.Errorf("voice %s speaks %s, and no available voice speaks %s", primary.ID, primary.Language, langCode)
+    }
+    return fmt.Errorf("voice %s speaks %s, not %s; compatible voices: %s", primary.ID, primary.Language, langCode, strings.Join(compatible, ", "))
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "net/url"
+    "time"
+)
+
+const (
+    webhookTimeout    = 10 * time.Second
+    webhookAttempts   = 2
+    webhookRetryDelay = 3 * time.Second
+)
+
+// PipelineCompletion is the JSON body POSTed to the completion webhook
+type PipelineCompletion struct {
+    Event           string             `json:"event"`
+    ProjectID       string             `json:"projectId"`
+    ProjectName     string             `json:"projectName"`
+    Status          string             `json:"status"`
+    FailedStep      string             `json:"failedStep,omitempty"`
+    Error           string             `json:"error,omitempty"`
+    OutputPath      string             `json:"outputPath,omitempty"`
+    StartedAt       string             `json:"startedAt"`
+    FinishedAt      string             `json:"finishedAt"`
+    DurationSeconds float64            `json:"durationSeconds"`
+    StepDurations   map[string]float64 `json:"stepDurations"`
+    Warnings        []PipelineWarning  `json:"warnings"`
+}
+
+// validateWebhookURL accepts empty (disabled) or an absolute http(s) URL
+func validateWebhookURL(raw *string) error {
+    if raw == nil || *raw == "" {
+        return nil
+    }
+    u, err := url.Parse(*raw)
+    if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+        return fmt.Errorf("completion webhook must be an http or https URL: %s", *raw)
+    }
+    return nil
+}
+
+// notifyPipelineCompletion emits the completion summary as a "pipeline:completed" event and
+// posts it to the configured webhook in the background, so a slow or unreachable endpoint
+// never holds up the caller
+func (a *App) notifyPipelineCompletion(completion PipelineCompletion) {
+    a.emitEvent("pipeline:completed", completion)
+    a.recordLastRun(completion)
+
+    settings, err := a.GetAppSettings()
+    if err != nil || settings.CompletionWebhookURL == nil || *settings.CompletionWebhookURL == "" {
+        return
+    }
+    webhookURL := *settings.CompletionWebhookURL
+
+    projectDir, err := a.findProjectDirectory(completion.ProjectID)
+    if err != nil {
+        projectDir = ""
+    }
+
+    go func() {
+        err := postWebhook(webhookURL, completion)
+        if projectDir == "" {
+            return
+        }
+        if err != nil {
+            appendProjectLog(projectDir, "webhook: delivery of %s to %s failed: %v", completion.Event, webhookURL, err)
+        } else {
+            appendProjectLog(projectDir, "webhook: delivered %s to %s", completion.Event, webhookURL)
+        }
+    }()
+}
+
+// postWebhook sends the payload, retrying once on failure
+func postWebhook(webhookURL string, payload interface{}) error {
+    body, err := json.Marshal(payload)
+    if err != nil {
+        return err
+    }
+
+    client := &http.Client{Timeout: webhookTimeout}
+    for attempt := 1; ; attempt++ {
+        err = postWebhookOnce(client, webhookURL, body)
+        if err == nil || attempt >= webhookAttempts {
+            return err
+        }
+        time.Sleep(webhookRetryDelay)
+    }
+}
+
+func postWebhookOnce(client *http.Client, webhookURL string, body []byte) error {
+    resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        return fmt.Errorf("endpoint returned %s", resp.Status)
+    }
+    return nil
+}